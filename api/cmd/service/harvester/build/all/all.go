@@ -1,24 +1,44 @@
 package all
 
 import (
+	"github.com/godwinrob/harvester/api/domain/http/alertapi"
+	"github.com/godwinrob/harvester/api/domain/http/auditapi"
 	"github.com/godwinrob/harvester/api/domain/http/galaxyapi"
+	"github.com/godwinrob/harvester/api/domain/http/importapi"
+	"github.com/godwinrob/harvester/api/domain/http/planetapi"
 	"github.com/godwinrob/harvester/api/domain/http/resourceapi"
 	"github.com/godwinrob/harvester/api/domain/http/resourcegroupapi"
 	"github.com/godwinrob/harvester/api/domain/http/resourcetypeapi"
+	"github.com/godwinrob/harvester/api/domain/http/schematicapi"
+	"github.com/godwinrob/harvester/api/domain/http/searchapi"
 	"github.com/godwinrob/harvester/api/domain/http/userapi"
+	"github.com/godwinrob/harvester/api/domain/http/webhookapi"
+	"github.com/godwinrob/harvester/api/sdk/http/mux"
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+	"github.com/godwinrob/harvester/business/domain/alertbus/stores/alertdb"
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/domain/auditbus/stores/auditdb"
 	"github.com/godwinrob/harvester/business/domain/galaxybus"
 	"github.com/godwinrob/harvester/business/domain/galaxybus/stores/galaxydb"
+	"github.com/godwinrob/harvester/business/domain/importbus"
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/domain/planetbus/stores/planetdb"
 	"github.com/godwinrob/harvester/business/domain/resourcebus"
 	"github.com/godwinrob/harvester/business/domain/resourcebus/stores/resourcedb"
 	"github.com/godwinrob/harvester/business/domain/resourcegroupbus"
 	"github.com/godwinrob/harvester/business/domain/resourcegroupbus/stores/resourcegroupdb"
 	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
 	"github.com/godwinrob/harvester/business/domain/resourcetypebus/stores/resourcetypedb"
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+	"github.com/godwinrob/harvester/business/domain/schematicbus/stores/schematicdb"
+	"github.com/godwinrob/harvester/business/domain/searchbus"
+	"github.com/godwinrob/harvester/business/domain/searchbus/stores/searchdb"
 	"github.com/godwinrob/harvester/business/domain/userbus"
 	"github.com/godwinrob/harvester/business/domain/userbus/stores/userdb"
-	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/business/domain/webhookbus"
+	"github.com/godwinrob/harvester/business/domain/webhookbus/stores/webhookdb"
+	"github.com/godwinrob/harvester/business/sdk/delegate"
 	"github.com/godwinrob/harvester/foundation/web"
-	"github.com/jmoiron/sqlx"
 )
 
 // Routes constructs the add value which provides the implementation of
@@ -30,30 +50,104 @@ func Routes() add {
 type add struct{}
 
 // Add implements the RouterAdder interface.
-func (a add) Add(log *logger.Logger, db *sqlx.DB, app *web.App) {
+func (a add) Add(app *web.App, cfg mux.Config) {
+	log := cfg.Log
+	db := cfg.DB
+
+	// The delegate lets a domain react to actions in another domain without
+	// importing it.
+	dlg := delegate.New(log)
+
+	userBus := userbus.NewBusiness(log, userdb.NewStore(log, db, auditdb.NewRecorder(log, auditbus.Entities.User)))
 
 	userapi.Routes(app, userapi.Config{
-		Log:     log,
-		UserBus: userbus.NewBusiness(log, userdb.NewStore(log, db)),
+		Log:        log,
+		UserBus:    userBus,
+		Auth:       cfg.Auth,
+		PageLimits: cfg.PageLimits,
 	})
 
+	galaxyBus := galaxybus.NewBusiness(log, userBus, galaxydb.NewStore(log, db, auditdb.NewRecorder(log, auditbus.Entities.Galaxy), auditdb.NewRecorder(log, auditbus.Entities.GalaxyMember)))
+
 	galaxyapi.Routes(app, galaxyapi.Config{
-		Log:       log,
-		GalaxyBus: galaxybus.NewBusiness(log, galaxydb.NewStore(log, db)),
+		Log:        log,
+		GalaxyBus:  galaxyBus,
+		Auth:       cfg.Auth,
+		PageLimits: cfg.PageLimits,
 	})
 
+	resourceTypeBus := resourcetypebus.NewBusiness(log, resourcetypedb.NewStore(log, db, auditdb.NewRecorder(log, auditbus.Entities.ResourceType)))
+	resourceGroupBus := resourcegroupbus.NewBusiness(log, resourcegroupdb.NewStore(log, db))
+	planetBus := planetbus.NewBusiness(log, planetdb.NewStore(log, db))
+	resourceBus := resourcebus.NewBusiness(log, dlg, galaxyBus, resourceTypeBus, planetBus, resourcedb.NewStore(log, db, webhookdb.NewEnqueuer(log), auditdb.NewRecorder(log, auditbus.Entities.Resource)))
+
 	resourceapi.Routes(app, resourceapi.Config{
 		Log:         log,
-		ResourceBus: resourcebus.NewBusiness(log, resourcedb.NewStore(log, db)),
+		ResourceBus: resourceBus,
+		GalaxyBus:   galaxyBus,
+		Auth:        cfg.Auth,
+		PageLimits:  cfg.PageLimits,
 	})
 
 	resourcetypeapi.Routes(app, resourcetypeapi.Config{
 		Log:             log,
-		ResourceTypeBus: resourcetypebus.NewBusiness(log, resourcetypedb.NewStore(log, db)),
+		ResourceTypeBus: resourceTypeBus,
+		Auth:            cfg.Auth,
+		PageLimits:      cfg.PageLimits,
 	})
 
 	resourcegroupapi.Routes(app, resourcegroupapi.Config{
 		Log:              log,
-		ResourceGroupBus: resourcegroupbus.NewBusiness(log, resourcegroupdb.NewStore(log, db)),
+		ResourceGroupBus: resourceGroupBus,
+		Auth:             cfg.Auth,
+		PageLimits:       cfg.PageLimits,
+	})
+
+	planetapi.Routes(app, planetapi.Config{
+		Log:        log,
+		PlanetBus:  planetBus,
+		Auth:       cfg.Auth,
+		PageLimits: cfg.PageLimits,
+	})
+
+	schematicapi.Routes(app, schematicapi.Config{
+		Log:          log,
+		SchematicBus: schematicbus.NewBusiness(log, resourceBus, resourceTypeBus, resourceGroupBus, schematicdb.NewStore(log, db)),
+		Auth:         cfg.Auth,
+		PageLimits:   cfg.PageLimits,
+	})
+
+	searchapi.Routes(app, searchapi.Config{
+		Log:       log,
+		SearchBus: searchbus.NewBusiness(log, searchdb.NewStore(log, db)),
+		Auth:      cfg.Auth,
+	})
+
+	alertapi.Routes(app, alertapi.Config{
+		Log:        log,
+		AlertBus:   alertbus.NewBusiness(log, dlg, galaxyBus, resourceBus, resourceTypeBus, resourceGroupBus, alertdb.NewStore(log, db)),
+		Auth:       cfg.Auth,
+		PageLimits: cfg.PageLimits,
+	})
+
+	webhookapi.Routes(app, webhookapi.Config{
+		Log:        log,
+		WebhookBus: webhookbus.NewBusiness(log, webhookdb.NewStore(log, db)),
+		GalaxyBus:  galaxyBus,
+		Auth:       cfg.Auth,
+	})
+
+	importapi.Routes(app, importapi.Config{
+		Log:       log,
+		ImportBus: importbus.NewBusiness(log, galaxyBus, resourceBus, resourceTypeBus, planetBus),
+		GalaxyBus: galaxyBus,
+		Auth:      cfg.Auth,
+	})
+
+	auditapi.Routes(app, auditapi.Config{
+		Log:        log,
+		AuditBus:   auditbus.NewBusiness(log, auditdb.NewStore(log, db)),
+		Auth:       cfg.Auth,
+		PageLimits: cfg.PageLimits,
 	})
 }
@@ -13,10 +13,22 @@ import (
 	"time"
 
 	"github.com/godwinrob/harvester/api/cmd/service/harvester/build/all"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/domain/auditbus/stores/auditdb"
+	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/godwinrob/harvester/business/domain/userbus/stores/userdb"
+	"github.com/godwinrob/harvester/business/domain/webhookbus"
+	"github.com/godwinrob/harvester/business/domain/webhookbus/stores/webhookdb"
+	"github.com/godwinrob/harvester/business/sdk/ratelimit"
+	"github.com/godwinrob/harvester/business/sdk/ratelimit/stores/ratelimitdb"
 	"github.com/godwinrob/harvester/business/sdk/sqldb"
-	"github.com/godwinrob/harvester/foundation/web"
+	"github.com/godwinrob/harvester/foundation/otel"
 
 	conf "github.com/ardanlabs/conf/v3"
+	"github.com/godwinrob/harvester/api/sdk/http/debug"
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
 	"github.com/godwinrob/harvester/api/sdk/http/mux"
 	"github.com/godwinrob/harvester/foundation/logger"
 )
@@ -33,7 +45,7 @@ func main() {
 	}
 
 	traceIDFn := func(ctx context.Context) string {
-		return web.GetTraceID(ctx)
+		return otel.GetTraceID(ctx)
 	}
 
 	log = logger.NewWithEvents(os.Stdout, logger.LevelInfo, "SALES", traceIDFn, events)
@@ -78,6 +90,36 @@ func run(ctx context.Context, log *logger.Logger) error {
 			MaxOpenConns int    `conf:"default:0"`
 			DisableTLS   bool   `conf:"default:true"`
 		}
+		Auth struct {
+			Secret        string        `conf:"mask"`
+			Issuer        string        `conf:"default:harvester"`
+			TokenDuration time.Duration `conf:"default:24h"`
+		}
+		Page struct {
+			DefaultRows int `conf:"default:10"`
+			MinRows     int `conf:"default:1"`
+			MaxRows     int `conf:"default:100"`
+		}
+		RateLimit struct {
+			Store             string        `conf:"default:memory"`
+			Period            time.Duration `conf:"default:1m"`
+			ReadLimit         int           `conf:"default:600"`
+			WriteLimit        int           `conf:"default:120"`
+			BulkLimit         int           `conf:"default:10"`
+			TrustForwardedFor bool          `conf:"default:false"`
+			FailOpen          bool          `conf:"default:true"`
+		}
+		Tracing struct {
+			Host        string
+			ServiceName string  `conf:"default:harvester"`
+			Probability float64 `conf:"default:0.05"`
+		}
+		Webhook struct {
+			Interval    time.Duration `conf:"default:5s"`
+			BatchSize   int           `conf:"default:50"`
+			MaxAttempts int           `conf:"default:8"`
+			Timeout     time.Duration `conf:"default:10s"`
+		}
 	}{
 		Version: conf.Version{
 			Build: build,
@@ -118,7 +160,29 @@ func run(ctx context.Context, log *logger.Logger) error {
 	log.Info(ctx, "starting service", "version", cfg.Build)
 	defer slog.Info("shutdown complete")
 
-	slog.Info("startup", "config", fmt.Sprintf("%+v", cfg))
+	out, err := conf.String(&cfg)
+	if err != nil {
+		return fmt.Errorf("generating config for output: %w", err)
+	}
+	slog.Info("startup", "config", out)
+
+	// -------------------------------------------------------------------------
+	// Start Tracing Support
+
+	log.Info(ctx, "startup", "status", "initializing tracing support", "host", cfg.Tracing.Host)
+
+	traceProvider, teardown, err := otel.InitTracing(log, otel.Config{
+		ServiceName: cfg.Tracing.ServiceName,
+		Host:        cfg.Tracing.Host,
+		Probability: cfg.Tracing.Probability,
+	})
+	if err != nil {
+		return fmt.Errorf("starting tracing: %w", err)
+	}
+
+	defer teardown(context.Background())
+
+	tracer := traceProvider.Tracer(cfg.Tracing.ServiceName)
 
 	// -------------------------------------------------------------------------
 	// Database Support
@@ -137,17 +201,129 @@ func run(ctx context.Context, log *logger.Logger) error {
 		return fmt.Errorf("failed to ping db: %w", err)
 	}
 
+	// -------------------------------------------------------------------------
+	// Auth Support
+
+	log.Info(ctx, "startup", "status", "initializing authentication support")
+
+	ath, err := auth.New(auth.Config{
+		Log:      log,
+		UserBus:  userbus.NewBusiness(log, userdb.NewStore(log, db, auditdb.NewRecorder(log, auditbus.Entities.User))),
+		Secret:   cfg.Auth.Secret,
+		Issuer:   cfg.Auth.Issuer,
+		Duration: cfg.Auth.TokenDuration,
+	})
+	if err != nil {
+		return fmt.Errorf("constructing auth: %w", err)
+	}
+
+	// -------------------------------------------------------------------------
+	// Start Debug Service
+
+	log.Info(ctx, "startup", "status", "debug router started", "host", cfg.Web.DebugHost)
+
+	// The debug server has no write timeout, a CPU profile or trace streams
+	// for as long as it was asked to run.
+	dbg := http.Server{
+		Addr:        cfg.Web.DebugHost,
+		Handler:     debug.Mux(debug.Config{Build: build, Log: log, DB: db}),
+		ReadTimeout: cfg.Web.ReadTimeout,
+		IdleTimeout: cfg.Web.IdleTimeout,
+		ErrorLog:    logger.NewStdLogger(log, logger.LevelError),
+	}
+
+	go func() {
+		if err := dbg.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error(ctx, "shutdown", "status", "debug router closed", "host", dbg.Addr, "msg", err)
+		}
+	}()
+
+	defer dbg.Close()
+
+	// -------------------------------------------------------------------------
+	// Start Webhook Dispatcher
+
+	log.Info(ctx, "startup", "status", "initializing webhook dispatcher")
+
+	dispatcher := webhookbus.NewDispatcher(log, webhookdb.NewStore(log, db), webhookbus.DispatcherConfig{
+		Interval:    cfg.Webhook.Interval,
+		BatchSize:   cfg.Webhook.BatchSize,
+		MaxAttempts: cfg.Webhook.MaxAttempts,
+		Timeout:     cfg.Webhook.Timeout,
+	})
+
+	dispatchCtx, stopDispatch := context.WithCancel(ctx)
+	dispatchDone := make(chan struct{})
+
+	go func() {
+		defer close(dispatchDone)
+		dispatcher.Run(dispatchCtx)
+	}()
+
+	defer func() {
+		stopDispatch()
+		<-dispatchDone
+	}()
+
+	// -------------------------------------------------------------------------
+	// Rate Limit Support
+
+	log.Info(ctx, "startup", "status", "initializing rate limit support", "store", cfg.RateLimit.Store)
+
+	var rateStore ratelimit.Storer
+	switch cfg.RateLimit.Store {
+	case "memory":
+		rateStore = ratelimit.NewMemoryStore(cfg.RateLimit.Period)
+	case "postgres":
+		rateStore = ratelimitdb.NewStore(log, db, cfg.RateLimit.Period)
+	default:
+		return fmt.Errorf("unknown rate limit store %q, expected memory or postgres", cfg.RateLimit.Store)
+	}
+
+	rateLimits := mid.RateLimits{
+		Limiter:           ratelimit.NewLimiter(rateStore),
+		TrustForwardedFor: cfg.RateLimit.TrustForwardedFor,
+		FailOpen:          cfg.RateLimit.FailOpen,
+	}
+
+	if rateLimits.Read, err = ratelimit.NewBudget("read", cfg.RateLimit.ReadLimit, cfg.RateLimit.Period); err != nil {
+		return fmt.Errorf("constructing rate limits: %w", err)
+	}
+
+	if rateLimits.Write, err = ratelimit.NewBudget("write", cfg.RateLimit.WriteLimit, cfg.RateLimit.Period); err != nil {
+		return fmt.Errorf("constructing rate limits: %w", err)
+	}
+
+	if rateLimits.Bulk, err = ratelimit.NewBudget("bulk", cfg.RateLimit.BulkLimit, cfg.RateLimit.Period); err != nil {
+		return fmt.Errorf("constructing rate limits: %w", err)
+	}
+
 	// -------------------------------------------------------------------------
 	// Start API Service
 
 	log.Info(ctx, "startup", "status", "initializing V1 API support")
 
+	pageLimits, err := page.NewLimits(cfg.Page.DefaultRows, cfg.Page.MinRows, cfg.Page.MaxRows)
+	if err != nil {
+		return fmt.Errorf("constructing page limits: %w", err)
+	}
+
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
 
+	cfgMux := mux.Config{
+		Log:                log,
+		DB:                 db,
+		Tracer:             tracer,
+		Auth:               ath,
+		PageLimits:         pageLimits,
+		CORSAllowedOrigins: cfg.Web.CORSAllowedOrigins,
+		RateLimits:         rateLimits,
+	}
+
 	api := http.Server{
 		Addr:         cfg.Web.APIHost,
-		Handler:      mux.WebAPI(log, db, all.Routes()),
+		Handler:      mux.WebAPI(cfgMux, all.Routes()),
 		ReadTimeout:  cfg.Web.ReadTimeout,
 		WriteTimeout: cfg.Web.WriteTimeout,
 		IdleTimeout:  cfg.Web.IdleTimeout,
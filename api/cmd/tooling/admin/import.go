@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+	"github.com/godwinrob/harvester/business/domain/alertbus/stores/alertdb"
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/domain/auditbus/stores/auditdb"
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/galaxybus/stores/galaxydb"
+	"github.com/godwinrob/harvester/business/domain/importbus"
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/domain/planetbus/stores/planetdb"
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/domain/resourcebus/stores/resourcedb"
+	"github.com/godwinrob/harvester/business/domain/resourcegroupbus"
+	"github.com/godwinrob/harvester/business/domain/resourcegroupbus/stores/resourcegroupdb"
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus/stores/resourcetypedb"
+	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/godwinrob/harvester/business/domain/userbus/stores/userdb"
+	"github.com/godwinrob/harvester/business/domain/webhookbus/stores/webhookdb"
+	"github.com/godwinrob/harvester/business/sdk/delegate"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Import loads the resources of a Galaxy Harvester or SWGAide export file
+// into a galaxy, crediting them to a user, and prints the rows that were not
+// accepted.
+func Import(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	galaxy := fs.String("galaxy", "", "name or id of the galaxy to import into")
+	email := fs.String("user", "", "email of the user the resources are added by")
+	format := fs.String("format", "", "gh-xml or swgaide-csv, by default taken from the file extension")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *galaxy == "" || *email == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("a galaxy, a user and one file are required")
+	}
+
+	path := fs.Arg(0)
+
+	if *format == "" {
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".xml":
+			*format = importbus.Formats.GalaxyHarvester.String()
+		case ".csv":
+			*format = importbus.Formats.SWGAide.String()
+		}
+	}
+
+	f, err := importbus.Formats.Parse(*format)
+	if err != nil {
+		return err
+	}
+
+	addr, err := mail.ParseAddress(*email)
+	if err != nil {
+		return fmt.Errorf("parse user email: %w", err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open file: %w", err)
+	}
+	defer file.Close()
+
+	db, err := openDB()
+	if err != nil {
+		return err
+	}
+
+	defer func(db *sqlx.DB) {
+		err := db.Close()
+		if err != nil {
+			slog.Error("close-db", "error", err)
+		}
+	}(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	log := logger.New(os.Stderr, logger.LevelWarn, "ADMIN", func(context.Context) string { return "" })
+
+	// The alert domain registers with the delegate so an import from here
+	// raises alerts like one through the API. Webhook deliveries are queued
+	// by the resource store.
+	dlg := delegate.New(log)
+
+	userBus := userbus.NewBusiness(log, userdb.NewStore(log, db, auditdb.NewRecorder(log, auditbus.Entities.User)))
+	galaxyBus := galaxybus.NewBusiness(log, userBus, galaxydb.NewStore(log, db, auditdb.NewRecorder(log, auditbus.Entities.Galaxy), auditdb.NewRecorder(log, auditbus.Entities.GalaxyMember)))
+	resourceTypeBus := resourcetypebus.NewBusiness(log, resourcetypedb.NewStore(log, db, auditdb.NewRecorder(log, auditbus.Entities.ResourceType)))
+	resourceGroupBus := resourcegroupbus.NewBusiness(log, resourcegroupdb.NewStore(log, db))
+	planetBus := planetbus.NewBusiness(log, planetdb.NewStore(log, db))
+	resourceBus := resourcebus.NewBusiness(log, dlg, galaxyBus, resourceTypeBus, planetBus, resourcedb.NewStore(log, db, webhookdb.NewEnqueuer(log), auditdb.NewRecorder(log, auditbus.Entities.Resource)))
+	alertbus.NewBusiness(log, dlg, galaxyBus, resourceBus, resourceTypeBus, resourceGroupBus, alertdb.NewStore(log, db))
+	importBus := importbus.NewBusiness(log, galaxyBus, resourceBus, resourceTypeBus, planetBus)
+
+	usr, err := userBus.QueryByEmail(ctx, *addr)
+	if err != nil {
+		return fmt.Errorf("query user: %w", err)
+	}
+
+	// The resources loaded are audited as added by the importing user.
+	ctx = auditbus.SetActor(ctx, usr.ID)
+
+	var gal galaxybus.Galaxy
+	if id, err := uuid.Parse(*galaxy); err == nil {
+		gal, err = galaxyBus.QueryByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("query galaxy: %w", err)
+		}
+	} else {
+		gal, err = galaxyBus.QueryByName(ctx, *galaxy)
+		if err != nil {
+			return fmt.Errorf("query galaxy: %w", err)
+		}
+	}
+
+	ni := importbus.NewImport{
+		GalaxyID: gal.ID,
+		UserID:   usr.ID,
+		Format:   f,
+		Data:     file,
+	}
+
+	// A failed import still reports the rows loaded before it failed.
+	rpt, err := importBus.Import(ctx, ni)
+	if err != nil && rpt.Rows == nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	for _, row := range rpt.Rows {
+		switch row.Status {
+		case importbus.Statuses.Duplicate:
+			fmt.Printf("line %d: %s: %s\n", row.Line, row.Status, row.Name)
+		case importbus.Statuses.Rejected:
+			fmt.Printf("line %d: %s: %s: %s\n", row.Line, row.Status, row.Name, strings.Join(row.Errors, "; "))
+		}
+	}
+
+	fmt.Printf("imported into %s: %d accepted, %d duplicates, %d rejected, %d not attempted\n", gal.Name, rpt.Accepted, rpt.Duplicates, rpt.Rejected, rpt.NotAttempted)
+
+	if err != nil {
+		return fmt.Errorf("import: %w", err)
+	}
+
+	return nil
+}
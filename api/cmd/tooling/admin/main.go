@@ -13,40 +13,45 @@ import (
 )
 
 func main() {
+	cmd := "migrate"
+	if len(os.Args) > 1 {
+		cmd = os.Args[1]
+	}
+
+	switch cmd {
+	case "migrate":
+		slog.Info("migrate", "status", "starting migration in 5 seconds")
+		time.Sleep(8 * time.Second)
+
+		if err := Migrate(); err != nil {
+			slog.Error("migration failed", "error", err)
+			os.Exit(1)
+		}
+
+		slog.Info("migrate", "status", "migration completed")
 
-	slog.Info("migrate", "status", "starting migration in 5 seconds")
-	time.Sleep(8 * time.Second)
+	case "import":
+		if err := Import(os.Args[2:]); err != nil {
+			slog.Error("import failed", "error", err)
+			os.Exit(1)
+		}
 
-	if err := Migrate(); err != nil {
-		slog.Error("migration failed", "error", err)
-		os.Exit(1)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n", cmd)
+		fmt.Fprintln(os.Stderr, "usage: admin [migrate]")
+		fmt.Fprintln(os.Stderr, "       admin import -galaxy <name|id> -user <email> [-format gh-xml|swgaide-csv] <file>")
+		os.Exit(2)
 	}
 
-	slog.Info("migrate", "status", "migration completed")
 	os.Exit(0)
 }
 
 func Migrate() error {
 	slog.Info("migrate", "status", "beginning database migration")
 
-	cfg := sqldb.Config{
-		User:         getEnv("HARVESTER_DB_USER", "postgres"),
-		Password:     getEnv("HARVESTER_DB_PASSWORD", "postgres"),
-		Host:         getEnv("HARVESTER_DB_HOST", "postgres"),
-		Name:         getEnv("HARVESTER_DB_NAME", "postgres"),
-		MaxIdleConns: 0,
-		MaxOpenConns: 0,
-		DisableTLS:   getEnv("HARVESTER_DB_DISABLE_TLS", "true") == "true",
-	}
-
-	// Validate configuration before attempting to connect
-	if err := sqldb.ValidateConfig(cfg); err != nil {
-		return err
-	}
-
-	db, err := sqldb.Open(cfg)
+	db, err := openDB()
 	if err != nil {
-		return fmt.Errorf("connect database: %w", err)
+		return err
 	}
 
 	defer func(db *sqlx.DB) {
@@ -83,6 +88,12 @@ func Migrate() error {
 	}
 	fmt.Println("resource type seed data complete")
 
+	slog.Info("seeding", "status", "inserting planet reference data")
+	if err := migrate.SeedPlanets(ctx, db); err != nil {
+		return fmt.Errorf("seed planets: %w", err)
+	}
+	fmt.Println("planet seed data complete")
+
 	if err := migrate.Seed(ctx, db); err != nil {
 		return fmt.Errorf("seed database: %w", err)
 	}
@@ -105,6 +116,31 @@ func Migrate() error {
 	return nil
 }
 
+// openDB connects to the database configured by the environment.
+func openDB() (*sqlx.DB, error) {
+	cfg := sqldb.Config{
+		User:         getEnv("HARVESTER_DB_USER", "postgres"),
+		Password:     getEnv("HARVESTER_DB_PASSWORD", "postgres"),
+		Host:         getEnv("HARVESTER_DB_HOST", "postgres"),
+		Name:         getEnv("HARVESTER_DB_NAME", "postgres"),
+		MaxIdleConns: 0,
+		MaxOpenConns: 0,
+		DisableTLS:   getEnv("HARVESTER_DB_DISABLE_TLS", "true") == "true",
+	}
+
+	// Validate configuration before attempting to connect
+	if err := sqldb.ValidateConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	db, err := sqldb.Open(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("connect database: %w", err)
+	}
+
+	return db, nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
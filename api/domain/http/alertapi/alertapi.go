@@ -0,0 +1,89 @@
+// Package alertapi maintains the web based api for watch rule and alert
+// access.
+package alertapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/alertapp"
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+type api struct {
+	alertApp *alertapp.App
+}
+
+func newAPI(alertApp *alertapp.App) *api {
+	return &api{
+		alertApp: alertApp,
+	}
+}
+
+func (api *api) createRule(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	var app alertapp.NewRule
+	if err := web.Decode(r, &app); err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	rule, err := api.alertApp.CreateRule(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (api *api) deleteRule(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	if err := api.alertApp.DeleteRule(ctx, web.Param(r, "rule_id")); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (api *api) queryRules(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	rules, err := api.alertApp.QueryRules(ctx, qp)
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (api *api) queryRuleByID(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	rule, err := api.alertApp.QueryRuleByID(ctx, web.Param(r, "rule_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+func (api *api) queryAlerts(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	alerts, err := api.alertApp.QueryAlerts(ctx, qp)
+	if err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+func (api *api) deleteAlert(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	if err := api.alertApp.DeleteAlert(ctx, web.Param(r, "alert_id")); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
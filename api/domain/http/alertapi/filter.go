@@ -0,0 +1,22 @@
+package alertapi
+
+import (
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/alertapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
+)
+
+func parseQueryParams(r *http.Request) (alertapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	filter := alertapp.QueryParams{
+		Page:    values.Get("page"),
+		Rows:    page.Rows(values),
+		OrderBy: values.Get("orderBy"),
+		Cursor:  values.Get("cursor"),
+		RuleID:  values.Get("rule_id"),
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,33 @@
+package alertapi
+
+import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
+	"github.com/godwinrob/harvester/app/domain/alertapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	Log        *logger.Logger
+	AlertBus   *alertbus.Business
+	Auth       *auth.Auth
+	PageLimits page.Limits
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+
+	api := newAPI(alertapp.NewApp(cfg.AlertBus, cfg.PageLimits))
+	app.HandleFunc("GET /v1/alerts", api.queryAlerts, authen, ruleAny)
+	app.HandleFunc("DELETE /v1/alerts/{alert_id}", api.deleteAlert, authen, ruleAny)
+	app.HandleFunc("POST /v1/alerts/rules", api.createRule, authen, ruleAny)
+	app.HandleFunc("GET /v1/alerts/rules", api.queryRules, authen, ruleAny)
+	app.HandleFunc("GET /v1/alerts/rules/{rule_id}", api.queryRuleByID, authen, ruleAny)
+	app.HandleFunc("DELETE /v1/alerts/rules/{rule_id}", api.deleteRule, authen, ruleAny)
+}
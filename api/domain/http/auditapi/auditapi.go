@@ -0,0 +1,35 @@
+// Package auditapi maintains the web based api for audit log access.
+package auditapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/auditapp"
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+type api struct {
+	auditApp *auditapp.App
+}
+
+func newAPI(auditApp *auditapp.App) *api {
+	return &api{
+		auditApp: auditApp,
+	}
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	entries, err := api.auditApp.Query(ctx, qp)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,26 @@
+package auditapi
+
+import (
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/auditapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
+)
+
+func parseQueryParams(r *http.Request) (auditapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	filter := auditapp.QueryParams{
+		Page:       values.Get("page"),
+		Rows:       page.Rows(values),
+		OrderBy:    values.Get("orderBy"),
+		Cursor:     values.Get("cursor"),
+		EntityType: values.Get("entity_type"),
+		EntityID:   values.Get("entity_id"),
+		ActorID:    values.Get("actor_id"),
+		StartDate:  values.Get("start_date"),
+		EndDate:    values.Get("end_date"),
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,28 @@
+package auditapi
+
+import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
+	"github.com/godwinrob/harvester/app/domain/auditapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	Log        *logger.Logger
+	AuditBus   *auditbus.Business
+	Auth       *auth.Auth
+	PageLimits page.Limits
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAdmin := mid.Authorize(cfg.Auth, auth.RuleAdminOnly)
+
+	api := newAPI(auditapp.NewApp(cfg.AuditBus, cfg.PageLimits))
+	app.HandleFunc("GET /v1/audit", api.query, authen, ruleAdmin)
+}
@@ -2,6 +2,7 @@ package galaxyapi
 
 import (
 	"github.com/godwinrob/harvester/app/domain/galaxyapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
 	"net/http"
 )
 
@@ -10,8 +11,9 @@ func parseQueryParams(r *http.Request) (galaxyapp.QueryParams, error) {
 
 	filter := galaxyapp.QueryParams{
 		Page:        values.Get("page"),
-		Rows:        values.Get("row"),
+		Rows:        page.Rows(values),
 		OrderBy:     values.Get("orderBy"),
+		Cursor:      values.Get("cursor"),
 		ID:          values.Get("galaxy_id"),
 		Name:        values.Get("name"),
 		DateCreated: values.Get("date_created"),
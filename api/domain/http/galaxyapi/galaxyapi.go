@@ -79,7 +79,7 @@ func (api *api) queryByID(ctx context.Context, r *http.Request) (web.Encoder, er
 }
 
 func (api *api) queryByName(ctx context.Context, r *http.Request) (web.Encoder, error) {
-	usr, err := api.galaxyApp.QueryByName(ctx, web.Param(r, "name"))
+	usr, err := api.galaxyApp.QueryByName(ctx, r.URL.Query().Get("name"))
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +87,24 @@ func (api *api) queryByName(ctx context.Context, r *http.Request) (web.Encoder,
 	return usr, nil
 }
 
+// queryByPathName serves GET /v1/galaxies/name/{name}, the name lookup clients
+// used before /v1/galaxies/lookup. The path overlaps the routes below a
+// galaxy, so the mux only accepts it as {galaxy_id}/{name} and the galaxy_id
+// must be "name". A galaxy named like one of those routes, such as "members",
+// can only be found through /v1/galaxies/lookup.
+func (api *api) queryByPathName(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	if web.Param(r, "galaxy_id") != "name" {
+		return nil, errs.Newf(errs.NotFound, "no route for %s", r.URL.Path)
+	}
+
+	gal, err := api.galaxyApp.QueryByName(ctx, web.Param(r, "name"))
+	if err != nil {
+		return nil, err
+	}
+
+	return gal, nil
+}
+
 func (api *api) bulkCreate(ctx context.Context, r *http.Request) (web.Encoder, error) {
 	var app galaxyapp.BulkNewGalaxies
 	if err := web.Decode(r, &app); err != nil {
@@ -128,3 +146,51 @@ func (api *api) bulkDelete(ctx context.Context, r *http.Request) (web.Encoder, e
 
 	return result, nil
 }
+
+func (api *api) queryMembers(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	members, err := api.galaxyApp.QueryMembers(ctx, web.Param(r, "galaxy_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (api *api) invite(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	var app galaxyapp.NewMember
+	if err := web.Decode(r, &app); err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	mem, err := api.galaxyApp.Invite(ctx, web.Param(r, "galaxy_id"), app)
+	if err != nil {
+		return nil, err
+	}
+
+	return mem, nil
+}
+
+func (api *api) join(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	mem, err := api.galaxyApp.Join(ctx, web.Param(r, "galaxy_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return mem, nil
+}
+
+func (api *api) leave(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	if err := api.galaxyApp.Leave(ctx, web.Param(r, "galaxy_id")); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (api *api) removeMember(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	if err := api.galaxyApp.RemoveMember(ctx, web.Param(r, "galaxy_id"), web.Param(r, "user_id")); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -1,7 +1,10 @@
 package galaxyapi
 
 import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
 	"github.com/godwinrob/harvester/app/domain/galaxyapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
 	"github.com/godwinrob/harvester/business/domain/galaxybus"
 	"github.com/godwinrob/harvester/foundation/logger"
 	"github.com/godwinrob/harvester/foundation/web"
@@ -9,21 +12,32 @@ import (
 
 // Config contains all the mandatory systems required by handlers.
 type Config struct {
-	Log       *logger.Logger
-	GalaxyBus *galaxybus.Business
+	Log        *logger.Logger
+	GalaxyBus  *galaxybus.Business
+	Auth       *auth.Auth
+	PageLimits page.Limits
 }
 
 // Routes adds specific routes for this group.
 func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Auth, auth.RuleAdminOnly)
 
-	api := newAPI(galaxyapp.NewApp(cfg.GalaxyBus))
-	app.HandleFunc("POST /v1/galaxies", api.create)
-	app.HandleFunc("POST /v1/galaxies/bulk", api.bulkCreate)
-	app.HandleFunc("GET /v1/galaxies", api.query)
-	app.HandleFunc("GET /v1/galaxies/{galaxy_id}", api.queryByID)
-	app.HandleFunc("GET /v1/galaxies/name/{name}", api.queryByName)
-	app.HandleFunc("PUT /v1/galaxies/bulk", api.bulkUpdate)
-	app.HandleFunc("PUT /v1/galaxies/{galaxy_id}", api.update)
-	app.HandleFunc("DELETE /v1/galaxies/bulk", api.bulkDelete)
-	app.HandleFunc("DELETE /v1/galaxies/{galaxy_id}", api.delete)
+	api := newAPI(galaxyapp.NewApp(cfg.GalaxyBus, cfg.PageLimits))
+	app.HandleFunc("POST /v1/galaxies", api.create, authen, ruleAny)
+	app.HandleFunc("POST /v1/galaxies/bulk", api.bulkCreate, authen, ruleAdmin)
+	app.HandleFunc("GET /v1/galaxies", api.query, authen, ruleAny)
+	app.HandleFunc("GET /v1/galaxies/{galaxy_id}", api.queryByID, authen, ruleAny)
+	app.HandleFunc("GET /v1/galaxies/lookup", api.queryByName, authen, ruleAny)
+	app.HandleFunc("GET /v1/galaxies/{galaxy_id}/{name}", api.queryByPathName, authen, ruleAny)
+	app.HandleFunc("GET /v1/galaxies/{galaxy_id}/members", api.queryMembers, authen, ruleAny)
+	app.HandleFunc("POST /v1/galaxies/{galaxy_id}/members", api.invite, authen, ruleAny)
+	app.HandleFunc("POST /v1/galaxies/{galaxy_id}/members/join", api.join, authen, ruleAny)
+	app.HandleFunc("POST /v1/galaxies/{galaxy_id}/members/leave", api.leave, authen, ruleAny)
+	app.HandleFunc("DELETE /v1/galaxies/{galaxy_id}/members/{user_id}", api.removeMember, authen, ruleAny)
+	app.HandleFunc("PUT /v1/galaxies/bulk", api.bulkUpdate, authen, ruleAdmin)
+	app.HandleFunc("PUT /v1/galaxies/{galaxy_id}", api.update, authen, ruleAdmin)
+	app.HandleFunc("DELETE /v1/galaxies/bulk", api.bulkDelete, authen, ruleAdmin)
+	app.HandleFunc("DELETE /v1/galaxies/{galaxy_id}", api.delete, authen, ruleAdmin)
 }
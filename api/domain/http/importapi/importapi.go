@@ -0,0 +1,67 @@
+// Package importapi maintains the web based api for importing resources.
+package importapi
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/godwinrob/harvester/app/domain/importapp"
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/business/domain/importbus"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// maxFileSize is the largest export file accepted, in bytes.
+const maxFileSize = 32 << 20
+
+// importTimeout is how long reading and importing a file may take. It
+// replaces the server's read and write timeouts, which are too short for a
+// large file.
+const importTimeout = 5 * time.Minute
+
+type api struct {
+	importApp *importapp.App
+}
+
+func newAPI(importApp *importapp.App) *api {
+	return &api{
+		importApp: importApp,
+	}
+}
+
+// importFile reads the export file from the request body. The format is taken
+// from the format query parameter, or else from the content type.
+func (api *api) importFile(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	if err := web.ExtendDeadlines(ctx, importTimeout); err != nil {
+		return nil, errs.New(errs.Internal, err)
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = formatOf(r.Header.Get("Content-Type"))
+	}
+
+	data := http.MaxBytesReader(nil, r.Body, maxFileSize)
+
+	rpt, err := api.importApp.Import(ctx, web.Param(r, "galaxy_id"), format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return rpt, nil
+}
+
+func formatOf(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+
+	switch mediaType {
+	case "application/xml", "text/xml":
+		return importbus.Formats.GalaxyHarvester.String()
+	case "text/csv":
+		return importbus.Formats.SWGAide.String()
+	}
+
+	return ""
+}
@@ -0,0 +1,28 @@
+package importapi
+
+import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
+	"github.com/godwinrob/harvester/app/domain/importapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/importbus"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	Log       *logger.Logger
+	ImportBus *importbus.Business
+	GalaxyBus *galaxybus.Business
+	Auth      *auth.Auth
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+
+	api := newAPI(importapp.NewApp(cfg.ImportBus, cfg.GalaxyBus))
+	app.HandleFunc("POST /v1/galaxies/{galaxy_id}/import", api.importFile, authen, ruleAny)
+}
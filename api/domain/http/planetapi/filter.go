@@ -0,0 +1,23 @@
+package planetapi
+
+import (
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/planetapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
+)
+
+func parseQueryParams(r *http.Request) (planetapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	filter := planetapp.QueryParams{
+		Page:    values.Get("page"),
+		Rows:    page.Rows(values),
+		OrderBy: values.Get("orderBy"),
+		Cursor:  values.Get("cursor"),
+		ID:      values.Get("planet_id"),
+		Name:    values.Get("name"),
+	}
+
+	return filter, nil
+}
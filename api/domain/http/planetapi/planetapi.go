@@ -0,0 +1,44 @@
+// Package planetapi maintains the web based api for planet access.
+package planetapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/planetapp"
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+type api struct {
+	planetApp *planetapp.App
+}
+
+func newAPI(planetApp *planetapp.App) *api {
+	return &api{
+		planetApp: planetApp,
+	}
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	planets, err := api.planetApp.Query(ctx, qp)
+	if err != nil {
+		return nil, err
+	}
+
+	return planets, nil
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	planet, err := api.planetApp.QueryByID(ctx, web.Param(r, "planet_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return planet, nil
+}
@@ -0,0 +1,29 @@
+package planetapi
+
+import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
+	"github.com/godwinrob/harvester/app/domain/planetapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	Log        *logger.Logger
+	PlanetBus  *planetbus.Business
+	Auth       *auth.Auth
+	PageLimits page.Limits
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+
+	api := newAPI(planetapp.NewApp(cfg.PlanetBus, cfg.PageLimits))
+	app.HandleFunc("GET /v1/planets", api.query, authen, ruleAny)
+	app.HandleFunc("GET /v1/planets/{planet_id}", api.queryByID, authen, ruleAny)
+}
@@ -2,6 +2,7 @@ package resourceapi
 
 import (
 	"github.com/godwinrob/harvester/app/domain/resourceapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
 	"net/http"
 )
 
@@ -9,26 +10,55 @@ func parseQueryParams(r *http.Request) (resourceapp.QueryParams, error) {
 	values := r.URL.Query()
 
 	filter := resourceapp.QueryParams{
-		Page:        values.Get("page"),
-		Rows:        values.Get("row"),
-		OrderBy:     values.Get("orderBy"),
-		ID:          values.Get("resource_id"),
-		Name:        values.Get("name"),
-		AddedAtDate: values.Get("added_at"),
+		Page:          values.Get("page"),
+		Rows:          page.Rows(values),
+		OrderBy:       values.Get("orderBy"),
+		Cursor:        values.Get("cursor"),
+		ID:            values.Get("resource_id"),
+		Name:          values.Get("name"),
+		AddedAtDate:   values.Get("added_at"),
 		ResourceType:  values.Get("resource_type"),
 		ResourceGroup: values.Get("resource_group"),
-		//CR:           values.Get("cr"),
-		//CD:           values.Get("cd"),
-		//DR:           values.Get("dr"),
-		//FL:           values.Get("fl"),
-		//HR:           values.Get("hr"),
-		//MA:           values.Get("ma"),
-		//PE:           values.Get("pe"),
-		//OQ:           values.Get("oq"),
-		//SR:           values.Get("sr"),
-		//UT:           values.Get("ut"),
-		//ER:           values.Get("er"),
+		Planet:        values.Get("planet"),
+		Verified:      values.Get("verified"),
+		StartDate:     values.Get("start_date"),
+		EndDate:       values.Get("end_date"),
+		CRMin:         values.Get("cr_min"),
+		CRMax:         values.Get("cr_max"),
+		CDMin:         values.Get("cd_min"),
+		CDMax:         values.Get("cd_max"),
+		DRMin:         values.Get("dr_min"),
+		DRMax:         values.Get("dr_max"),
+		FLMin:         values.Get("fl_min"),
+		FLMax:         values.Get("fl_max"),
+		HRMin:         values.Get("hr_min"),
+		HRMax:         values.Get("hr_max"),
+		MAMin:         values.Get("ma_min"),
+		MAMax:         values.Get("ma_max"),
+		PEMin:         values.Get("pe_min"),
+		PEMax:         values.Get("pe_max"),
+		OQMin:         values.Get("oq_min"),
+		OQMax:         values.Get("oq_max"),
+		SRMin:         values.Get("sr_min"),
+		SRMax:         values.Get("sr_max"),
+		UTMin:         values.Get("ut_min"),
+		UTMax:         values.Get("ut_max"),
+		ERMin:         values.Get("er_min"),
+		ERMax:         values.Get("er_max"),
 	}
 
 	return filter, nil
 }
+
+func parseBestParams(r *http.Request) resourceapp.BestParams {
+	values := r.URL.Query()
+
+	return resourceapp.BestParams{
+		GalaxyID:      values.Get("galaxy_id"),
+		ResourceType:  values.Get("resource_type"),
+		ResourceGroup: values.Get("resource_group"),
+		Weights:       values.Get("weights"),
+		Available:     values.Get("available"),
+		Rows:          page.Rows(values),
+	}
+}
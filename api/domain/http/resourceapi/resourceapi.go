@@ -6,7 +6,10 @@ import (
 	"github.com/godwinrob/harvester/app/domain/resourceapp"
 	"github.com/godwinrob/harvester/app/sdk/errs"
 	"github.com/godwinrob/harvester/foundation/web"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 )
 
 type api struct {
@@ -61,6 +64,15 @@ func (api *api) query(ctx context.Context, r *http.Request) (web.Encoder, error)
 		return nil, errs.New(errs.FailedPrecondition, err)
 	}
 
+	if mediaType := exportMediaType(r.Header.Get("Accept")); mediaType != "" {
+		exp, err := api.resourceApp.Export(ctx, qp, mediaType)
+		if err != nil {
+			return nil, err
+		}
+
+		return web.Stream(exp), nil
+	}
+
 	usr, err := api.resourceApp.Query(ctx, qp)
 	if err != nil {
 		return nil, err
@@ -79,7 +91,7 @@ func (api *api) queryByID(ctx context.Context, r *http.Request) (web.Encoder, er
 }
 
 func (api *api) queryByName(ctx context.Context, r *http.Request) (web.Encoder, error) {
-	usr, err := api.resourceApp.QueryByName(ctx, web.Param(r, "name"))
+	usr, err := api.resourceApp.QueryByName(ctx, r.URL.Query().Get("galaxy_id"), web.Param(r, "name"))
 	if err != nil {
 		return nil, err
 	}
@@ -87,6 +99,69 @@ func (api *api) queryByName(ctx context.Context, r *http.Request) (web.Encoder,
 	return usr, nil
 }
 
+func (api *api) queryBest(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	best, err := api.resourceApp.QueryBest(ctx, parseBestParams(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return best, nil
+}
+
+func (api *api) despawn(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	res, err := api.resourceApp.Despawn(ctx, web.Param(r, "resource_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (api *api) reactivate(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	res, err := api.resourceApp.Reactivate(ctx, web.Param(r, "resource_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (api *api) statusHistory(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	history, err := api.resourceApp.QueryStatusHistory(ctx, web.Param(r, "resource_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+func (api *api) confirm(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	vs, err := api.resourceApp.Confirm(ctx, web.Param(r, "resource_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+func (api *api) dispute(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	vs, err := api.resourceApp.Dispute(ctx, web.Param(r, "resource_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
+func (api *api) verifications(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	vs, err := api.resourceApp.QueryVerifications(ctx, web.Param(r, "resource_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return vs, nil
+}
+
 func (api *api) bulkCreate(ctx context.Context, r *http.Request) (web.Encoder, error) {
 	var app resourceapp.BulkNewResources
 	if err := web.Decode(r, &app); err != nil {
@@ -128,3 +203,39 @@ func (api *api) bulkDelete(ctx context.Context, r *http.Request) (web.Encoder, e
 
 	return result, nil
 }
+
+// exportMediaType returns the export media type the Accept header prefers,
+// by quality and then order, or an empty string when it prefers JSON or asks
+// for neither.
+func exportMediaType(accept string) string {
+	var best string
+	var bestQ float64
+
+	for part := range strings.SplitSeq(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case resourceapp.ExportCSV, resourceapp.ExportTSV, resourceapp.ExportNDJSON:
+		case "application/json", "application/*", "*/*":
+			mediaType = ""
+		default:
+			continue
+		}
+
+		q := 1.0
+		if v, exists := params["q"]; exists {
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = mediaType, q
+		}
+	}
+
+	return best
+}
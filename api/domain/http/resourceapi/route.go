@@ -1,7 +1,11 @@
 package resourceapi
 
 import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
 	"github.com/godwinrob/harvester/app/domain/resourceapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
 	"github.com/godwinrob/harvester/business/domain/resourcebus"
 	"github.com/godwinrob/harvester/foundation/logger"
 	"github.com/godwinrob/harvester/foundation/web"
@@ -11,19 +15,33 @@ import (
 type Config struct {
 	Log         *logger.Logger
 	ResourceBus *resourcebus.Business
+	GalaxyBus   *galaxybus.Business
+	Auth        *auth.Auth
+	PageLimits  page.Limits
 }
 
 // Routes adds specific routes for this group.
 func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Auth, auth.RuleAdminOnly)
 
-	api := newAPI(resourceapp.NewApp(cfg.ResourceBus))
-	app.HandleFunc("POST /v1/resources", api.create)
-	app.HandleFunc("POST /v1/resources/bulk", api.bulkCreate)
-	app.HandleFunc("GET /v1/resources", api.query)
-	app.HandleFunc("GET /v1/resources/{resource_id}", api.queryByID)
-	app.HandleFunc("GET /v1/resources/name/{name}", api.queryByName)
-	app.HandleFunc("PUT /v1/resources/bulk", api.bulkUpdate)
-	app.HandleFunc("PUT /v1/resources/{resource_id}", api.update)
-	app.HandleFunc("DELETE /v1/resources/bulk", api.bulkDelete)
-	app.HandleFunc("DELETE /v1/resources/{resource_id}", api.delete)
+	api := newAPI(resourceapp.NewAppWithAuth(cfg.ResourceBus, cfg.GalaxyBus, cfg.PageLimits))
+	app.HandleFunc("POST /v1/resources", api.create, authen, ruleAny)
+	app.HandleFunc("POST /v1/resources/bulk", api.bulkCreate, authen, ruleAny)
+	app.HandleFunc("GET /v1/resources", api.query, authen, ruleAny)
+	app.HandleFunc("GET /v1/resources/best", api.queryBest, authen, ruleAny)
+	app.HandleFunc("GET /v1/resources/{resource_id}", api.queryByID, authen, ruleAny)
+	app.HandleFunc("GET /v1/resources/history/{resource_id}", api.statusHistory, authen, ruleAny)
+	app.HandleFunc("GET /v1/resources/verifications/{resource_id}", api.verifications, authen, ruleAny)
+	app.HandleFunc("GET /v1/resources/name/{name}", api.queryByName, authen, ruleAny)
+	app.HandleFunc("POST /v1/resources/{resource_id}/despawn", api.despawn, authen, ruleAny)
+	app.HandleFunc("POST /v1/resources/{resource_id}/reactivate", api.reactivate, authen, ruleAny)
+	app.HandleFunc("POST /v1/resources/{resource_id}/confirm", api.confirm, authen, ruleAny)
+	app.HandleFunc("POST /v1/resources/{resource_id}/dispute", api.dispute, authen, ruleAny)
+	app.HandleFunc("PUT /v1/resources/bulk", api.bulkUpdate, authen, ruleAny)
+	app.HandleFunc("PUT /v1/resources/{resource_id}", api.update, authen, ruleAny)
+	app.HandleFunc("DELETE /v1/resources/bulk", api.bulkDelete, authen, ruleAdmin)
+	app.HandleFunc("DELETE /v1/resources/{resource_id}", api.delete, authen, ruleAny)
+	app.HandleStreamFunc("GET /v1/galaxies/{galaxy_id}/events", api.events, authen, ruleAny)
 }
@@ -0,0 +1,62 @@
+package resourceapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/godwinrob/harvester/app/domain/resourceapp"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// pingInterval is how often an idle event stream is written to, so proxies
+// and clients don't time it out.
+const pingInterval = 15 * time.Second
+
+func (api *api) events(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	events, err := api.resourceApp.Subscribe(ctx, web.Param(r, "galaxy_id"))
+	if err != nil {
+		return err
+	}
+
+	es, err := web.NewEventStream(w)
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case evt, ok := <-events:
+			if !ok {
+				return nil
+			}
+
+			data, _, err := evt.Encode()
+			if err != nil {
+				return fmt.Errorf("encode: %w", err)
+			}
+
+			if err := es.Send(evt.Action, "", data); err != nil {
+				return err
+			}
+
+			// The client missed events, it catches up by querying the
+			// resources when it reconnects.
+			if evt.Action == resourceapp.EventOverflow {
+				return nil
+			}
+
+		case <-ticker.C:
+			if err := es.Ping(); err != nil {
+				return err
+			}
+		}
+	}
+}
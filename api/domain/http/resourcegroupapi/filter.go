@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/godwinrob/harvester/app/domain/resourcegroupapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
 )
 
 func parseQueryParams(r *http.Request) (resourcegroupapp.QueryParams, error) {
@@ -11,8 +12,9 @@ func parseQueryParams(r *http.Request) (resourcegroupapp.QueryParams, error) {
 
 	filter := resourcegroupapp.QueryParams{
 		Page:          values.Get("page"),
-		Rows:          values.Get("row"),
+		Rows:          page.Rows(values),
 		OrderBy:       values.Get("orderBy"),
+		Cursor:        values.Get("cursor"),
 		ResourceGroup: values.Get("resourceGroup"),
 		GroupName:     values.Get("groupName"),
 		GroupLevel:    values.Get("groupLevel"),
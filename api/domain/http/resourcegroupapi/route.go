@@ -1,7 +1,10 @@
 package resourcegroupapi
 
 import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
 	"github.com/godwinrob/harvester/app/domain/resourcegroupapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
 	"github.com/godwinrob/harvester/business/domain/resourcegroupbus"
 	"github.com/godwinrob/harvester/foundation/logger"
 	"github.com/godwinrob/harvester/foundation/web"
@@ -11,11 +14,16 @@ import (
 type Config struct {
 	Log              *logger.Logger
 	ResourceGroupBus *resourcegroupbus.Business
+	Auth             *auth.Auth
+	PageLimits       page.Limits
 }
 
 // Routes adds specific routes for this group.
 func Routes(app *web.App, cfg Config) {
-	api := newAPI(resourcegroupapp.NewApp(cfg.ResourceGroupBus))
-	app.HandleFunc("GET /v1/resource-groups", api.query)
-	app.HandleFunc("GET /v1/resource-groups/{resource_group}", api.queryByID)
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+
+	api := newAPI(resourcegroupapp.NewApp(cfg.ResourceGroupBus, cfg.PageLimits))
+	app.HandleFunc("GET /v1/resource-groups", api.query, authen, ruleAny)
+	app.HandleFunc("GET /v1/resource-groups/{resource_group}", api.queryByID, authen, ruleAny)
 }
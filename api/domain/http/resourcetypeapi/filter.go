@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/godwinrob/harvester/app/domain/resourcetypeapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
 )
 
 func parseQueryParams(r *http.Request) (resourcetypeapp.QueryParams, error) {
@@ -11,8 +12,9 @@ func parseQueryParams(r *http.Request) (resourcetypeapp.QueryParams, error) {
 
 	filter := resourcetypeapp.QueryParams{
 		Page:             values.Get("page"),
-		Rows:             values.Get("row"),
+		Rows:             page.Rows(values),
 		OrderBy:          values.Get("orderBy"),
+		Cursor:           values.Get("cursor"),
 		ResourceType:     values.Get("resourceType"),
 		ResourceTypeName: values.Get("resourceTypeName"),
 		ResourceCategory: values.Get("resourceCategory"),
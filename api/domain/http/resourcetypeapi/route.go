@@ -1,7 +1,10 @@
 package resourcetypeapi
 
 import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
 	"github.com/godwinrob/harvester/app/domain/resourcetypeapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
 	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
 	"github.com/godwinrob/harvester/foundation/logger"
 	"github.com/godwinrob/harvester/foundation/web"
@@ -11,15 +14,21 @@ import (
 type Config struct {
 	Log             *logger.Logger
 	ResourceTypeBus *resourcetypebus.Business
+	Auth            *auth.Auth
+	PageLimits      page.Limits
 }
 
 // Routes adds specific routes for this group.
 func Routes(app *web.App, cfg Config) {
-	api := newAPI(resourcetypeapp.NewApp(cfg.ResourceTypeBus))
-	app.HandleFunc("GET /v1/resource-types", api.query)
-	app.HandleFunc("GET /v1/resource-types/{resource_type}", api.queryByID)
-	app.HandleFunc("POST /v1/resource-types", api.create)
-	app.HandleFunc("POST /v1/resource-types/bulk", api.bulkCreate)
-	app.HandleFunc("PUT /v1/resource-types/{resource_type}", api.update)
-	app.HandleFunc("DELETE /v1/resource-types/{resource_type}", api.delete)
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Auth, auth.RuleAdminOnly)
+
+	api := newAPI(resourcetypeapp.NewApp(cfg.ResourceTypeBus, cfg.PageLimits))
+	app.HandleFunc("GET /v1/resource-types", api.query, authen, ruleAny)
+	app.HandleFunc("GET /v1/resource-types/{resource_type}", api.queryByID, authen, ruleAny)
+	app.HandleFunc("POST /v1/resource-types", api.create, authen, ruleAdmin)
+	app.HandleFunc("POST /v1/resource-types/bulk", api.bulkCreate, authen, ruleAdmin)
+	app.HandleFunc("PUT /v1/resource-types/{resource_type}", api.update, authen, ruleAdmin)
+	app.HandleFunc("DELETE /v1/resource-types/{resource_type}", api.delete, authen, ruleAdmin)
 }
@@ -0,0 +1,34 @@
+package schematicapi
+
+import (
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/schematicapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
+)
+
+func parseQueryParams(r *http.Request) (schematicapp.QueryParams, error) {
+	values := r.URL.Query()
+
+	filter := schematicapp.QueryParams{
+		Page:         values.Get("page"),
+		Rows:         page.Rows(values),
+		OrderBy:      values.Get("orderBy"),
+		Cursor:       values.Get("cursor"),
+		ID:           values.Get("schematic_id"),
+		Name:         values.Get("name"),
+		ResourceType: values.Get("resource_type"),
+	}
+
+	return filter, nil
+}
+
+func parseScoreParams(r *http.Request) schematicapp.ScoreParams {
+	values := r.URL.Query()
+
+	return schematicapp.ScoreParams{
+		GalaxyID:  values.Get("galaxy_id"),
+		Available: values.Get("available"),
+		Rows:      page.Rows(values),
+	}
+}
@@ -0,0 +1,34 @@
+package schematicapi
+
+import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
+	"github.com/godwinrob/harvester/app/domain/schematicapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	Log          *logger.Logger
+	SchematicBus *schematicbus.Business
+	Auth         *auth.Auth
+	PageLimits   page.Limits
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+	ruleAdmin := mid.Authorize(cfg.Auth, auth.RuleAdminOnly)
+
+	api := newAPI(schematicapp.NewApp(cfg.SchematicBus, cfg.PageLimits))
+	app.HandleFunc("POST /v1/schematics", api.create, authen, ruleAdmin)
+	app.HandleFunc("GET /v1/schematics", api.query, authen, ruleAny)
+	app.HandleFunc("GET /v1/schematics/{schematic_id}", api.queryByID, authen, ruleAny)
+	app.HandleFunc("GET /v1/schematics/{schematic_id}/score", api.score, authen, ruleAny)
+	app.HandleFunc("PUT /v1/schematics/{schematic_id}", api.update, authen, ruleAdmin)
+	app.HandleFunc("DELETE /v1/schematics/{schematic_id}", api.delete, authen, ruleAdmin)
+}
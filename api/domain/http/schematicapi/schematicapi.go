@@ -0,0 +1,89 @@
+// Package schematicapi maintains the web based api for schematic access.
+package schematicapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/schematicapp"
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+type api struct {
+	schematicApp *schematicapp.App
+}
+
+func newAPI(schematicApp *schematicapp.App) *api {
+	return &api{
+		schematicApp: schematicApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	var app schematicapp.NewSchematic
+	if err := web.Decode(r, &app); err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	sch, err := api.schematicApp.Create(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+
+	return sch, nil
+}
+
+func (api *api) update(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	var app schematicapp.UpdateSchematic
+	if err := web.Decode(r, &app); err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	sch, err := api.schematicApp.Update(ctx, web.Param(r, "schematic_id"), app)
+	if err != nil {
+		return nil, err
+	}
+
+	return sch, nil
+}
+
+func (api *api) delete(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	if err := api.schematicApp.Delete(ctx, web.Param(r, "schematic_id")); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	qp, err := parseQueryParams(r)
+	if err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	schematics, err := api.schematicApp.Query(ctx, qp)
+	if err != nil {
+		return nil, err
+	}
+
+	return schematics, nil
+}
+
+func (api *api) queryByID(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	sch, err := api.schematicApp.QueryByID(ctx, web.Param(r, "schematic_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return sch, nil
+}
+
+func (api *api) score(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	score, err := api.schematicApp.Score(ctx, web.Param(r, "schematic_id"), parseScoreParams(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return score, nil
+}
@@ -0,0 +1,18 @@
+package searchapi
+
+import (
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/searchapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
+)
+
+func parseQueryParams(r *http.Request) searchapp.QueryParams {
+	values := r.URL.Query()
+
+	return searchapp.QueryParams{
+		Q:        values.Get("q"),
+		GalaxyID: values.Get("galaxy_id"),
+		Rows:     page.Rows(values),
+	}
+}
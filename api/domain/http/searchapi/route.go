@@ -0,0 +1,26 @@
+package searchapi
+
+import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
+	"github.com/godwinrob/harvester/app/domain/searchapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/business/domain/searchbus"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	Log       *logger.Logger
+	SearchBus *searchbus.Business
+	Auth      *auth.Auth
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+
+	api := newAPI(searchapp.NewApp(cfg.SearchBus))
+	app.HandleFunc("GET /v1/search", api.search, authen, ruleAny)
+}
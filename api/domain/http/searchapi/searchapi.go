@@ -0,0 +1,30 @@
+// Package searchapi maintains the web based api for searching resources,
+// resource types and resource groups by name.
+package searchapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/searchapp"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+type api struct {
+	searchApp *searchapp.App
+}
+
+func newAPI(searchApp *searchapp.App) *api {
+	return &api{
+		searchApp: searchApp,
+	}
+}
+
+func (api *api) search(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	hits, err := api.searchApp.Search(ctx, parseQueryParams(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return hits, nil
+}
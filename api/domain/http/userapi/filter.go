@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/godwinrob/harvester/app/domain/userapp"
+	"github.com/godwinrob/harvester/app/sdk/page"
 )
 
 func parseQueryParams(r *http.Request) (userapp.QueryParams, error) {
@@ -11,8 +12,9 @@ func parseQueryParams(r *http.Request) (userapp.QueryParams, error) {
 
 	filter := userapp.QueryParams{
 		Page:             values.Get("page"),
-		Rows:             values.Get("row"),
+		Rows:             page.Rows(values),
 		OrderBy:          values.Get("orderBy"),
+		Cursor:           values.Get("cursor"),
 		ID:               values.Get("user_id"),
 		Name:             values.Get("name"),
 		Email:            values.Get("email"),
@@ -1,7 +1,10 @@
 package userapi
 
 import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
 	"github.com/godwinrob/harvester/app/domain/userapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
 	"github.com/godwinrob/harvester/business/domain/userbus"
 	"github.com/godwinrob/harvester/foundation/logger"
 	"github.com/godwinrob/harvester/foundation/web"
@@ -9,21 +12,28 @@ import (
 
 // Config contains all the mandatory systems required by handlers.
 type Config struct {
-	Log     *logger.Logger
-	UserBus *userbus.Business
+	Log        *logger.Logger
+	UserBus    *userbus.Business
+	Auth       *auth.Auth
+	PageLimits page.Limits
 }
 
 // Routes adds specific routes for this group.
 func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAdmin := mid.Authorize(cfg.Auth, auth.RuleAdminOnly)
+	ruleAuthorizeUser := mid.AuthorizeUser(cfg.Auth, cfg.UserBus, auth.RuleAdminOrSubject)
+	ruleAuthorizeAdmin := mid.AuthorizeUser(cfg.Auth, cfg.UserBus, auth.RuleAdminOnly)
 
-	api := newAPI(userapp.NewApp(cfg.UserBus))
-	app.HandleFunc("GET /v1/users", api.query)
-	app.HandleFunc("GET /v1/users/{user_id}", api.queryByID)
-	app.HandleFunc("POST /v1/users", api.create)
-	app.HandleFunc("POST /v1/users/bulk", api.bulkCreate)
-	app.HandleFunc("PUT /v1/users/role/{user_id}", api.updateRole)
-	app.HandleFunc("PUT /v1/users/bulk", api.bulkUpdate)
-	app.HandleFunc("PUT /v1/users/{user_id}", api.update)
-	app.HandleFunc("DELETE /v1/users/bulk", api.bulkDelete)
-	app.HandleFunc("DELETE /v1/users/{user_id}", api.delete)
+	api := newAPI(userapp.NewAppWithAuth(cfg.UserBus, cfg.Auth, cfg.PageLimits))
+	app.HandleFunc("POST /v1/auth/token", api.token)
+	app.HandleFunc("GET /v1/users", api.query, authen, ruleAdmin)
+	app.HandleFunc("GET /v1/users/{user_id}", api.queryByID, authen, ruleAuthorizeUser)
+	app.HandleFunc("POST /v1/users", api.create, authen, ruleAdmin)
+	app.HandleFunc("POST /v1/users/bulk", api.bulkCreate, authen, ruleAdmin)
+	app.HandleFunc("PUT /v1/users/role/{user_id}", api.updateRole, authen, ruleAuthorizeAdmin)
+	app.HandleFunc("PUT /v1/users/bulk", api.bulkUpdate, authen, ruleAdmin)
+	app.HandleFunc("PUT /v1/users/{user_id}", api.update, authen, ruleAuthorizeUser)
+	app.HandleFunc("DELETE /v1/users/bulk", api.bulkDelete, authen, ruleAdmin)
+	app.HandleFunc("DELETE /v1/users/{user_id}", api.delete, authen, ruleAuthorizeUser)
 }
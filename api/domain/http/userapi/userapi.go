@@ -19,6 +19,20 @@ func newAPI(userApp *userapp.App) *api {
 	}
 }
 
+func (api *api) token(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	var app userapp.Login
+	if err := web.Decode(r, &app); err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	tkn, err := api.userApp.Token(ctx, app)
+	if err != nil {
+		return nil, err
+	}
+
+	return tkn, nil
+}
+
 func (api *api) create(ctx context.Context, r *http.Request) (web.Encoder, error) {
 	var app userapp.NewUser
 	if err := web.Decode(r, &app); err != nil {
@@ -39,7 +53,7 @@ func (api *api) update(ctx context.Context, r *http.Request) (web.Encoder, error
 		return nil, errs.New(errs.FailedPrecondition, err)
 	}
 
-	usr, err := api.userApp.Update(ctx, web.Param(r, "user_id"), app)
+	usr, err := api.userApp.Update(ctx, app)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +67,7 @@ func (api *api) updateRole(ctx context.Context, r *http.Request) (web.Encoder, e
 		return nil, errs.New(errs.FailedPrecondition, err)
 	}
 
-	usr, err := api.userApp.UpdateRole(ctx, web.Param(r, "user_id"), app)
+	usr, err := api.userApp.UpdateRole(ctx, app)
 	if err != nil {
 		return nil, err
 	}
@@ -62,7 +76,7 @@ func (api *api) updateRole(ctx context.Context, r *http.Request) (web.Encoder, e
 }
 
 func (api *api) delete(ctx context.Context, r *http.Request) (web.Encoder, error) {
-	if err := api.userApp.Delete(ctx, web.Param(r, "user_id")); err != nil {
+	if err := api.userApp.Delete(ctx); err != nil {
 		return nil, err
 	}
 
@@ -84,7 +98,7 @@ func (api *api) query(ctx context.Context, r *http.Request) (web.Encoder, error)
 }
 
 func (api *api) queryByID(ctx context.Context, r *http.Request) (web.Encoder, error) {
-	usr, err := api.userApp.QueryByID(ctx, web.Param(r, "user_id"))
+	usr, err := api.userApp.QueryByID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,31 @@
+package webhookapi
+
+import (
+	"github.com/godwinrob/harvester/api/sdk/http/mid"
+	"github.com/godwinrob/harvester/app/domain/webhookapp"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/webhookbus"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	Log        *logger.Logger
+	WebhookBus *webhookbus.Business
+	GalaxyBus  *galaxybus.Business
+	Auth       *auth.Auth
+}
+
+// Routes adds specific routes for this group.
+func Routes(app *web.App, cfg Config) {
+	authen := mid.Authenticate(cfg.Auth)
+	ruleAny := mid.Authorize(cfg.Auth, auth.RuleAny)
+
+	api := newAPI(webhookapp.NewApp(cfg.WebhookBus, cfg.GalaxyBus))
+	app.HandleFunc("GET /v1/galaxies/{galaxy_id}/webhooks", api.query, authen, ruleAny)
+	app.HandleFunc("POST /v1/galaxies/{galaxy_id}/webhooks", api.create, authen, ruleAny)
+	app.HandleFunc("GET /v1/galaxies/{galaxy_id}/webhooks/{webhook_id}/deliveries", api.queryDeliveries, authen, ruleAny)
+	app.HandleFunc("DELETE /v1/galaxies/{galaxy_id}/webhooks/{webhook_id}", api.delete, authen, ruleAny)
+}
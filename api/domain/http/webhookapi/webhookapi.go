@@ -0,0 +1,61 @@
+// Package webhookapi maintains the web based api for galaxy webhook access.
+package webhookapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/domain/webhookapp"
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+type api struct {
+	webhookApp *webhookapp.App
+}
+
+func newAPI(webhookApp *webhookapp.App) *api {
+	return &api{
+		webhookApp: webhookApp,
+	}
+}
+
+func (api *api) create(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	var app webhookapp.NewWebhook
+	if err := web.Decode(r, &app); err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	wh, err := api.webhookApp.Create(ctx, web.Param(r, "galaxy_id"), app)
+	if err != nil {
+		return nil, err
+	}
+
+	return wh, nil
+}
+
+func (api *api) delete(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	if err := api.webhookApp.Delete(ctx, web.Param(r, "galaxy_id"), web.Param(r, "webhook_id")); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (api *api) query(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	whs, err := api.webhookApp.Query(ctx, web.Param(r, "galaxy_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return whs, nil
+}
+
+func (api *api) queryDeliveries(ctx context.Context, r *http.Request) (web.Encoder, error) {
+	deliveries, err := api.webhookApp.QueryDeliveries(ctx, web.Param(r, "galaxy_id"), web.Param(r, "webhook_id"))
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
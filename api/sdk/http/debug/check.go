@@ -0,0 +1,83 @@
+package debug
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+type check struct {
+	build string
+	log   *logger.Logger
+	db    *sqlx.DB
+}
+
+func newCheck(cfg Config) *check {
+	return &check{
+		build: cfg.Build,
+		log:   cfg.Log,
+		db:    cfg.DB,
+	}
+}
+
+// liveness reports the service is running. It does not touch the database,
+// so an outage there doesn't get every instance restarted.
+func (c *check) liveness(w http.ResponseWriter, r *http.Request) {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unavailable"
+	}
+
+	data := struct {
+		Status     string `json:"status"`
+		Build      string `json:"build"`
+		Host       string `json:"host"`
+		GOMAXPROCS int    `json:"GOMAXPROCS"`
+	}{
+		Status:     "up",
+		Build:      c.build,
+		Host:       host,
+		GOMAXPROCS: runtime.GOMAXPROCS(0),
+	}
+
+	c.respond(r.Context(), w, http.StatusOK, data)
+}
+
+// readiness reports whether the service can take traffic, which it can't
+// while the database is unreachable.
+func (c *check) readiness(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Second)
+	defer cancel()
+
+	status := "ok"
+	statusCode := http.StatusOK
+	if err := sqldb.StatusCheck(ctx, c.db); err != nil {
+		c.log.Info(ctx, "readiness failure", "ERROR", err)
+		status = "db not ready"
+		statusCode = http.StatusInternalServerError
+	}
+
+	data := struct {
+		Status string `json:"status"`
+	}{
+		Status: status,
+	}
+
+	c.respond(ctx, w, statusCode, data)
+}
+
+func (c *check) respond(ctx context.Context, w http.ResponseWriter, statusCode int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		c.log.Error(ctx, "debug: respond", "ERROR", err)
+	}
+}
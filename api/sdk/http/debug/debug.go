@@ -0,0 +1,41 @@
+// Package debug provides the handlers of the debug server: profiling,
+// runtime and Prometheus metrics and the health checks the orchestrator
+// probes.
+package debug
+
+import (
+	"expvar"
+	"net/http"
+	"net/http/pprof"
+
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Config contains all the mandatory systems required by the handlers.
+type Config struct {
+	Build string
+	Log   *logger.Logger
+	DB    *sqlx.DB
+}
+
+// Mux registers the debug routes on a mux of their own, so none of them are
+// ever reachable through the public API.
+func Mux(cfg Config) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	mux.Handle("/debug/vars", expvar.Handler())
+	mux.Handle("/metrics", promhttp.Handler())
+
+	chk := newCheck(cfg)
+	mux.HandleFunc("GET /liveness", chk.liveness)
+	mux.HandleFunc("GET /readiness", chk.readiness)
+
+	return mux
+}
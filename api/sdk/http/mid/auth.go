@@ -0,0 +1,39 @@
+package mid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/mid"
+	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// Authenticate validates the bearer token supplied in the authorization header.
+func Authenticate(ath *auth.Auth) web.Middleware {
+	midFunc := func(ctx context.Context, r *http.Request, next mid.Handler) (mid.Encoder, error) {
+		return mid.Authenticate(ctx, ath, r.Header.Get("authorization"), next)
+	}
+
+	return addMiddleware(midFunc)
+}
+
+// Authorize validates the authenticated caller satisfies the specified rule.
+func Authorize(ath *auth.Auth, rule string) web.Middleware {
+	midFunc := func(ctx context.Context, r *http.Request, next mid.Handler) (mid.Encoder, error) {
+		return mid.Authorize(ctx, ath, rule, next)
+	}
+
+	return addMiddleware(midFunc)
+}
+
+// AuthorizeUser loads the user identified by the user_id path parameter and
+// validates the authenticated caller satisfies the rule against that user.
+func AuthorizeUser(ath *auth.Auth, userBus *userbus.Business, rule string) web.Middleware {
+	midFunc := func(ctx context.Context, r *http.Request, next mid.Handler) (mid.Encoder, error) {
+		return mid.AuthorizeUser(ctx, ath, userBus, rule, web.Param(r, "user_id"), next)
+	}
+
+	return addMiddleware(midFunc)
+}
@@ -0,0 +1,18 @@
+package mid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/godwinrob/harvester/app/sdk/mid"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// Metrics executes the metrics middleware functionality.
+func Metrics() web.Middleware {
+	midFunc := func(ctx context.Context, r *http.Request, next mid.Handler) (mid.Encoder, error) {
+		return mid.Metrics(ctx, r.Pattern, next)
+	}
+
+	return addMiddleware(midFunc)
+}
@@ -0,0 +1,108 @@
+package mid
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/mid"
+	"github.com/godwinrob/harvester/business/sdk/ratelimit"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/web"
+)
+
+// RateLimits are the limiter and the budgets requests are limited to. Reads
+// are GET requests, bulk writes are the bulk and import routes, and every
+// other request is a write.
+type RateLimits struct {
+	Limiter *ratelimit.Limiter
+	Read    ratelimit.Budget
+	Write   ratelimit.Budget
+	Bulk    ratelimit.Budget
+
+	// TrustForwardedFor takes the client IP from the last X-Forwarded-For
+	// entry, the address the proxy in front of the service saw. Only set it
+	// when every request comes through such a proxy, since a client can send
+	// any X-Forwarded-For it likes.
+	TrustForwardedFor bool
+
+	// FailOpen lets requests through when the buckets can't be reached,
+	// instead of failing them with a 503.
+	FailOpen bool
+}
+
+// RateLimit executes the rate limit middleware functionality. The quota left
+// is added to the response headers.
+func RateLimit(log *logger.Logger, ath *auth.Auth, limits RateLimits) web.Middleware {
+	midFunc := func(ctx context.Context, r *http.Request, next mid.Handler) (mid.Encoder, error) {
+		quota, err := mid.RateLimit(ctx, log, ath, limits.Limiter, limits.budget(r), limits.FailOpen, r.Header.Get("authorization"), limits.clientIP(r))
+
+		if quota != (ratelimit.Quota{}) {
+			setQuota(web.Header(ctx), quota)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		return next(ctx)
+	}
+
+	return addMiddleware(midFunc)
+}
+
+// setQuota sets the headers describing the quota left of the budget.
+func setQuota(header http.Header, quota ratelimit.Quota) {
+	header.Set("RateLimit-Limit", strconv.Itoa(quota.Budget.Limit))
+	header.Set("RateLimit-Remaining", strconv.Itoa(quota.Remaining))
+	header.Set("RateLimit-Reset", seconds(quota.Reset))
+	header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%s", quota.Budget.Limit, seconds(quota.Budget.Period)))
+
+	if !quota.Allowed {
+		header.Set("Retry-After", seconds(quota.RetryAfter))
+	}
+}
+
+// budget returns the budget of the kind of request.
+func (rl RateLimits) budget(r *http.Request) ratelimit.Budget {
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return rl.Read
+	}
+
+	_, path, _ := strings.Cut(r.Pattern, " ")
+	if strings.HasSuffix(path, "/bulk") || strings.HasSuffix(path, "/import") {
+		return rl.Bulk
+	}
+
+	return rl.Write
+}
+
+// clientIP returns the address of the client that made the request.
+func (rl RateLimits) clientIP(r *http.Request) string {
+	if rl.TrustForwardedFor {
+		if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if ip := strings.TrimSpace(entries[len(entries)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// seconds formats the duration as whole seconds, rounded up, for a header.
+func seconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
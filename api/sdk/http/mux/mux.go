@@ -6,26 +6,42 @@ import (
 	"context"
 
 	"github.com/godwinrob/harvester/api/sdk/http/mid"
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/page"
 	"github.com/godwinrob/harvester/foundation/logger"
 	"github.com/godwinrob/harvester/foundation/web"
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// Config contains all the mandatory systems required by handlers.
+type Config struct {
+	Log                *logger.Logger
+	DB                 *sqlx.DB
+	Tracer             trace.Tracer
+	Auth               *auth.Auth
+	PageLimits         page.Limits
+	CORSAllowedOrigins []string
+	RateLimits         mid.RateLimits
+}
+
 // RouteAdder defines behavior that sets the routes to bind for an instance
 // of the service.
 type RouteAdder interface {
-	Add(*logger.Logger, *sqlx.DB, *web.App)
+	Add(app *web.App, cfg Config)
 }
 
 // WebAPI constructs a http.Handler with all application routes bound.
-func WebAPI(log *logger.Logger, db *sqlx.DB, routeAdder RouteAdder) *web.App {
+func WebAPI(cfg Config, routeAdder RouteAdder) *web.App {
 	l := func(ctx context.Context, msg string, args ...any) {
-		log.Info(ctx, msg, args...)
+		cfg.Log.Info(ctx, msg, args...)
 	}
 
-	app := web.NewApp(l, mid.Logger(log), mid.Error(log), mid.Panics())
+	app := web.NewApp(l, cfg.Tracer, mid.Logger(cfg.Log), mid.Error(cfg.Log), mid.Metrics(), mid.Panics(), mid.RateLimit(cfg.Log, cfg.Auth, cfg.RateLimits))
+
+	app.EnableCORS(cfg.CORSAllowedOrigins)
 
-	routeAdder.Add(log, db, app)
+	routeAdder.Add(app, cfg)
 
 	return app
 }
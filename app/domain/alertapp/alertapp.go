@@ -0,0 +1,245 @@
+// Package alertapp maintains the app layer api for the alert domain.
+package alertapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/mid"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// App manages the set of app layer api functions for the alert domain.
+type App struct {
+	alertBus   *alertbus.Business
+	pageLimits page.Limits
+}
+
+// NewApp constructs an alert app API for use.
+func NewApp(alertBus *alertbus.Business, pageLimits page.Limits) *App {
+	return &App{
+		alertBus:   alertBus,
+		pageLimits: pageLimits,
+	}
+}
+
+// CreateRule adds a new watch rule for the caller.
+func (a *App) CreateRule(ctx context.Context, app NewRule) (Rule, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Rule{}, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	nr, err := toBusNewRule(app, userID)
+	if err != nil {
+		return Rule{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	rule, err := a.alertBus.CreateRule(ctx, nr)
+	if err != nil {
+		if fe := validate.GetFieldErrors(err); fe != nil {
+			return Rule{}, errs.New(errs.FailedPrecondition, fe)
+		}
+		return Rule{}, errs.Newf(errs.Internal, "createrule: rule[%+v]: %s", app, err)
+	}
+
+	return toAppRule(rule), nil
+}
+
+// DeleteRule removes one of the caller's watch rules and its alerts.
+func (a *App) DeleteRule(ctx context.Context, ruleID string) error {
+	rule, err := a.queryRule(ctx, ruleID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.alertBus.DeleteRule(ctx, rule); err != nil {
+		return errs.Newf(errs.Internal, "deleterule: ruleID[%s]: %s", rule.ID, err)
+	}
+
+	return nil
+}
+
+// QueryRules returns the caller's watch rules with paging.
+func (a *App) QueryRules(ctx context.Context, qp QueryParams) (page.Document[Rule], error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return page.Document[Rule]{}, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Rule]{}, err
+	}
+
+	orderBy, err := order.Parse(ruleOrderByFields, qp.OrderBy, alertbus.DefaultRuleOrderBy)
+	if err != nil {
+		return page.Document[Rule]{}, err
+	}
+
+	filter := alertbus.RuleFilter{
+		UserID: &userID,
+	}
+
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[Rule]{}, err
+	}
+
+	var rules []alertbus.Rule
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		rules, err = a.alertBus.QueryRules(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		rules, next, err = a.alertBus.QueryRulesAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
+	if err != nil {
+		return page.Document[Rule]{}, errs.Newf(errs.Internal, "queryrules: %s", err)
+	}
+
+	total, err := a.alertBus.CountRules(ctx, filter)
+	if err != nil {
+		return page.Document[Rule]{}, errs.Newf(errs.Internal, "countrules: %s", err)
+	}
+
+	doc := page.NewDocument(toAppRules(rules), total, pg.Number, pg.RowsPerPage)
+	if len(rules) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
+	}
+
+	return doc, nil
+}
+
+// QueryRuleByID returns one of the caller's watch rules by its ID.
+func (a *App) QueryRuleByID(ctx context.Context, ruleID string) (Rule, error) {
+	rule, err := a.queryRule(ctx, ruleID)
+	if err != nil {
+		return Rule{}, err
+	}
+
+	return toAppRule(rule), nil
+}
+
+// QueryAlerts returns the caller's alert inbox, newest first, with paging.
+func (a *App) QueryAlerts(ctx context.Context, qp QueryParams) (page.Document[Alert], error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return page.Document[Alert]{}, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Alert]{}, err
+	}
+
+	filter, err := parseAlertFilter(qp, userID)
+	if err != nil {
+		return page.Document[Alert]{}, err
+	}
+
+	orderBy, err := order.Parse(alertOrderByFields, qp.OrderBy, alertbus.DefaultAlertOrderBy)
+	if err != nil {
+		return page.Document[Alert]{}, err
+	}
+
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[Alert]{}, err
+	}
+
+	var alerts []alertbus.Alert
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		alerts, err = a.alertBus.QueryAlerts(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		alerts, next, err = a.alertBus.QueryAlertsAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
+	if err != nil {
+		return page.Document[Alert]{}, errs.Newf(errs.Internal, "queryalerts: %s", err)
+	}
+
+	total, err := a.alertBus.CountAlerts(ctx, filter)
+	if err != nil {
+		return page.Document[Alert]{}, errs.Newf(errs.Internal, "countalerts: %s", err)
+	}
+
+	doc := page.NewDocument(toAppAlerts(alerts), total, pg.Number, pg.RowsPerPage)
+	if len(alerts) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
+	}
+
+	return doc, nil
+}
+
+// DeleteAlert removes an alert from the caller's inbox.
+func (a *App) DeleteAlert(ctx context.Context, alertID string) error {
+	id, err := uuid.Parse(alertID)
+	if err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	alert, err := a.alertBus.QueryAlertByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, alertbus.ErrAlertNotFound) {
+			return errs.New(errs.NotFound, alertbus.ErrAlertNotFound)
+		}
+		return errs.Newf(errs.Internal, "queryalertbyid: alertID[%s]: %s", id, err)
+	}
+
+	if err := checkOwner(ctx, alert.UserID); err != nil {
+		return err
+	}
+
+	if err := a.alertBus.DeleteAlert(ctx, alert); err != nil {
+		return errs.Newf(errs.Internal, "deletealert: alertID[%s]: %s", alert.ID, err)
+	}
+
+	return nil
+}
+
+// =============================================================================
+
+// queryRule returns the watch rule if it belongs to the caller or the caller
+// is an admin.
+func (a *App) queryRule(ctx context.Context, ruleID string) (alertbus.Rule, error) {
+	id, err := uuid.Parse(ruleID)
+	if err != nil {
+		return alertbus.Rule{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	rule, err := a.alertBus.QueryRuleByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, alertbus.ErrRuleNotFound) {
+			return alertbus.Rule{}, errs.New(errs.NotFound, alertbus.ErrRuleNotFound)
+		}
+		return alertbus.Rule{}, errs.Newf(errs.Internal, "queryrulebyid: ruleID[%s]: %s", id, err)
+	}
+
+	if err := checkOwner(ctx, rule.UserID); err != nil {
+		return alertbus.Rule{}, err
+	}
+
+	return rule, nil
+}
+
+// checkOwner allows the user who owns a rule or alert, and admins, through.
+func checkOwner(ctx context.Context, ownerID uuid.UUID) error {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	if userID != ownerID && !mid.GetClaims(ctx).HasRole(userbus.Roles.Admin) {
+		return errs.Newf(errs.PermissionDenied, "user does not own this alert")
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package alertapp
+
+import (
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseAlertFilter(qp QueryParams, userID uuid.UUID) (alertbus.AlertFilter, error) {
+	filter := alertbus.AlertFilter{
+		UserID: &userID,
+	}
+
+	if qp.RuleID != "" {
+		id, err := uuid.Parse(qp.RuleID)
+		if err != nil {
+			return alertbus.AlertFilter{}, validate.NewFieldsError("rule_id", err)
+		}
+		filter.RuleID = &id
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,225 @@
+package alertapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page    string
+	Rows    string
+	OrderBy string
+	Cursor  string
+	RuleID  string
+}
+
+// Stats represents a value for each resource stat.
+type Stats struct {
+	CR int16 `json:"cr"`
+	CD int16 `json:"cd"`
+	DR int16 `json:"dr"`
+	FL int16 `json:"fl"`
+	HR int16 `json:"hr"`
+	MA int16 `json:"ma"`
+	PE int16 `json:"pe"`
+	OQ int16 `json:"oq"`
+	SR int16 `json:"sr"`
+	UT int16 `json:"ut"`
+	ER int16 `json:"er"`
+}
+
+// Rule represents information about an individual watch rule.
+type Rule struct {
+	ID            string  `json:"id"`
+	UserID        string  `json:"userID"`
+	Name          string  `json:"name"`
+	GalaxyID      string  `json:"galaxyID"`
+	ResourceType  string  `json:"resourceType,omitempty"`
+	ResourceGroup string  `json:"resourceGroup,omitempty"`
+	MinStats      Stats   `json:"minStats"`
+	Weights       Stats   `json:"weights"`
+	MinScore      float64 `json:"minScore"`
+	DateCreated   string  `json:"dateCreated"`
+	DateUpdated   string  `json:"dateUpdated"`
+}
+
+// Encode implements the encoder interface.
+func (app Rule) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppRule(bus alertbus.Rule) Rule {
+	return Rule{
+		ID:            bus.ID.String(),
+		UserID:        bus.UserID.String(),
+		Name:          bus.Name,
+		GalaxyID:      bus.GalaxyID.String(),
+		ResourceType:  bus.ResourceType,
+		ResourceGroup: bus.ResourceGroup,
+		MinStats: Stats{
+			CR: bus.MinStats.CR,
+			CD: bus.MinStats.CD,
+			DR: bus.MinStats.DR,
+			FL: bus.MinStats.FL,
+			HR: bus.MinStats.HR,
+			MA: bus.MinStats.MA,
+			PE: bus.MinStats.PE,
+			OQ: bus.MinStats.OQ,
+			SR: bus.MinStats.SR,
+			UT: bus.MinStats.UT,
+			ER: bus.MinStats.ER,
+		},
+		Weights: Stats{
+			CR: bus.Weights.CR,
+			CD: bus.Weights.CD,
+			DR: bus.Weights.DR,
+			FL: bus.Weights.FL,
+			HR: bus.Weights.HR,
+			MA: bus.Weights.MA,
+			PE: bus.Weights.PE,
+			OQ: bus.Weights.OQ,
+			SR: bus.Weights.SR,
+			UT: bus.Weights.UT,
+			ER: bus.Weights.ER,
+		},
+		MinScore:    bus.MinScore,
+		DateCreated: bus.DateCreated.Format(time.RFC3339),
+		DateUpdated: bus.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+func toAppRules(rules []alertbus.Rule) []Rule {
+	app := make([]Rule, len(rules))
+	for i, rule := range rules {
+		app[i] = toAppRule(rule)
+	}
+
+	return app
+}
+
+// =============================================================================
+
+// NewRule defines the data needed to add a new watch rule. Exactly one of
+// resourceType and resourceGroup must be set. A zero minimum stat has no
+// minimum; weights, when given, rank matches by score and minScore sets the
+// lowest score that raises an alert.
+type NewRule struct {
+	Name          string  `json:"name" validate:"required,min=1,max=63"`
+	GalaxyID      string  `json:"galaxyID" validate:"required,uuid"`
+	ResourceType  string  `json:"resourceType"`
+	ResourceGroup string  `json:"resourceGroup"`
+	MinStats      Stats   `json:"minStats"`
+	Weights       Stats   `json:"weights"`
+	MinScore      float64 `json:"minScore"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewRule) Decode(data []byte) error {
+	return json.Unmarshal(data, &app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewRule) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+func toBusNewRule(app NewRule, userID uuid.UUID) (alertbus.NewRule, error) {
+	galaxyID, err := uuid.Parse(app.GalaxyID)
+	if err != nil {
+		return alertbus.NewRule{}, fmt.Errorf("parse galaxyID: %w", err)
+	}
+
+	bus := alertbus.NewRule{
+		UserID:        userID,
+		Name:          app.Name,
+		GalaxyID:      galaxyID,
+		ResourceType:  app.ResourceType,
+		ResourceGroup: app.ResourceGroup,
+		MinStats: alertbus.Stats{
+			CR: app.MinStats.CR,
+			CD: app.MinStats.CD,
+			DR: app.MinStats.DR,
+			FL: app.MinStats.FL,
+			HR: app.MinStats.HR,
+			MA: app.MinStats.MA,
+			PE: app.MinStats.PE,
+			OQ: app.MinStats.OQ,
+			SR: app.MinStats.SR,
+			UT: app.MinStats.UT,
+			ER: app.MinStats.ER,
+		},
+		Weights: resourcebus.Weights{
+			CR: app.Weights.CR,
+			CD: app.Weights.CD,
+			DR: app.Weights.DR,
+			FL: app.Weights.FL,
+			HR: app.Weights.HR,
+			MA: app.Weights.MA,
+			PE: app.Weights.PE,
+			OQ: app.Weights.OQ,
+			SR: app.Weights.SR,
+			UT: app.Weights.UT,
+			ER: app.Weights.ER,
+		},
+		MinScore: app.MinScore,
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+
+// Alert represents a resource that matched one of the caller's watch rules.
+type Alert struct {
+	ID           string  `json:"id"`
+	RuleID       string  `json:"ruleID"`
+	RuleName     string  `json:"ruleName"`
+	ResourceID   string  `json:"resourceID"`
+	ResourceName string  `json:"resourceName"`
+	ResourceType string  `json:"resourceType"`
+	GalaxyID     string  `json:"galaxyID"`
+	Score        float64 `json:"score"`
+	DateCreated  string  `json:"dateCreated"`
+}
+
+// Encode implements the encoder interface.
+func (app Alert) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppAlert(bus alertbus.Alert) Alert {
+	return Alert{
+		ID:           bus.ID.String(),
+		RuleID:       bus.RuleID.String(),
+		RuleName:     bus.RuleName,
+		ResourceID:   bus.ResourceID.String(),
+		ResourceName: bus.ResourceName,
+		ResourceType: bus.ResourceType,
+		GalaxyID:     bus.GalaxyID.String(),
+		Score:        bus.Score,
+		DateCreated:  bus.DateCreated.Format(time.RFC3339),
+	}
+}
+
+func toAppAlerts(alerts []alertbus.Alert) []Alert {
+	app := make([]Alert, len(alerts))
+	for i, alert := range alerts {
+		app[i] = toAppAlert(alert)
+	}
+
+	return app
+}
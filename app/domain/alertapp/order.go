@@ -0,0 +1,21 @@
+package alertapp
+
+import (
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+)
+
+var ruleOrderByFields = map[string]string{
+	"rule_id":      alertbus.OrderByID,
+	"name":         alertbus.OrderByName,
+	"rule_name":    alertbus.OrderByName,
+	"dateCreated":  alertbus.OrderByDateCreated,
+	"date_created": alertbus.OrderByDateCreated,
+}
+
+var alertOrderByFields = map[string]string{
+	"alert_id":     alertbus.OrderByID,
+	"rule_name":    alertbus.OrderByName,
+	"score":        alertbus.OrderByScore,
+	"dateCreated":  alertbus.OrderByDateCreated,
+	"date_created": alertbus.OrderByDateCreated,
+}
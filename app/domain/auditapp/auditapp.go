@@ -0,0 +1,72 @@
+// Package auditapp maintains the app layer api for the audit domain.
+package auditapp
+
+import (
+	"context"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+)
+
+// App manages the set of app layer api functions for the audit domain.
+type App struct {
+	auditBus   *auditbus.Business
+	pageLimits page.Limits
+}
+
+// NewApp constructs an audit app API for use.
+func NewApp(auditBus *auditbus.Business, pageLimits page.Limits) *App {
+	return &App{
+		auditBus:   auditBus,
+		pageLimits: pageLimits,
+	}
+}
+
+// Query returns a list of audit entries with paging.
+func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Entry], error) {
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Entry]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Entry]{}, err
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return page.Document[Entry]{}, err
+	}
+
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[Entry]{}, err
+	}
+
+	var entries []auditbus.Entry
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		entries, err = a.auditBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		entries, next, err = a.auditBus.QueryAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
+	if err != nil {
+		return page.Document[Entry]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := a.auditBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Entry]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	doc := page.NewDocument(toAppEntries(entries), total, pg.Number, pg.RowsPerPage)
+	if len(entries) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
+	}
+
+	return doc, nil
+}
@@ -0,0 +1,56 @@
+package auditapp
+
+import (
+	"errors"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (auditbus.QueryFilter, error) {
+	var filter auditbus.QueryFilter
+
+	if qp.EntityType != "" {
+		entity, err := auditbus.Entities.Parse(qp.EntityType)
+		if err != nil {
+			return auditbus.QueryFilter{}, validate.NewFieldsError("entity_type", err)
+		}
+		filter.Entity = &entity
+	}
+
+	if qp.EntityID != "" {
+		filter.EntityID = &qp.EntityID
+	}
+
+	if qp.ActorID != "" {
+		id, err := uuid.Parse(qp.ActorID)
+		if err != nil {
+			return auditbus.QueryFilter{}, validate.NewFieldsError("actor_id", err)
+		}
+		filter.ActorID = &id
+	}
+
+	if qp.StartDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.StartDate)
+		if err != nil {
+			return auditbus.QueryFilter{}, validate.NewFieldsError("start_date", err)
+		}
+		filter.StartDate = &t
+	}
+
+	if qp.EndDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.EndDate)
+		if err != nil {
+			return auditbus.QueryFilter{}, validate.NewFieldsError("end_date", err)
+		}
+		filter.EndDate = &t
+	}
+
+	if filter.StartDate != nil && filter.EndDate != nil && filter.EndDate.Before(*filter.StartDate) {
+		return auditbus.QueryFilter{}, validate.NewFieldsError("end_date", errors.New("end_date is before start_date"))
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,70 @@
+package auditapp
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page       string
+	Rows       string
+	OrderBy    string
+	Cursor     string
+	EntityType string
+	EntityID   string
+	ActorID    string
+	StartDate  string
+	EndDate    string
+}
+
+// Entry represents a single mutation recorded in the audit log. Before and
+// After hold the fields the mutation changed.
+type Entry struct {
+	ID          string          `json:"id"`
+	ActorID     string          `json:"actorID,omitempty"`
+	Action      string          `json:"action"`
+	EntityType  string          `json:"entityType"`
+	EntityID    string          `json:"entityID"`
+	Before      json.RawMessage `json:"before,omitempty"`
+	After       json.RawMessage `json:"after,omitempty"`
+	TraceID     string          `json:"traceID"`
+	DateCreated string          `json:"dateCreated"`
+}
+
+// Encode implments the encoder interface.
+func (app Entry) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppEntry(bus auditbus.Entry) Entry {
+	var actorID string
+	if bus.ActorID != uuid.Nil {
+		actorID = bus.ActorID.String()
+	}
+
+	return Entry{
+		ID:          bus.ID.String(),
+		ActorID:     actorID,
+		Action:      bus.Action.String(),
+		EntityType:  bus.Entity.String(),
+		EntityID:    bus.EntityID,
+		Before:      bus.Before,
+		After:       bus.After,
+		TraceID:     bus.TraceID,
+		DateCreated: bus.DateCreated.Format(time.RFC3339),
+	}
+}
+
+func toAppEntries(entries []auditbus.Entry) []Entry {
+	app := make([]Entry, len(entries))
+	for i, e := range entries {
+		app[i] = toAppEntry(e)
+	}
+
+	return app
+}
@@ -0,0 +1,18 @@
+package auditapp
+
+import (
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+)
+
+var defaultOrderBy = order.NewBy("date_created", order.DESC)
+
+var orderByFields = map[string]string{
+	"audit_id":     auditbus.OrderByID,
+	"dateCreated":  auditbus.OrderByDateCreated,
+	"date_created": auditbus.OrderByDateCreated,
+	"actorID":      auditbus.OrderByActorID,
+	"actor_id":     auditbus.OrderByActorID,
+	"entityType":   auditbus.OrderByEntity,
+	"entity_type":  auditbus.OrderByEntity,
+}
@@ -5,33 +5,43 @@ import (
 	"errors"
 	"github.com/godwinrob/harvester/app/sdk/bulk"
 	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/mid"
 	"github.com/godwinrob/harvester/app/sdk/page"
 	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/userbus"
 	"github.com/godwinrob/harvester/business/sdk/order"
 	"github.com/google/uuid"
 )
 
 // App manages the set of app layer api functions for the galaxy domain.
 type App struct {
-	galaxyBus *galaxybus.Business
+	galaxyBus  *galaxybus.Business
+	pageLimits page.Limits
 }
 
 // NewApp constructs a galaxy app API for use.
-func NewApp(galaxyBus *galaxybus.Business) *App {
+func NewApp(galaxyBus *galaxybus.Business, pageLimits page.Limits) *App {
 	return &App{
-		galaxyBus: galaxyBus,
+		galaxyBus:  galaxyBus,
+		pageLimits: pageLimits,
 	}
 }
 
 // NewAppWithAuth constructs a galaxy app API for use with auth support.
-func NewAppWithAuth(galaxyBus *galaxybus.Business) *App {
+func NewAppWithAuth(galaxyBus *galaxybus.Business, pageLimits page.Limits) *App {
 	return &App{
-		galaxyBus: galaxyBus,
+		galaxyBus:  galaxyBus,
+		pageLimits: pageLimits,
 	}
 }
 
-// Create adds a new galaxy to the system.
+// Create adds a new galaxy to the system, owned by the caller unless an
+// admin names another owner.
 func (a *App) Create(ctx context.Context, app NewGalaxy) (Galaxy, error) {
+	if err := setOwner(ctx, &app); err != nil {
+		return Galaxy{}, err
+	}
+
 	nc, err := toBusNewGalaxy(app)
 	if err != nil {
 		return Galaxy{}, errs.New(errs.FailedPrecondition, err)
@@ -42,6 +52,9 @@ func (a *App) Create(ctx context.Context, app NewGalaxy) (Galaxy, error) {
 		if errors.Is(err, galaxybus.ErrUniqueName) {
 			return Galaxy{}, errs.New(errs.Aborted, galaxybus.ErrUniqueName)
 		}
+		if errors.Is(err, galaxybus.ErrUnknownUser) {
+			return Galaxy{}, errs.New(errs.FailedPrecondition, galaxybus.ErrUnknownUser)
+		}
 		return Galaxy{}, errs.Newf(errs.Internal, "create: gal[%+v]: %s", gal, err)
 	}
 
@@ -67,6 +80,12 @@ func (a *App) Update(ctx context.Context, galaxyID string, app UpdateGalaxy) (Ga
 
 	updGal, err := a.galaxyBus.Update(ctx, gal, uu)
 	if err != nil {
+		if errors.Is(err, galaxybus.ErrUniqueName) {
+			return Galaxy{}, errs.New(errs.Aborted, galaxybus.ErrUniqueName)
+		}
+		if errors.Is(err, galaxybus.ErrUnknownUser) {
+			return Galaxy{}, errs.New(errs.FailedPrecondition, galaxybus.ErrUnknownUser)
+		}
 		return Galaxy{}, errs.Newf(errs.Internal, "update: galaxyID[%s] uu[%+v]: %s", gal.ID, uu, err)
 	}
 
@@ -94,7 +113,7 @@ func (a *App) Delete(ctx context.Context, galaxyID string) error {
 
 // Query returns a list of galaxys with paging.
 func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Galaxy], error) {
-	pg, err := page.Parse(qp.Page, qp.Rows)
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
 	if err != nil {
 		return page.Document[Galaxy]{}, err
 	}
@@ -109,7 +128,19 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Galaxy],
 		return page.Document[Galaxy]{}, err
 	}
 
-	gals, err := a.galaxyBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[Galaxy]{}, err
+	}
+
+	var gals []galaxybus.Galaxy
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		gals, err = a.galaxyBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		gals, next, err = a.galaxyBus.QueryAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
 	if err != nil {
 		return page.Document[Galaxy]{}, errs.Newf(errs.Internal, "query: %s", err)
 	}
@@ -119,7 +150,12 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Galaxy],
 		return page.Document[Galaxy]{}, errs.Newf(errs.Internal, "count: %s", err)
 	}
 
-	return page.NewDocument(toAppGalaxies(gals), total, pg.Number, pg.RowsPerPage), nil
+	doc := page.NewDocument(toAppGalaxies(gals), total, pg.Number, pg.RowsPerPage)
+	if len(gals) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
+	}
+
+	return doc, nil
 }
 
 // QueryByID returns a galaxy by its Ia.
@@ -148,7 +184,8 @@ func (a *App) QueryByName(ctx context.Context, galaxyName string) (Galaxy, error
 	return toAppGalaxy(gal), nil
 }
 
-// BulkCreate adds multiple new galaxies to the system.
+// BulkCreate adds multiple new galaxies to the system. Galaxies naming no
+// owner are owned by the caller.
 func (a *App) BulkCreate(ctx context.Context, app BulkNewGalaxies) (BulkGalaxies, error) {
 	if err := bulk.ValidateBatchSize(len(app.Items)); err != nil {
 		return BulkGalaxies{}, errs.New(errs.FailedPrecondition, err)
@@ -159,6 +196,10 @@ func (a *App) BulkCreate(ctx context.Context, app BulkNewGalaxies) (BulkGalaxies
 	newGalaxies := make([]galaxybus.NewGalaxy, 0, len(app.Items))
 
 	for i, item := range app.Items {
+		if err := setOwner(ctx, &item); err != nil {
+			return BulkGalaxies{}, err
+		}
+
 		if err := item.Validate(); err != nil {
 			bulkErrors = append(bulkErrors, errs.BulkItemError{
 				Index: i,
@@ -189,6 +230,9 @@ func (a *App) BulkCreate(ctx context.Context, app BulkNewGalaxies) (BulkGalaxies
 		if errors.Is(err, galaxybus.ErrUniqueName) {
 			return BulkGalaxies{}, errs.New(errs.Aborted, galaxybus.ErrUniqueName)
 		}
+		if errors.Is(err, galaxybus.ErrUnknownUser) {
+			return BulkGalaxies{}, errs.New(errs.FailedPrecondition, galaxybus.ErrUnknownUser)
+		}
 		return BulkGalaxies{}, errs.Newf(errs.Internal, "bulkcreate: %s", err)
 	}
 
@@ -253,6 +297,9 @@ func (a *App) BulkUpdate(ctx context.Context, app BulkUpdateGalaxies) (BulkGalax
 		if errors.Is(err, galaxybus.ErrUniqueName) {
 			return BulkGalaxies{}, errs.New(errs.Aborted, galaxybus.ErrUniqueName)
 		}
+		if errors.Is(err, galaxybus.ErrUnknownUser) {
+			return BulkGalaxies{}, errs.New(errs.FailedPrecondition, galaxybus.ErrUnknownUser)
+		}
 		if errors.Is(err, galaxybus.ErrNotFound) {
 			return BulkGalaxies{}, errs.New(errs.NotFound, galaxybus.ErrNotFound)
 		}
@@ -300,3 +347,197 @@ func (a *App) BulkDelete(ctx context.Context, app BulkDeleteGalaxies) (BulkDelet
 		Deleted: len(ids),
 	}, nil
 }
+
+// =============================================================================
+
+// QueryMembers returns the members and pending invitations of a galaxy.
+func (a *App) QueryMembers(ctx context.Context, galaxyID string) (Members, error) {
+	gal, err := a.queryByID(ctx, galaxyID)
+	if err != nil {
+		return Members{}, err
+	}
+
+	members, err := a.galaxyBus.QueryMembers(ctx, gal.ID)
+	if err != nil {
+		return Members{}, errs.Newf(errs.Internal, "querymembers: galaxyID[%s]: %s", gal.ID, err)
+	}
+
+	return toAppMembers(members), nil
+}
+
+// Invite invites a user to a galaxy. Only owners of the galaxy and admins can
+// invite users.
+func (a *App) Invite(ctx context.Context, galaxyID string, app NewMember) (Member, error) {
+	gal, err := a.queryByID(ctx, galaxyID)
+	if err != nil {
+		return Member{}, err
+	}
+
+	userID, err := a.checkManage(ctx, gal)
+	if err != nil {
+		return Member{}, err
+	}
+
+	nm, err := toBusNewMember(app, userID)
+	if err != nil {
+		return Member{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	mem, err := a.galaxyBus.Invite(ctx, gal, nm)
+	if err != nil {
+		if errors.Is(err, galaxybus.ErrAlreadyMember) {
+			return Member{}, errs.New(errs.Aborted, galaxybus.ErrAlreadyMember)
+		}
+		if errors.Is(err, galaxybus.ErrUnknownUser) {
+			return Member{}, errs.New(errs.FailedPrecondition, galaxybus.ErrUnknownUser)
+		}
+		return Member{}, errs.Newf(errs.Internal, "invite: galaxyID[%s] userID[%s]: %s", gal.ID, nm.UserID, err)
+	}
+
+	return toAppMember(mem), nil
+}
+
+// Join accepts the caller's invitation to a galaxy.
+func (a *App) Join(ctx context.Context, galaxyID string) (Member, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Member{}, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	gal, err := a.queryByID(ctx, galaxyID)
+	if err != nil {
+		return Member{}, err
+	}
+
+	mem, err := a.galaxyBus.Join(ctx, gal, userID)
+	if err != nil {
+		if errors.Is(err, galaxybus.ErrMemberNotFound) {
+			return Member{}, errs.Newf(errs.NotFound, "no invitation to galaxy %s", gal.ID)
+		}
+		if errors.Is(err, galaxybus.ErrAlreadyMember) {
+			return Member{}, errs.New(errs.FailedPrecondition, galaxybus.ErrAlreadyMember)
+		}
+		return Member{}, errs.Newf(errs.Internal, "join: galaxyID[%s] userID[%s]: %s", gal.ID, userID, err)
+	}
+
+	return toAppMember(mem), nil
+}
+
+// Leave removes the caller from a galaxy.
+func (a *App) Leave(ctx context.Context, galaxyID string) error {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	gal, err := a.queryByID(ctx, galaxyID)
+	if err != nil {
+		return err
+	}
+
+	return a.removeMember(ctx, gal, userID)
+}
+
+// RemoveMember removes a user from a galaxy. Only owners of the galaxy and
+// admins can remove other users.
+func (a *App) RemoveMember(ctx context.Context, galaxyID string, userID string) error {
+	gal, err := a.queryByID(ctx, galaxyID)
+	if err != nil {
+		return err
+	}
+
+	id, err := uuid.Parse(userID)
+	if err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	if _, err := a.checkManage(ctx, gal); err != nil {
+		return err
+	}
+
+	return a.removeMember(ctx, gal, id)
+}
+
+func (a *App) removeMember(ctx context.Context, gal galaxybus.Galaxy, userID uuid.UUID) error {
+	if err := a.galaxyBus.RemoveMember(ctx, gal, userID); err != nil {
+		if errors.Is(err, galaxybus.ErrMemberNotFound) {
+			return errs.New(errs.NotFound, galaxybus.ErrMemberNotFound)
+		}
+		if errors.Is(err, galaxybus.ErrRemoveOwner) {
+			return errs.New(errs.FailedPrecondition, galaxybus.ErrRemoveOwner)
+		}
+		return errs.Newf(errs.Internal, "removemember: galaxyID[%s] userID[%s]: %s", gal.ID, userID, err)
+	}
+
+	return nil
+}
+
+// checkManage confirms the caller is an admin or an owner of the galaxy and
+// returns the caller's id.
+func (a *App) checkManage(ctx context.Context, gal galaxybus.Galaxy) (uuid.UUID, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return uuid.Nil, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	if mid.GetClaims(ctx).HasRole(userbus.Roles.Admin) {
+		return userID, nil
+	}
+
+	mem, err := a.galaxyBus.QueryMember(ctx, gal.ID, userID)
+	if err != nil {
+		if errors.Is(err, galaxybus.ErrMemberNotFound) {
+			return uuid.Nil, errs.Newf(errs.PermissionDenied, "user is not an owner of galaxy %s", gal.ID)
+		}
+		return uuid.Nil, errs.Newf(errs.Internal, "querymember: galaxyID[%s] userID[%s]: %s", gal.ID, userID, err)
+	}
+
+	if !mem.CanManage() {
+		return uuid.Nil, errs.Newf(errs.PermissionDenied, "user is not an owner of galaxy %s", gal.ID)
+	}
+
+	return userID, nil
+}
+
+func (a *App) queryByID(ctx context.Context, galaxyID string) (galaxybus.Galaxy, error) {
+	id, err := uuid.Parse(galaxyID)
+	if err != nil {
+		return galaxybus.Galaxy{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	gal, err := a.galaxyBus.QueryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, galaxybus.ErrNotFound) {
+			return galaxybus.Galaxy{}, errs.New(errs.NotFound, err)
+		}
+		return galaxybus.Galaxy{}, errs.Newf(errs.Internal, "querybyid: galaxyID[%s]: %s", id, err)
+	}
+
+	return gal, nil
+}
+
+// setOwner makes the caller the owner of a new galaxy that names no owner.
+// Only admins may create a galaxy owned by another user, since the owner
+// manages the galaxy's members.
+func setOwner(ctx context.Context, app *NewGalaxy) error {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	if app.OwnerUserID == "" {
+		app.OwnerUserID = userID.String()
+		return nil
+	}
+
+	ownerID, err := uuid.Parse(app.OwnerUserID)
+	if err != nil {
+		return errs.New(errs.FailedPrecondition, err)
+	}
+
+	if ownerID != userID && !mid.GetClaims(ctx).HasRole(userbus.Roles.Admin) {
+		return errs.Newf(errs.PermissionDenied, "only an admin can create a galaxy owned by another user")
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ type QueryParams struct {
 	Page        string
 	Rows        string
 	OrderBy     string
+	Cursor      string
 	ID          string
 	Name        string
 	DateCreated string
@@ -27,6 +28,7 @@ type Galaxy struct {
 	Name        string `json:"name"`
 	OwnerUserID string `json:"ownerUserID"`
 	Enabled     bool   `json:"enabled"`
+	Threshold   int16  `json:"verificationThreshold"`
 	DateCreated string `json:"dateCreated"`
 	DateUpdated string `json:"dateUpdated"`
 }
@@ -44,6 +46,7 @@ func toAppGalaxy(bus galaxybus.Galaxy) Galaxy {
 		Name:        bus.Name.String(),
 		OwnerUserID: bus.OwnerUserID.String(),
 		Enabled:     bus.Enabled,
+		Threshold:   bus.VerificationThreshold,
 		DateCreated: bus.DateCreated.Format(time.RFC3339),
 		DateUpdated: bus.DateUpdated.Format(time.RFC3339),
 	}
@@ -63,7 +66,8 @@ func toAppGalaxies(galaxies []galaxybus.Galaxy) []Galaxy {
 // NewGalaxy defines the data needed to add a new galaxy.
 type NewGalaxy struct {
 	Name        string `json:"name" validate:"required"`
-	OwnerUserID string `json:"ownerUserID" validate:"required,uuid"`
+	OwnerUserID string `json:"ownerUserID" validate:"omitempty,uuid"`
+	Threshold   int16  `json:"verificationThreshold" validate:"omitempty,min=1,max=100"`
 }
 
 // Decode implments the decoder interface.
@@ -93,8 +97,9 @@ func toBusNewGalaxy(app NewGalaxy) (galaxybus.NewGalaxy, error) {
 	}
 
 	bus := galaxybus.NewGalaxy{
-		Name:        name,
-		OwnerUserID: ownerID,
+		Name:                  name,
+		OwnerUserID:           ownerID,
+		VerificationThreshold: app.Threshold,
 	}
 
 	return bus, nil
@@ -107,6 +112,7 @@ type UpdateGalaxy struct {
 	Name        *string `json:"name"`
 	OwnerUserID *string `json:"ownerUserID" validate:"omitempty,uuid"`
 	Enabled     *bool   `json:"enabled"`
+	Threshold   *int16  `json:"verificationThreshold" validate:"omitempty,min=1,max=100"`
 }
 
 // Decode implments the decoder interface.
@@ -143,9 +149,10 @@ func toBusUpdateGalaxy(app UpdateGalaxy) (galaxybus.UpdateGalaxy, error) {
 	}
 
 	bus := galaxybus.UpdateGalaxy{
-		Name:        name,
-		OwnerUserID: ownerID,
-		Enabled:     app.Enabled,
+		Name:                  name,
+		OwnerUserID:           ownerID,
+		Enabled:               app.Enabled,
+		VerificationThreshold: app.Threshold,
 	}
 
 	return bus, nil
@@ -239,3 +246,96 @@ func (app BulkDeleteResult) Encode() ([]byte, string, error) {
 	data, err := json.Marshal(app)
 	return data, "application/json", err
 }
+
+// =============================================================================
+
+// Member represents a user's membership of a galaxy.
+type Member struct {
+	GalaxyID    string `json:"galaxyID"`
+	UserID      string `json:"userID"`
+	Role        string `json:"role"`
+	InvitedBy   string `json:"invitedBy"`
+	Joined      bool   `json:"joined"`
+	DateCreated string `json:"dateCreated"`
+	DateUpdated string `json:"dateUpdated"`
+}
+
+// Encode implements the encoder interface.
+func (app Member) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppMember(bus galaxybus.Member) Member {
+	return Member{
+		GalaxyID:    bus.GalaxyID.String(),
+		UserID:      bus.UserID.String(),
+		Role:        bus.Role.String(),
+		InvitedBy:   bus.InvitedBy.String(),
+		Joined:      bus.Joined,
+		DateCreated: bus.DateCreated.Format(time.RFC3339),
+		DateUpdated: bus.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+// Members represents the members of a galaxy.
+type Members struct {
+	Items []Member `json:"items"`
+}
+
+// Encode implements the encoder interface.
+func (app Members) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppMembers(members []galaxybus.Member) Members {
+	items := make([]Member, len(members))
+	for i, mem := range members {
+		items[i] = toAppMember(mem)
+	}
+
+	return Members{
+		Items: items,
+	}
+}
+
+// NewMember defines the data needed to invite a user to a galaxy.
+type NewMember struct {
+	UserID string `json:"userID" validate:"required,uuid"`
+	Role   string `json:"role" validate:"required"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewMember) Decode(data []byte) error {
+	return json.Unmarshal(data, &app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewMember) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+func toBusNewMember(app NewMember, invitedBy uuid.UUID) (galaxybus.NewMember, error) {
+	userID, err := uuid.Parse(app.UserID)
+	if err != nil {
+		return galaxybus.NewMember{}, fmt.Errorf("parse: %w", err)
+	}
+
+	role, err := galaxybus.MemberRoles.Parse(app.Role)
+	if err != nil {
+		return galaxybus.NewMember{}, fmt.Errorf("parse: %w", err)
+	}
+
+	bus := galaxybus.NewMember{
+		UserID:    userID,
+		Role:      role,
+		InvitedBy: invitedBy,
+	}
+
+	return bus, nil
+}
@@ -0,0 +1,99 @@
+// Package importapp maintains the app layer api for importing resources.
+package importapp
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/mid"
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/importbus"
+	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// App manages the set of app layer api functions for the import domain.
+type App struct {
+	importBus *importbus.Business
+	galaxyBus *galaxybus.Business
+}
+
+// NewApp constructs an import app API for use.
+func NewApp(importBus *importbus.Business, galaxyBus *galaxybus.Business) *App {
+	return &App{
+		importBus: importBus,
+		galaxyBus: galaxyBus,
+	}
+}
+
+// Import adds the resources of an export file to a galaxy and reports the
+// outcome of every row. An import that fails partway returns a partial error
+// with the report, marking the rows that were not loaded.
+func (a *App) Import(ctx context.Context, galaxyID string, format string, data io.Reader) (Report, error) {
+	gid, userID, err := a.checkWrite(ctx, galaxyID)
+	if err != nil {
+		return Report{}, err
+	}
+
+	f, err := importbus.Formats.Parse(format)
+	if err != nil {
+		return Report{}, errs.New(errs.FailedPrecondition, validate.NewFieldsError("format", err))
+	}
+
+	ni := importbus.NewImport{
+		GalaxyID: gid,
+		UserID:   userID,
+		Format:   f,
+		Data:     data,
+	}
+
+	rpt, err := a.importBus.Import(ctx, ni)
+	if err != nil {
+		switch {
+		case errors.Is(err, importbus.ErrMalformed), errors.Is(err, importbus.ErrNoRows), errors.Is(err, galaxybus.ErrDisabled):
+			return Report{}, errs.New(errs.FailedPrecondition, err)
+		case errors.Is(err, galaxybus.ErrNotFound):
+			return Report{}, errs.New(errs.NotFound, err)
+		case rpt.NotAttempted > 0:
+			return Report{}, errs.NewPartialError(errs.Internal, toAppReport(rpt), "import: galaxyID[%s]: %s", gid, err)
+		}
+		return Report{}, errs.Newf(errs.Internal, "import: galaxyID[%s]: %s", gid, err)
+	}
+
+	return toAppReport(rpt), nil
+}
+
+// checkWrite confirms the caller may add resources to the galaxy: admins may
+// import into any galaxy and other users must be joined owners or editors.
+func (a *App) checkWrite(ctx context.Context, galaxyID string) (uuid.UUID, uuid.UUID, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	gid, err := uuid.Parse(galaxyID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	if mid.GetClaims(ctx).HasRole(userbus.Roles.Admin) {
+		return gid, userID, nil
+	}
+
+	mem, err := a.galaxyBus.QueryMember(ctx, gid, userID)
+	if err != nil {
+		if errors.Is(err, galaxybus.ErrMemberNotFound) {
+			return uuid.Nil, uuid.Nil, errs.Newf(errs.PermissionDenied, "user is not a member of galaxy %s", gid)
+		}
+		return uuid.Nil, uuid.Nil, errs.Newf(errs.Internal, "querymember: galaxyID[%s] userID[%s]: %s", gid, userID, err)
+	}
+
+	if !mem.CanWrite() {
+		return uuid.Nil, uuid.Nil, errs.Newf(errs.PermissionDenied, "user can not add resources to galaxy %s", gid)
+	}
+
+	return gid, userID, nil
+}
@@ -0,0 +1,56 @@
+package importapp
+
+import (
+	"encoding/json"
+
+	"github.com/godwinrob/harvester/business/domain/importbus"
+	"github.com/google/uuid"
+)
+
+// Row represents the outcome of a row of an imported file.
+type Row struct {
+	Line       int      `json:"line"`
+	Name       string   `json:"name"`
+	Status     string   `json:"status"`
+	ResourceID string   `json:"resourceID,omitempty"`
+	Errors     []string `json:"errors,omitempty"`
+}
+
+// Report represents the outcome of an import.
+type Report struct {
+	Accepted     int   `json:"accepted"`
+	Duplicates   int   `json:"duplicates"`
+	Rejected     int   `json:"rejected"`
+	NotAttempted int   `json:"notAttempted"`
+	Rows         []Row `json:"rows"`
+}
+
+// Encode implements the encoder interface.
+func (app Report) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppReport(bus importbus.Report) Report {
+	rows := make([]Row, len(bus.Rows))
+	for i, r := range bus.Rows {
+		rows[i] = Row{
+			Line:   r.Line,
+			Name:   r.Name,
+			Status: r.Status.String(),
+			Errors: r.Errors,
+		}
+
+		if r.ResourceID != uuid.Nil {
+			rows[i].ResourceID = r.ResourceID.String()
+		}
+	}
+
+	return Report{
+		Accepted:     bus.Accepted,
+		Duplicates:   bus.Duplicates,
+		Rejected:     bus.Rejected,
+		NotAttempted: bus.NotAttempted,
+		Rows:         rows,
+	}
+}
@@ -0,0 +1,27 @@
+package planetapp
+
+import (
+	"strconv"
+
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/foundation/validate"
+)
+
+func parseFilter(qp QueryParams) (planetbus.QueryFilter, error) {
+	var filter planetbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := strconv.ParseInt(qp.ID, 10, 16)
+		if err != nil {
+			return planetbus.QueryFilter{}, validate.NewFieldsError("planet_id", err)
+		}
+		i := int16(id)
+		filter.ID = &i
+	}
+
+	if qp.Name != "" {
+		filter.Name = &qp.Name
+	}
+
+	return filter, nil
+}
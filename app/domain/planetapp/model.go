@@ -0,0 +1,44 @@
+package planetapp
+
+import (
+	"encoding/json"
+
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page    string
+	Rows    string
+	OrderBy string
+	Cursor  string
+	ID      string
+	Name    string
+}
+
+// Planet represents information about a planet.
+type Planet struct {
+	ID   int16  `json:"id"`
+	Name string `json:"name"`
+}
+
+// Encode implements the encoder interface.
+func (app Planet) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppPlanet(bus planetbus.Planet) Planet {
+	return Planet{
+		ID:   bus.ID,
+		Name: bus.Name,
+	}
+}
+
+func toAppPlanets(planets []planetbus.Planet) []Planet {
+	app := make([]Planet, len(planets))
+	for i, p := range planets {
+		app[i] = toAppPlanet(p)
+	}
+	return app
+}
@@ -0,0 +1,15 @@
+package planetapp
+
+import (
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+)
+
+var defaultOrderBy = order.NewBy(planetbus.OrderByID, order.ASC)
+
+var orderByFields = map[string]string{
+	"planet_id":   planetbus.OrderByID,
+	"id":          planetbus.OrderByID,
+	"planet_name": planetbus.OrderByName,
+	"name":        planetbus.OrderByName,
+}
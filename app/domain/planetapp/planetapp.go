@@ -0,0 +1,91 @@
+package planetapp
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+)
+
+// App manages the set of app layer api functions for the planet domain.
+type App struct {
+	planetBus  *planetbus.Business
+	pageLimits page.Limits
+}
+
+// NewApp constructs a planet app API for use.
+func NewApp(planetBus *planetbus.Business, pageLimits page.Limits) *App {
+	return &App{
+		planetBus:  planetBus,
+		pageLimits: pageLimits,
+	}
+}
+
+// Query returns a list of planets with paging.
+func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Planet], error) {
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Planet]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Planet]{}, err
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return page.Document[Planet]{}, err
+	}
+
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[Planet]{}, err
+	}
+
+	var planets []planetbus.Planet
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		planets, err = a.planetBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		planets, next, err = a.planetBus.QueryAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
+	if err != nil {
+		return page.Document[Planet]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := a.planetBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Planet]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	doc := page.NewDocument(toAppPlanets(planets), total, pg.Number, pg.RowsPerPage)
+	if len(planets) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
+	}
+
+	return doc, nil
+}
+
+// QueryByID returns a planet by its id.
+func (a *App) QueryByID(ctx context.Context, planetID string) (Planet, error) {
+	id, err := strconv.ParseInt(planetID, 10, 16)
+	if err != nil {
+		return Planet{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	planet, err := a.planetBus.QueryByID(ctx, int16(id))
+	if err != nil {
+		if errors.Is(err, planetbus.ErrNotFound) {
+			return Planet{}, errs.New(errs.NotFound, err)
+		}
+		return Planet{}, errs.Newf(errs.Internal, "querybyid: planetID[%d]: %s", id, err)
+	}
+
+	return toAppPlanet(planet), nil
+}
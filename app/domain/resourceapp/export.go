@@ -0,0 +1,120 @@
+package resourceapp
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+)
+
+// Set of media types resources can be exported as.
+const (
+	ExportCSV    = "text/csv"
+	ExportTSV    = "text/tab-separated-values"
+	ExportNDJSON = "application/x-ndjson"
+)
+
+// exportColumns are the header of a CSV or TSV export.
+var exportColumns = []string{
+	"id", "name", "galaxyID", "resourceType", "addedAtDate", "updatedAtDate", "addedUserID",
+	"unavailableAt", "verified", "cr", "cd", "dr", "fl", "hr", "ma", "pe", "oq", "sr", "ut", "er", "planets",
+}
+
+// Export represents the resources matching a query, encoded one at a time as
+// they are read.
+type Export struct {
+	mediaType string
+	each      func(ctx context.Context, fn func(resourcebus.Resource) error) error
+}
+
+// ContentType implements the stream encoder interface.
+func (app Export) ContentType() string {
+	if app.mediaType == ExportNDJSON {
+		return app.mediaType
+	}
+
+	return app.mediaType + "; charset=utf-8"
+}
+
+// EncodeTo implements the stream encoder interface.
+func (app Export) EncodeTo(ctx context.Context, w io.Writer) error {
+	if app.mediaType == ExportNDJSON {
+		enc := json.NewEncoder(w)
+
+		return app.each(ctx, func(res resourcebus.Resource) error {
+			return enc.Encode(toAppResource(res))
+		})
+	}
+
+	cw := csv.NewWriter(w)
+	if app.mediaType == ExportTSV {
+		cw.Comma = '\t'
+	}
+
+	if err := cw.Write(exportColumns); err != nil {
+		return err
+	}
+
+	err := app.each(ctx, func(res resourcebus.Resource) error {
+		return cw.Write(toExportRecord(toAppResource(res)))
+	})
+
+	cw.Flush()
+
+	return errors.Join(err, cw.Error())
+}
+
+func toExportRecord(app Resource) []string {
+	planets := make([]string, len(app.Planets))
+	for i, p := range app.Planets {
+		planets[i] = strconv.Itoa(int(p))
+	}
+
+	stat := func(v int16) string {
+		return strconv.Itoa(int(v))
+	}
+
+	return []string{
+		app.ID, app.Name, app.GalaxyID, app.ResourceType, app.AddedAtDate, app.UpdatedAtDate, app.AddedUserID,
+		app.UnavailableAt, strconv.FormatBool(app.Verified),
+		stat(app.CR), stat(app.CD), stat(app.DR), stat(app.FL), stat(app.HR), stat(app.MA),
+		stat(app.PE), stat(app.OQ), stat(app.SR), stat(app.UT), stat(app.ER),
+		strings.Join(planets, ";"),
+	}
+}
+
+// Export returns every resource matching the filter, ignoring paging, to be
+// encoded as the media type while it is read from the database.
+func (a *App) Export(ctx context.Context, qp QueryParams, mediaType string) (Export, error) {
+	switch mediaType {
+	case ExportCSV, ExportTSV, ExportNDJSON:
+	default:
+		return Export{}, errs.Newf(errs.FailedPrecondition, "resources can not be exported as %q", mediaType)
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return Export{}, err
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return Export{}, err
+	}
+
+	exp := Export{
+		mediaType: mediaType,
+		each: func(ctx context.Context, fn func(resourcebus.Resource) error) error {
+			return a.resourceBus.QueryEach(ctx, filter, orderBy, fn)
+		},
+	}
+
+	return exp, nil
+}
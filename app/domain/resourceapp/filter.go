@@ -1,6 +1,13 @@
 package resourceapp
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
 	"github.com/godwinrob/harvester/business/domain/resourcebus"
 	"github.com/godwinrob/harvester/foundation/validate"
 	"github.com/google/uuid"
@@ -33,13 +40,220 @@ func parseFilter(qp QueryParams) (resourcebus.QueryFilter, error) {
 		filter.ResourceGroup = &qp.ResourceGroup
 	}
 
-	//if qp.StartCreatedDate != "" {
-	//	t, err := time.Parse(time.RFC3339, qp.StartCreatedDate)
-	//	if err != nil {
-	//		return userbus.QueryFilter{}, validate.NewFieldsError("added_at", err)
-	//	}
-	//	filter.AddedAtDate = &t
-	//}
+	if qp.Planet != "" {
+		planetID, err := strconv.ParseInt(qp.Planet, 10, 16)
+		if err != nil {
+			return resourcebus.QueryFilter{}, validate.NewFieldsError("planet", err)
+		}
+		p := int16(planetID)
+		filter.Planet = &p
+	}
+
+	if qp.Verified != "" {
+		verified, err := strconv.ParseBool(qp.Verified)
+		if err != nil {
+			return resourcebus.QueryFilter{}, validate.NewFieldsError("verified", err)
+		}
+		filter.Verified = &verified
+	}
+
+	if qp.StartDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.StartDate)
+		if err != nil {
+			return resourcebus.QueryFilter{}, validate.NewFieldsError("start_date", err)
+		}
+		filter.StartCreatedDate = &t
+	}
+
+	if qp.EndDate != "" {
+		t, err := time.Parse(time.RFC3339, qp.EndDate)
+		if err != nil {
+			return resourcebus.QueryFilter{}, validate.NewFieldsError("end_date", err)
+		}
+		filter.EndCreatedDate = &t
+	}
+
+	if filter.StartCreatedDate != nil && filter.EndCreatedDate != nil && filter.EndCreatedDate.Before(*filter.StartCreatedDate) {
+		return resourcebus.QueryFilter{}, validate.NewFieldsError("end_date", errors.New("end_date is before start_date"))
+	}
+
+	if qp.AddedAtDate != "" {
+		start, err := parseDay(qp.AddedAtDate)
+		if err != nil {
+			return resourcebus.QueryFilter{}, validate.NewFieldsError("added_at", err)
+		}
+		end := start.AddDate(0, 0, 1).Add(-time.Nanosecond)
+
+		if filter.StartCreatedDate == nil || start.After(*filter.StartCreatedDate) {
+			filter.StartCreatedDate = &start
+		}
+
+		if filter.EndCreatedDate == nil || end.Before(*filter.EndCreatedDate) {
+			filter.EndCreatedDate = &end
+		}
+
+		if filter.EndCreatedDate.Before(*filter.StartCreatedDate) {
+			return resourcebus.QueryFilter{}, validate.NewFieldsError("added_at", errors.New("added_at is outside start_date and end_date"))
+		}
+	}
+
+	stats := []struct {
+		name     string
+		minValue string
+		maxValue string
+		minField **int16
+		maxField **int16
+	}{
+		{"cr", qp.CRMin, qp.CRMax, &filter.CR, &filter.CRMax},
+		{"cd", qp.CDMin, qp.CDMax, &filter.CD, &filter.CDMax},
+		{"dr", qp.DRMin, qp.DRMax, &filter.DR, &filter.DRMax},
+		{"fl", qp.FLMin, qp.FLMax, &filter.FL, &filter.FLMax},
+		{"hr", qp.HRMin, qp.HRMax, &filter.HR, &filter.HRMax},
+		{"ma", qp.MAMin, qp.MAMax, &filter.MA, &filter.MAMax},
+		{"pe", qp.PEMin, qp.PEMax, &filter.PE, &filter.PEMax},
+		{"oq", qp.OQMin, qp.OQMax, &filter.OQ, &filter.OQMax},
+		{"sr", qp.SRMin, qp.SRMax, &filter.SR, &filter.SRMax},
+		{"ut", qp.UTMin, qp.UTMax, &filter.UT, &filter.UTMax},
+		{"er", qp.ERMin, qp.ERMax, &filter.ER, &filter.ERMax},
+	}
+
+	for _, stat := range stats {
+		if err := parseStatRange(stat.name, stat.minValue, stat.maxValue, stat.minField, stat.maxField); err != nil {
+			return resourcebus.QueryFilter{}, err
+		}
+	}
 
 	return filter, nil
 }
+
+// parseDay parses the day of an added_at filter, given as a date or as an
+// RFC 3339 time, and returns its start. A date is a UTC day and a time falls
+// on the day of its own offset.
+func parseDay(value string) (time.Time, error) {
+	if t, err := time.Parse(time.DateOnly, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("added_at must be a date or an RFC 3339 time")
+	}
+
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()), nil
+}
+
+// parseStatRange parses the bounds of a stat range filter. Either bound may
+// be left out.
+func parseStatRange(stat string, minValue string, maxValue string, minField **int16, maxField **int16) error {
+	if minValue != "" {
+		v, err := strconv.ParseInt(minValue, 10, 16)
+		if err != nil || v < 0 {
+			return validate.NewFieldsError(stat+"_min", fmt.Errorf("%s_min must be a whole number between 0 and %d", stat, math.MaxInt16))
+		}
+		n := int16(v)
+		*minField = &n
+	}
+
+	if maxValue != "" {
+		v, err := strconv.ParseInt(maxValue, 10, 16)
+		if err != nil || v < 0 {
+			return validate.NewFieldsError(stat+"_max", fmt.Errorf("%s_max must be a whole number between 0 and %d", stat, math.MaxInt16))
+		}
+		n := int16(v)
+		*maxField = &n
+	}
+
+	if *minField != nil && *maxField != nil && **minField > **maxField {
+		return validate.NewFieldsError(stat+"_max", fmt.Errorf("%s_max is below %s_min", stat, stat))
+	}
+
+	return nil
+}
+
+// maxBestRows caps the number of resources a best query returns.
+const maxBestRows = 100
+
+func parseBestFilter(bp BestParams) (resourcebus.QueryFilter, resourcebus.Weights, int, error) {
+	var filter resourcebus.QueryFilter
+
+	switch {
+	case bp.ResourceType != "":
+		filter.ResourceType = &bp.ResourceType
+	case bp.ResourceGroup != "":
+		filter.ResourceGroup = &bp.ResourceGroup
+	default:
+		return resourcebus.QueryFilter{}, resourcebus.Weights{}, 0, validate.NewFieldsError("resource_group", errors.New("resource_group or resource_type is required"))
+	}
+
+	if bp.GalaxyID != "" {
+		galaxyID, err := uuid.Parse(bp.GalaxyID)
+		if err != nil {
+			return resourcebus.QueryFilter{}, resourcebus.Weights{}, 0, validate.NewFieldsError("galaxy_id", err)
+		}
+		filter.GalaxyID = &galaxyID
+	}
+
+	if bp.Available != "" {
+		available, err := strconv.ParseBool(bp.Available)
+		if err != nil {
+			return resourcebus.QueryFilter{}, resourcebus.Weights{}, 0, validate.NewFieldsError("available", err)
+		}
+		filter.Available = &available
+	}
+
+	weights, err := parseWeights(bp.Weights)
+	if err != nil {
+		return resourcebus.QueryFilter{}, resourcebus.Weights{}, 0, validate.NewFieldsError("weights", err)
+	}
+
+	rows := 10
+	if bp.Rows != "" {
+		rows, err = strconv.Atoi(bp.Rows)
+		if err != nil {
+			return resourcebus.QueryFilter{}, resourcebus.Weights{}, 0, validate.NewFieldsError("rows", err)
+		}
+	}
+
+	if rows < 1 || rows > maxBestRows {
+		return resourcebus.QueryFilter{}, resourcebus.Weights{}, 0, validate.NewFieldsError("rows", fmt.Errorf("rows must be between 1 and %d", maxBestRows))
+	}
+
+	return filter, weights, rows, nil
+}
+
+// parseWeights parses a weight formula of the form "oq:66,sr:33".
+func parseWeights(formula string) (resourcebus.Weights, error) {
+	if formula == "" {
+		return resourcebus.Weights{}, errors.New("weights is required")
+	}
+
+	var w resourcebus.Weights
+	stats := map[string]*int16{
+		"cr": &w.CR, "cd": &w.CD, "dr": &w.DR, "fl": &w.FL, "hr": &w.HR, "ma": &w.MA,
+		"pe": &w.PE, "oq": &w.OQ, "sr": &w.SR, "ut": &w.UT, "er": &w.ER,
+	}
+
+	for _, term := range strings.Split(formula, ",") {
+		stat, weight, found := strings.Cut(strings.TrimSpace(term), ":")
+		if !found {
+			return resourcebus.Weights{}, fmt.Errorf("weight %q must be of the form stat:weight", term)
+		}
+
+		field, exists := stats[strings.ToLower(stat)]
+		if !exists {
+			return resourcebus.Weights{}, fmt.Errorf("unknown stat %q", stat)
+		}
+
+		v, err := strconv.ParseInt(weight, 10, 16)
+		if err != nil {
+			return resourcebus.Weights{}, fmt.Errorf("weight for %s: %w", stat, err)
+		}
+		*field = int16(v)
+	}
+
+	if err := w.Validate(); err != nil {
+		return resourcebus.Weights{}, err
+	}
+
+	return w, nil
+}
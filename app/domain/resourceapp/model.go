@@ -13,40 +13,79 @@ import (
 
 // QueryParams represents the set of possible query strings.
 type QueryParams struct {
-	Page         string
-	Rows         string
-	OrderBy      string
-	ID           string
-	Name         string
+	Page          string
+	Rows          string
+	OrderBy       string
+	Cursor        string
+	ID            string
+	Name          string
+	AddedAtDate   string
 	ResourceType  string
 	ResourceGroup string
-	AddedAtDate   string
+	Planet        string
+	Verified      string
+	StartDate     string
+	EndDate       string
+	CRMin         string
+	CRMax         string
+	CDMin         string
+	CDMax         string
+	DRMin         string
+	DRMax         string
+	FLMin         string
+	FLMax         string
+	HRMin         string
+	HRMax         string
+	MAMin         string
+	MAMax         string
+	PEMin         string
+	PEMax         string
+	OQMin         string
+	OQMax         string
+	SRMin         string
+	SRMax         string
+	UTMin         string
+	UTMax         string
+	ERMin         string
+	ERMax         string
+}
+
+// BestParams represents the set of possible query strings for ranking
+// resources by a weighted score.
+type BestParams struct {
+	GalaxyID      string
+	ResourceType  string
+	ResourceGroup string
+	Weights       string
+	Available     string
+	Rows          string
 }
 
 // Resource represents information about an individual resource.
 type Resource struct {
-	ID                string `json:"id"`
-	Name              string `json:"name"`
-	GalaxyID          string `json:"galaxyID"`
-	AddedAtDate       string `json:"addedAtDate"`
-	UpdatedAtDate     string `json:"updatedAtDate"`
-	AddedUserID       string `json:"addedUserID"`
-	ResourceType      string `json:"resourceType"`
-	UnavailableAt     string `json:"unavailableAt"`
-	UnavailableUserID string `json:"unavailableUserID"`
-	Verified          bool   `json:"verified"`
-	VerifiedUserID    string `json:"verifiedUserID"`
-	CR                int16  `json:"cr"`
-	CD                int16  `json:"cd"`
-	DR                int16  `json:"dr"`
-	FL                int16  `json:"fl"`
-	HR                int16  `json:"hr"`
-	MA                int16  `json:"ma"`
-	PE                int16  `json:"pe"`
-	OQ                int16  `json:"oq"`
-	SR                int16  `json:"sr"`
-	UT                int16  `json:"ut"`
-	ER                int16  `json:"er"`
+	ID                string  `json:"id"`
+	Name              string  `json:"name"`
+	GalaxyID          string  `json:"galaxyID"`
+	AddedAtDate       string  `json:"addedAtDate"`
+	UpdatedAtDate     string  `json:"updatedAtDate"`
+	AddedUserID       string  `json:"addedUserID"`
+	ResourceType      string  `json:"resourceType"`
+	UnavailableAt     string  `json:"unavailableAt"`
+	UnavailableUserID string  `json:"unavailableUserID"`
+	Verified          bool    `json:"verified"`
+	VerifiedUserID    string  `json:"verifiedUserID"`
+	CR                int16   `json:"cr"`
+	CD                int16   `json:"cd"`
+	DR                int16   `json:"dr"`
+	FL                int16   `json:"fl"`
+	HR                int16   `json:"hr"`
+	MA                int16   `json:"ma"`
+	PE                int16   `json:"pe"`
+	OQ                int16   `json:"oq"`
+	SR                int16   `json:"sr"`
+	UT                int16   `json:"ut"`
+	ER                int16   `json:"er"`
+	Planets           []int16 `json:"planets"`
 }
 
 // Encode implments the encoder interface.
@@ -85,6 +124,7 @@ func toAppResource(bus resourcebus.Resource) Resource {
 		SR:                int16(bus.SR),
 		UT:                int16(bus.UT),
 		ER:                int16(bus.ER),
+		Planets:           bus.Planets,
 	}
 }
 
@@ -101,21 +141,21 @@ func toAppResources(resources []resourcebus.Resource) []Resource {
 
 // NewResource defines the data needed to add a new resource.
 type NewResource struct {
-	Name         string `json:"name" validate:"required"`
-	GalaxyID     string `json:"galaxyID" validate:"required"`
-	AddedUserID  string `json:"addedUserID" validate:"required"`
-	ResourceType string `json:"resourceType" validate:"required"`
-	CR           int16  `json:"cr"`
-	CD           int16  `json:"cd"`
-	DR           int16  `json:"dr"`
-	FL           int16  `json:"fl"`
-	HR           int16  `json:"hr"`
-	MA           int16  `json:"ma"`
-	PE           int16  `json:"pe"`
-	OQ           int16  `json:"oq" validate:"required"`
-	SR           int16  `json:"sr"`
-	UT           int16  `json:"ut"`
-	ER           int16  `json:"er"`
+	Name         string  `json:"name" validate:"required"`
+	GalaxyID     string  `json:"galaxyID" validate:"required"`
+	ResourceType string  `json:"resourceType" validate:"required"`
+	CR           int16   `json:"cr"`
+	CD           int16   `json:"cd"`
+	DR           int16   `json:"dr"`
+	FL           int16   `json:"fl"`
+	HR           int16   `json:"hr"`
+	MA           int16   `json:"ma"`
+	PE           int16   `json:"pe"`
+	OQ           int16   `json:"oq" validate:"required"`
+	SR           int16   `json:"sr"`
+	UT           int16   `json:"ut"`
+	ER           int16   `json:"er"`
+	Planets      []int16 `json:"planets"`
 }
 
 // Decode implments the decoder interface.
@@ -132,7 +172,7 @@ func (app NewResource) Validate() error {
 	return nil
 }
 
-func toBusNewResource(app NewResource) (resourcebus.NewResource, error) {
+func toBusNewResource(app NewResource, addedUserID uuid.UUID) (resourcebus.NewResource, error) {
 
 	name, err := resourcebus.Names.Parse(app.Name)
 	if err != nil {
@@ -144,11 +184,6 @@ func toBusNewResource(app NewResource) (resourcebus.NewResource, error) {
 		return resourcebus.NewResource{}, fmt.Errorf("parse: %w", err)
 	}
 
-	addedUserID, err := uuid.Parse(app.AddedUserID)
-	if err != nil {
-		return resourcebus.NewResource{}, fmt.Errorf("parse: %w", err)
-	}
-
 	bus := resourcebus.NewResource{
 		Name:         name,
 		GalaxyID:     galaxyID,
@@ -165,6 +200,7 @@ func toBusNewResource(app NewResource) (resourcebus.NewResource, error) {
 		SR:           app.SR,
 		UT:           app.UT,
 		ER:           app.ER,
+		Planets:      app.Planets,
 	}
 
 	return bus, nil
@@ -172,27 +208,26 @@ func toBusNewResource(app NewResource) (resourcebus.NewResource, error) {
 
 // =============================================================================
 
-// UpdateResource defines the data needed to update a resource.
+// UpdateResource defines the data needed to update a resource. Whether the
+// resource is still in spawn is changed through the despawn and reactivate
+// endpoints, and whether it is verified through the confirm and dispute
+// endpoints.
 type UpdateResource struct {
-	Name              *string    `json:"name"`
-	GalaxyID          *string    `json:"galaxyID"`
-	AddedUserID       *string    `json:"addedUserID"`
-	ResourceType      *string    `json:"resourceType"`
-	UnavailableAt     *time.Time `json:"unavailableAt"`
-	UnavailableUserID *string    `json:"unavailableUserID"`
-	Verified          *bool      `json:"verified"`
-	VerifiedUserID    *string    `json:"verifiedUserID"`
-	CR                *int16     `json:"cr"`
-	CD                *int16     `json:"cd"`
-	DR                *int16     `json:"dr"`
-	FL                *int16     `json:"fl"`
-	HR                *int16     `json:"hr"`
-	MA                *int16     `json:"ma"`
-	PE                *int16     `json:"pe"`
-	OQ                *int16     `json:"oq"`
-	SR                *int16     `json:"sr"`
-	UT                *int16     `json:"ut"`
-	ER                *int16     `json:"er"`
+	Name         *string `json:"name"`
+	GalaxyID     *string `json:"galaxyID"`
+	ResourceType *string `json:"resourceType"`
+	CR           *int16  `json:"cr"`
+	CD           *int16  `json:"cd"`
+	DR           *int16  `json:"dr"`
+	FL           *int16  `json:"fl"`
+	HR           *int16  `json:"hr"`
+	MA           *int16  `json:"ma"`
+	PE           *int16  `json:"pe"`
+	OQ           *int16  `json:"oq"`
+	SR           *int16  `json:"sr"`
+	UT           *int16  `json:"ut"`
+	ER           *int16  `json:"er"`
+	Planets      []int16 `json:"planets"`
 }
 
 // Decode implments the decoder interface.
@@ -220,41 +255,20 @@ func toBusUpdateResource(app UpdateResource) (resourcebus.UpdateResource, error)
 		name = &nm
 	}
 
-	var unavailableUserID *uuid.UUID
-	if app.UnavailableUserID != nil {
-		id, err := uuid.Parse(*app.UnavailableUserID)
-		if err != nil {
-			return resourcebus.UpdateResource{}, fmt.Errorf("parse: %w", err)
-		}
-		unavailableUserID = &id
-	}
-
-	var verifiedUserID *uuid.UUID
-	if app.VerifiedUserID != nil {
-		id, err := uuid.Parse(*app.VerifiedUserID)
-		if err != nil {
-			return resourcebus.UpdateResource{}, fmt.Errorf("parse: %w", err)
-		}
-		verifiedUserID = &id
-	}
-
 	bus := resourcebus.UpdateResource{
-		Name:              name,
-		UnavailableAt:     app.UnavailableAt,
-		UnavailableUserID: unavailableUserID,
-		Verified:          app.Verified,
-		VerifiedUserID:    verifiedUserID,
-		CR:                app.CR,
-		CD:                app.CD,
-		DR:                app.DR,
-		FL:                app.FL,
-		HR:                app.HR,
-		MA:                app.MA,
-		PE:                app.PE,
-		OQ:                app.OQ,
-		SR:                app.SR,
-		UT:                app.UT,
-		ER:                app.ER,
+		Name:    name,
+		CR:      app.CR,
+		CD:      app.CD,
+		DR:      app.DR,
+		FL:      app.FL,
+		HR:      app.HR,
+		MA:      app.MA,
+		PE:      app.PE,
+		OQ:      app.OQ,
+		SR:      app.SR,
+		UT:      app.UT,
+		ER:      app.ER,
+		Planets: app.Planets,
 	}
 
 	return bus, nil
@@ -348,3 +362,124 @@ func (app BulkDeleteResult) Encode() ([]byte, string, error) {
 	data, err := json.Marshal(app)
 	return data, "application/json", err
 }
+
+// =============================================================================
+
+// ScoredResource represents a resource and its weighted score.
+type ScoredResource struct {
+	Resource Resource `json:"resource"`
+	Score    float64  `json:"score"`
+}
+
+// BestResources represents resources ranked by their weighted score.
+type BestResources struct {
+	Items []ScoredResource `json:"items"`
+}
+
+// Encode implements the encoder interface.
+func (app BestResources) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppBestResources(bus []resourcebus.ScoredResource) BestResources {
+	items := make([]ScoredResource, len(bus))
+	for i, sr := range bus {
+		items[i] = ScoredResource{
+			Resource: toAppResource(sr.Resource),
+			Score:    sr.Score,
+		}
+	}
+
+	return BestResources{
+		Items: items,
+	}
+}
+
+// =============================================================================
+
+// StatusChange represents a resource moving in or out of spawn.
+type StatusChange struct {
+	ID         string `json:"id"`
+	ResourceID string `json:"resourceID"`
+	Status     string `json:"status"`
+	UserID     string `json:"userID"`
+	ChangedAt  string `json:"changedAt"`
+}
+
+// StatusHistory represents the spawn status changes of a resource.
+type StatusHistory struct {
+	Items []StatusChange `json:"items"`
+}
+
+// Encode implements the encoder interface.
+func (app StatusHistory) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppStatusHistory(bus []resourcebus.StatusChange) StatusHistory {
+	items := make([]StatusChange, len(bus))
+	for i, sc := range bus {
+		items[i] = StatusChange{
+			ID:         sc.ID.String(),
+			ResourceID: sc.ResourceID.String(),
+			Status:     sc.Status.String(),
+			UserID:     sc.UserID.String(),
+			ChangedAt:  sc.ChangedAt.Format(time.RFC3339),
+		}
+	}
+
+	return StatusHistory{
+		Items: items,
+	}
+}
+
+// =============================================================================
+
+// Verification represents a user's vote on the stats of a resource.
+type Verification struct {
+	UserID      string `json:"userID"`
+	Vote        string `json:"vote"`
+	DateCreated string `json:"dateCreated"`
+}
+
+// Verifications represents the verification state of a resource: the tally
+// of votes against the galaxy's threshold and, when queried, the votes cast.
+type Verifications struct {
+	ResourceID    string         `json:"resourceID"`
+	Verified      bool           `json:"verified"`
+	Confirmations int            `json:"confirmations"`
+	Disputes      int            `json:"disputes"`
+	Threshold     int16          `json:"threshold"`
+	Votes         []Verification `json:"votes,omitempty"`
+}
+
+// Encode implements the encoder interface.
+func (app Verifications) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppVerifications(res resourcebus.Resource, tally resourcebus.VerificationTally, bus []resourcebus.Verification) Verifications {
+	var votes []Verification
+	if bus != nil {
+		votes = make([]Verification, len(bus))
+		for i, v := range bus {
+			votes[i] = Verification{
+				UserID:      v.UserID.String(),
+				Vote:        v.Vote.String(),
+				DateCreated: v.DateCreated.Format(time.RFC3339),
+			}
+		}
+	}
+
+	return Verifications{
+		ResourceID:    res.ID.String(),
+		Verified:      res.Verified,
+		Confirmations: tally.Confirmations,
+		Disputes:      tally.Disputes,
+		Threshold:     tally.Threshold,
+		Votes:         votes,
+	}
+}
@@ -5,43 +5,65 @@ import (
 	"errors"
 	"github.com/godwinrob/harvester/app/sdk/bulk"
 	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/mid"
 	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
 	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/domain/userbus"
 	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/foundation/validate"
 	"github.com/google/uuid"
 )
 
 // App manages the set of app layer api functions for the resource domain.
 type App struct {
 	resourceBus *resourcebus.Business
+	galaxyBus   *galaxybus.Business
+	pageLimits  page.Limits
 }
 
 // NewApp constructs a resource app API for use.
-func NewApp(resourceBus *resourcebus.Business) *App {
+func NewApp(resourceBus *resourcebus.Business, galaxyBus *galaxybus.Business, pageLimits page.Limits) *App {
 	return &App{
 		resourceBus: resourceBus,
+		galaxyBus:   galaxyBus,
+		pageLimits:  pageLimits,
 	}
 }
 
 // NewAppWithAuth constructs a resource app API for use with auth support.
-func NewAppWithAuth(resourceBus *resourcebus.Business) *App {
+func NewAppWithAuth(resourceBus *resourcebus.Business, galaxyBus *galaxybus.Business, pageLimits page.Limits) *App {
 	return &App{
 		resourceBus: resourceBus,
+		galaxyBus:   galaxyBus,
+		pageLimits:  pageLimits,
 	}
 }
 
 // Create adds a new resource to the system.
 func (a *App) Create(ctx context.Context, app NewResource) (Resource, error) {
-	nc, err := toBusNewResource(app)
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Resource{}, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	nc, err := toBusNewResource(app, userID)
 	if err != nil {
 		return Resource{}, errs.New(errs.FailedPrecondition, err)
 	}
 
+	if err := a.checkWrite(ctx, nc.GalaxyID); err != nil {
+		return Resource{}, err
+	}
+
 	usr, err := a.resourceBus.Create(ctx, nc)
 	if err != nil {
 		if errors.Is(err, resourcebus.ErrUniqueName) {
 			return Resource{}, errs.New(errs.Aborted, resourcebus.ErrUniqueName)
 		}
+		if fe := validate.GetFieldErrors(err); fe != nil {
+			return Resource{}, errs.New(errs.FailedPrecondition, fe)
+		}
 		return Resource{}, errs.Newf(errs.Internal, "create: usr[%+v]: %s", usr, err)
 	}
 
@@ -65,14 +87,160 @@ func (a *App) Update(ctx context.Context, resourceID string, app UpdateResource)
 		return Resource{}, errs.Newf(errs.Internal, "resource missing in context: %s", err)
 	}
 
+	if err := a.checkWrite(ctx, usr.GalaxyID); err != nil {
+		return Resource{}, err
+	}
+
 	updUsr, err := a.resourceBus.Update(ctx, usr, uu)
 	if err != nil {
+		if errors.Is(err, resourcebus.ErrUniqueName) {
+			return Resource{}, errs.New(errs.Aborted, resourcebus.ErrUniqueName)
+		}
+		if fe := validate.GetFieldErrors(err); fe != nil {
+			return Resource{}, errs.New(errs.FailedPrecondition, fe)
+		}
 		return Resource{}, errs.Newf(errs.Internal, "update: resourceID[%s] uu[%+v]: %s", usr.ID, uu, err)
 	}
 
 	return toAppResource(updUsr), nil
 }
 
+// Despawn marks a resource as no longer in spawn.
+func (a *App) Despawn(ctx context.Context, resourceID string) (Resource, error) {
+	return a.changeStatus(ctx, resourceID, a.resourceBus.Despawn)
+}
+
+// Reactivate puts a despawned resource back in spawn.
+func (a *App) Reactivate(ctx context.Context, resourceID string) (Resource, error) {
+	return a.changeStatus(ctx, resourceID, a.resourceBus.Reactivate)
+}
+
+func (a *App) changeStatus(ctx context.Context, resourceID string, change func(context.Context, resourcebus.Resource, uuid.UUID) (resourcebus.Resource, error)) (Resource, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Resource{}, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	id, err := uuid.Parse(resourceID)
+	if err != nil {
+		return Resource{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	res, err := a.resourceBus.QueryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, resourcebus.ErrNotFound) {
+			return Resource{}, errs.New(errs.NotFound, err)
+		}
+		return Resource{}, errs.Newf(errs.Internal, "querybyid: resourceID[%s]: %s", id, err)
+	}
+
+	if err := a.checkWrite(ctx, res.GalaxyID); err != nil {
+		return Resource{}, err
+	}
+
+	updRes, err := change(ctx, res, userID)
+	if err != nil {
+		if errors.Is(err, resourcebus.ErrAlreadyUnavailable) || errors.Is(err, resourcebus.ErrAlreadyAvailable) {
+			return Resource{}, errs.New(errs.FailedPrecondition, err)
+		}
+		if errors.Is(err, resourcebus.ErrStatusConflict) {
+			return Resource{}, errs.New(errs.Aborted, resourcebus.ErrStatusConflict)
+		}
+		return Resource{}, errs.Newf(errs.Internal, "changestatus: resourceID[%s]: %s", res.ID, err)
+	}
+
+	return toAppResource(updRes), nil
+}
+
+// QueryStatusHistory returns the spawn status changes of a resource.
+func (a *App) QueryStatusHistory(ctx context.Context, resourceID string) (StatusHistory, error) {
+	id, err := uuid.Parse(resourceID)
+	if err != nil {
+		return StatusHistory{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	if _, err := a.resourceBus.QueryByID(ctx, id); err != nil {
+		if errors.Is(err, resourcebus.ErrNotFound) {
+			return StatusHistory{}, errs.New(errs.NotFound, err)
+		}
+		return StatusHistory{}, errs.Newf(errs.Internal, "querybyid: resourceID[%s]: %s", id, err)
+	}
+
+	history, err := a.resourceBus.QueryStatusHistory(ctx, id)
+	if err != nil {
+		return StatusHistory{}, errs.Newf(errs.Internal, "querystatushistory: resourceID[%s]: %s", id, err)
+	}
+
+	return toAppStatusHistory(history), nil
+}
+
+// Confirm records the user confirming the stats of a resource.
+func (a *App) Confirm(ctx context.Context, resourceID string) (Verifications, error) {
+	return a.vote(ctx, resourceID, a.resourceBus.Confirm)
+}
+
+// Dispute records the user disputing the stats of a resource.
+func (a *App) Dispute(ctx context.Context, resourceID string) (Verifications, error) {
+	return a.vote(ctx, resourceID, a.resourceBus.Dispute)
+}
+
+func (a *App) vote(ctx context.Context, resourceID string, cast func(context.Context, resourcebus.Resource, uuid.UUID) (resourcebus.Resource, resourcebus.VerificationTally, error)) (Verifications, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return Verifications{}, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	id, err := uuid.Parse(resourceID)
+	if err != nil {
+		return Verifications{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	res, err := a.resourceBus.QueryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, resourcebus.ErrNotFound) {
+			return Verifications{}, errs.New(errs.NotFound, err)
+		}
+		return Verifications{}, errs.Newf(errs.Internal, "querybyid: resourceID[%s]: %s", id, err)
+	}
+
+	if err := a.checkWrite(ctx, res.GalaxyID); err != nil {
+		return Verifications{}, err
+	}
+
+	updRes, tally, err := cast(ctx, res, userID)
+	if err != nil {
+		if errors.Is(err, resourcebus.ErrSelfVerification) {
+			return Verifications{}, errs.New(errs.FailedPrecondition, err)
+		}
+		return Verifications{}, errs.Newf(errs.Internal, "vote: resourceID[%s]: %s", res.ID, err)
+	}
+
+	return toAppVerifications(updRes, tally, nil), nil
+}
+
+// QueryVerifications returns the votes cast on a resource and their tally.
+func (a *App) QueryVerifications(ctx context.Context, resourceID string) (Verifications, error) {
+	id, err := uuid.Parse(resourceID)
+	if err != nil {
+		return Verifications{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	res, err := a.resourceBus.QueryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, resourcebus.ErrNotFound) {
+			return Verifications{}, errs.New(errs.NotFound, err)
+		}
+		return Verifications{}, errs.Newf(errs.Internal, "querybyid: resourceID[%s]: %s", id, err)
+	}
+
+	votes, tally, err := a.resourceBus.QueryVerifications(ctx, res)
+	if err != nil {
+		return Verifications{}, errs.Newf(errs.Internal, "queryverifications: resourceID[%s]: %s", id, err)
+	}
+
+	return toAppVerifications(res, tally, votes), nil
+}
+
 // Delete removes a resource from the system.
 func (a *App) Delete(ctx context.Context, resourceID string) error {
 	id, err := uuid.Parse(resourceID)
@@ -85,6 +253,10 @@ func (a *App) Delete(ctx context.Context, resourceID string) error {
 		return errs.Newf(errs.Internal, "resource missing in context: %s", err)
 	}
 
+	if err := a.checkWrite(ctx, usr.GalaxyID); err != nil {
+		return err
+	}
+
 	if err := a.resourceBus.Delete(ctx, usr); err != nil {
 		return errs.Newf(errs.Internal, "delete: resourceID[%s]: %s", usr.ID, err)
 	}
@@ -94,7 +266,7 @@ func (a *App) Delete(ctx context.Context, resourceID string) error {
 
 // Query returns a list of resources with paging.
 func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Resource], error) {
-	pg, err := page.Parse(qp.Page, qp.Rows)
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
 	if err != nil {
 		return page.Document[Resource]{}, err
 	}
@@ -109,7 +281,21 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Resource
 		return page.Document[Resource]{}, err
 	}
 
-	usrs, err := a.resourceBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[Resource]{}, err
+	}
+
+	// Later pages asked for by number keep using an offset. The first page
+	// and pages asked for by cursor come with the cursor for the next one.
+	var usrs []resourcebus.Resource
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		usrs, err = a.resourceBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		usrs, next, err = a.resourceBus.QueryAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
 	if err != nil {
 		return page.Document[Resource]{}, errs.Newf(errs.Internal, "query: %s", err)
 	}
@@ -119,7 +305,12 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Resource
 		return page.Document[Resource]{}, errs.Newf(errs.Internal, "count: %s", err)
 	}
 
-	return page.NewDocument(toAppResources(usrs), total, pg.Number, pg.RowsPerPage), nil
+	doc := page.NewDocument(toAppResources(usrs), total, pg.Number, pg.RowsPerPage)
+	if len(usrs) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
+	}
+
+	return doc, nil
 }
 
 // QueryByID returns a resource by its Ia.
@@ -137,22 +328,55 @@ func (a *App) QueryByID(ctx context.Context, resourceID string) (Resource, error
 	return toAppResource(usr), nil
 }
 
-func (a *App) QueryByName(ctx context.Context, resourceName string) (Resource, error) {
+// QueryByName returns a resource by its name within a galaxy.
+func (a *App) QueryByName(ctx context.Context, galaxyID string, resourceName string) (Resource, error) {
+	if galaxyID == "" {
+		return Resource{}, errs.New(errs.FailedPrecondition, validate.NewFieldsError("galaxy_id", errors.New("galaxy_id is required")))
+	}
+
+	gid, err := uuid.Parse(galaxyID)
+	if err != nil {
+		return Resource{}, errs.New(errs.FailedPrecondition, validate.NewFieldsError("galaxy_id", err))
+	}
 
-	usr, err := a.resourceBus.QueryByName(ctx, resourceName)
+	usr, err := a.resourceBus.QueryByName(ctx, gid, resourceName)
 	if err != nil {
-		return Resource{}, errs.Newf(errs.Internal, "resource missing in context: %s", err)
+		if errors.Is(err, resourcebus.ErrNotFound) {
+			return Resource{}, errs.New(errs.NotFound, err)
+		}
+		return Resource{}, errs.Newf(errs.Internal, "querybyname: galaxyID[%s] name[%s]: %s", gid, resourceName, err)
 	}
 
 	return toAppResource(usr), nil
 }
 
+// QueryBest returns the resources ranked by their weighted score for the
+// requested weights, highest first.
+func (a *App) QueryBest(ctx context.Context, bp BestParams) (BestResources, error) {
+	filter, weights, rows, err := parseBestFilter(bp)
+	if err != nil {
+		return BestResources{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	resources, err := a.resourceBus.QueryBest(ctx, filter, weights, rows)
+	if err != nil {
+		return BestResources{}, errs.Newf(errs.Internal, "querybest: %s", err)
+	}
+
+	return toAppBestResources(resources), nil
+}
+
 // BulkCreate adds multiple new resources to the system.
 func (a *App) BulkCreate(ctx context.Context, app BulkNewResources) (BulkResources, error) {
 	if err := bulk.ValidateBatchSize(len(app.Items)); err != nil {
 		return BulkResources{}, errs.New(errs.FailedPrecondition, err)
 	}
 
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return BulkResources{}, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
 	// Validate and convert all items first (fail-fast)
 	var bulkErrors []errs.BulkItemError
 	newResources := make([]resourcebus.NewResource, 0, len(app.Items))
@@ -167,7 +391,7 @@ func (a *App) BulkCreate(ctx context.Context, app BulkNewResources) (BulkResourc
 			continue
 		}
 
-		nr, err := toBusNewResource(item)
+		nr, err := toBusNewResource(item, userID)
 		if err != nil {
 			bulkErrors = append(bulkErrors, errs.BulkItemError{
 				Index: i,
@@ -183,8 +407,21 @@ func (a *App) BulkCreate(ctx context.Context, app BulkNewResources) (BulkResourc
 		return BulkResources{}, errs.NewBulkValidationError(bulkErrors)
 	}
 
+	galaxyIDs := make([]uuid.UUID, len(newResources))
+	for i, nr := range newResources {
+		galaxyIDs[i] = nr.GalaxyID
+	}
+
+	if err := a.checkWrite(ctx, galaxyIDs...); err != nil {
+		return BulkResources{}, err
+	}
+
 	resources, err := a.resourceBus.BulkCreate(ctx, newResources)
 	if err != nil {
+		var itemErrs resourcebus.ItemErrors
+		if errors.As(err, &itemErrs) {
+			return BulkResources{}, errs.NewBulkValidationError(toBulkItemErrors(itemErrs))
+		}
 		if errors.Is(err, resourcebus.ErrUniqueName) {
 			return BulkResources{}, errs.New(errs.Aborted, resourcebus.ErrUniqueName)
 		}
@@ -247,8 +484,28 @@ func (a *App) BulkUpdate(ctx context.Context, app BulkUpdateResources) (BulkReso
 		return BulkResources{}, errs.NewBulkValidationError(bulkErrors)
 	}
 
+	galaxyIDs := make([]uuid.UUID, len(updates))
+	for i, upd := range updates {
+		res, err := a.resourceBus.QueryByID(ctx, upd.ID)
+		if err != nil {
+			if errors.Is(err, resourcebus.ErrNotFound) {
+				return BulkResources{}, errs.New(errs.NotFound, resourcebus.ErrNotFound)
+			}
+			return BulkResources{}, errs.Newf(errs.Internal, "querybyid: resourceID[%s]: %s", upd.ID, err)
+		}
+		galaxyIDs[i] = res.GalaxyID
+	}
+
+	if err := a.checkWrite(ctx, galaxyIDs...); err != nil {
+		return BulkResources{}, err
+	}
+
 	resources, err := a.resourceBus.BulkUpdate(ctx, updates)
 	if err != nil {
+		var itemErrs resourcebus.ItemErrors
+		if errors.As(err, &itemErrs) {
+			return BulkResources{}, errs.NewBulkValidationError(toBulkItemErrors(itemErrs))
+		}
 		if errors.Is(err, resourcebus.ErrUniqueName) {
 			return BulkResources{}, errs.New(errs.Aborted, resourcebus.ErrUniqueName)
 		}
@@ -299,3 +556,80 @@ func (a *App) BulkDelete(ctx context.Context, app BulkDeleteResources) (BulkDele
 		Deleted: len(ids),
 	}, nil
 }
+
+// checkWrite confirms each galaxy accepts writes and the caller may write to
+// it. Disabled galaxies reject all writes. Otherwise admins may write to any
+// galaxy and other users must be joined owners or editors of the galaxy.
+func (a *App) checkWrite(ctx context.Context, galaxyIDs ...uuid.UUID) error {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	isAdmin := mid.GetClaims(ctx).HasRole(userbus.Roles.Admin)
+
+	checked := make(map[uuid.UUID]bool)
+	for _, galaxyID := range galaxyIDs {
+		if checked[galaxyID] {
+			continue
+		}
+		checked[galaxyID] = true
+
+		gal, err := a.galaxyBus.QueryByID(ctx, galaxyID)
+		if err != nil {
+			if errors.Is(err, galaxybus.ErrNotFound) {
+				return errs.Newf(errs.FailedPrecondition, "galaxy %s does not exist", galaxyID)
+			}
+			return errs.Newf(errs.Internal, "querybyid: galaxyID[%s]: %s", galaxyID, err)
+		}
+
+		if !gal.Enabled {
+			return errs.Newf(errs.FailedPrecondition, "galaxy %s: %s", galaxyID, galaxybus.ErrDisabled)
+		}
+
+		if isAdmin {
+			continue
+		}
+
+		mem, err := a.galaxyBus.QueryMember(ctx, galaxyID, userID)
+		if err != nil {
+			if errors.Is(err, galaxybus.ErrMemberNotFound) {
+				return errs.Newf(errs.PermissionDenied, "user is not a member of galaxy %s", galaxyID)
+			}
+			return errs.Newf(errs.Internal, "querymember: galaxyID[%s] userID[%s]: %s", galaxyID, userID, err)
+		}
+
+		if !mem.CanWrite() {
+			return errs.Newf(errs.PermissionDenied, "user can not add or change resources in galaxy %s", galaxyID)
+		}
+	}
+
+	return nil
+}
+
+// toBulkItemErrors converts the per item validation failures reported by the
+// business layer into bulk item errors, one for each failing field.
+func toBulkItemErrors(itemErrs resourcebus.ItemErrors) []errs.BulkItemError {
+	var bulkErrors []errs.BulkItemError
+	for _, ie := range itemErrs {
+		fe := validate.GetFieldErrors(ie.Err)
+		if fe == nil {
+			bulkErrors = append(bulkErrors, errs.BulkItemError{
+				Index: ie.Index,
+				Field: "item",
+				Error: ie.Err.Error(),
+			})
+			continue
+		}
+
+		for _, f := range fe {
+			bulkErrors = append(bulkErrors, errs.BulkItemError{
+				Index: ie.Index,
+				Field: f.Field,
+				Error: f.Err,
+			})
+		}
+	}
+
+	return bulkErrors
+}
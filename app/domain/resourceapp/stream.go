@@ -0,0 +1,84 @@
+package resourceapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/google/uuid"
+)
+
+// EventOverflow is the action of the last event of a subscriber that fell too
+// far behind and missed events.
+const EventOverflow = resourcebus.EventOverflow
+
+// Event represents a change to a resource of a galaxy.
+type Event struct {
+	Action   string
+	Resource Resource
+}
+
+// Encode implements the encoder interface for the resource of the event. An
+// overflow event has no resource and is encoded as an empty object.
+func (app Event) Encode() ([]byte, string, error) {
+	if app.Action == EventOverflow {
+		return []byte("{}"), "application/json", nil
+	}
+
+	data, err := json.Marshal(app.Resource)
+	return data, "application/json", err
+}
+
+// Subscribe returns the changes made to the resources of the galaxy until the
+// context is canceled or the subscriber falls too far behind, at which point
+// the channel is closed. Falling behind is reported by a last EventOverflow.
+func (a *App) Subscribe(ctx context.Context, galaxyID string) (<-chan Event, error) {
+	gid, err := uuid.Parse(galaxyID)
+	if err != nil {
+		return nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	if _, err := a.galaxyBus.QueryByID(ctx, gid); err != nil {
+		if errors.Is(err, galaxybus.ErrNotFound) {
+			return nil, errs.New(errs.NotFound, err)
+		}
+		return nil, errs.Newf(errs.Internal, "subscribe: galaxyID[%s]: %s", gid, err)
+	}
+
+	busEvents, unsubscribe := a.resourceBus.Subscribe(gid)
+
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case evt := <-busEvents:
+				appEvt := Event{Action: evt.Action}
+				if evt.Action != EventOverflow {
+					appEvt.Resource = toAppResource(evt.Resource)
+				}
+
+				select {
+				case events <- appEvt:
+				case <-ctx.Done():
+					return
+				}
+
+				if evt.Action == EventOverflow {
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
@@ -11,6 +11,7 @@ type QueryParams struct {
 	Page          string
 	Rows          string
 	OrderBy       string
+	Cursor        string
 	ResourceGroup string
 	GroupName     string
 	GroupLevel    string
@@ -12,18 +12,20 @@ import (
 // App manages the set of app layer api functions for the resource group domain.
 type App struct {
 	resourceGroupBus *resourcegroupbus.Business
+	pageLimits       page.Limits
 }
 
 // NewApp constructs a resource group app API for use.
-func NewApp(resourceGroupBus *resourcegroupbus.Business) *App {
+func NewApp(resourceGroupBus *resourcegroupbus.Business, pageLimits page.Limits) *App {
 	return &App{
 		resourceGroupBus: resourceGroupBus,
+		pageLimits:       pageLimits,
 	}
 }
 
 // Query returns a list of resource groups with paging.
 func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[ResourceGroup], error) {
-	pg, err := page.Parse(qp.Page, qp.Rows)
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
 	if err != nil {
 		return page.Document[ResourceGroup]{}, err
 	}
@@ -38,7 +40,19 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Resource
 		return page.Document[ResourceGroup]{}, err
 	}
 
-	groups, err := a.resourceGroupBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[ResourceGroup]{}, err
+	}
+
+	var groups []resourcegroupbus.ResourceGroup
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		groups, err = a.resourceGroupBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		groups, next, err = a.resourceGroupBus.QueryAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
 	if err != nil {
 		return page.Document[ResourceGroup]{}, errs.Newf(errs.Internal, "query: %s", err)
 	}
@@ -48,7 +62,12 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Resource
 		return page.Document[ResourceGroup]{}, errs.Newf(errs.Internal, "count: %s", err)
 	}
 
-	return page.NewDocument(toAppResourceGroups(groups), total, pg.Number, pg.RowsPerPage), nil
+	doc := page.NewDocument(toAppResourceGroups(groups), total, pg.Number, pg.RowsPerPage)
+	if len(groups) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
+	}
+
+	return doc, nil
 }
 
 // QueryByID returns a resource group by its key.
@@ -13,6 +13,7 @@ type QueryParams struct {
 	Page             string
 	Rows             string
 	OrderBy          string
+	Cursor           string
 	ResourceType     string
 	ResourceTypeName string
 	ResourceCategory string
@@ -13,12 +13,14 @@ import (
 // App manages the set of app layer api functions for the resource type domain.
 type App struct {
 	resourceTypeBus *resourcetypebus.Business
+	pageLimits      page.Limits
 }
 
 // NewApp constructs a resource type app API for use.
-func NewApp(resourceTypeBus *resourcetypebus.Business) *App {
+func NewApp(resourceTypeBus *resourcetypebus.Business, pageLimits page.Limits) *App {
 	return &App{
 		resourceTypeBus: resourceTypeBus,
+		pageLimits:      pageLimits,
 	}
 }
 
@@ -70,7 +72,7 @@ func (a *App) Delete(ctx context.Context, resourceTypeKey string) error {
 
 // Query returns a list of resource types with paging.
 func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[ResourceType], error) {
-	pg, err := page.Parse(qp.Page, qp.Rows)
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
 	if err != nil {
 		return page.Document[ResourceType]{}, err
 	}
@@ -85,7 +87,19 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Resource
 		return page.Document[ResourceType]{}, err
 	}
 
-	rts, err := a.resourceTypeBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[ResourceType]{}, err
+	}
+
+	var rts []resourcetypebus.ResourceType
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		rts, err = a.resourceTypeBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		rts, next, err = a.resourceTypeBus.QueryAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
 	if err != nil {
 		return page.Document[ResourceType]{}, errs.Newf(errs.Internal, "query: %s", err)
 	}
@@ -95,7 +109,12 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Resource
 		return page.Document[ResourceType]{}, errs.Newf(errs.Internal, "count: %s", err)
 	}
 
-	return page.NewDocument(toAppResourceTypes(rts), total, pg.Number, pg.RowsPerPage), nil
+	doc := page.NewDocument(toAppResourceTypes(rts), total, pg.Number, pg.RowsPerPage)
+	if len(rts) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
+	}
+
+	return doc, nil
 }
 
 // QueryByID returns a resource type by its key.
@@ -0,0 +1,73 @@
+package schematicapp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+func parseFilter(qp QueryParams) (schematicbus.QueryFilter, error) {
+	var filter schematicbus.QueryFilter
+
+	if qp.ID != "" {
+		id, err := uuid.Parse(qp.ID)
+		if err != nil {
+			return schematicbus.QueryFilter{}, validate.NewFieldsError("schematic_id", err)
+		}
+		filter.ID = &id
+	}
+
+	if qp.Name != "" {
+		filter.Name = &qp.Name
+	}
+
+	if qp.ResourceType != "" {
+		filter.ResourceType = &qp.ResourceType
+	}
+
+	return filter, nil
+}
+
+// maxScoreRows caps the number of candidates returned per slot.
+const maxScoreRows = 100
+
+func parseScoreFilter(sp ScoreParams) (schematicbus.ScoreFilter, int, error) {
+	if sp.GalaxyID == "" {
+		return schematicbus.ScoreFilter{}, 0, validate.NewFieldsError("galaxy_id", errors.New("galaxy_id is required"))
+	}
+
+	galaxyID, err := uuid.Parse(sp.GalaxyID)
+	if err != nil {
+		return schematicbus.ScoreFilter{}, 0, validate.NewFieldsError("galaxy_id", err)
+	}
+
+	filter := schematicbus.ScoreFilter{
+		GalaxyID: galaxyID,
+	}
+
+	if sp.Available != "" {
+		available, err := strconv.ParseBool(sp.Available)
+		if err != nil {
+			return schematicbus.ScoreFilter{}, 0, validate.NewFieldsError("available", err)
+		}
+		filter.Available = &available
+	}
+
+	rows := 10
+	if sp.Rows != "" {
+		rows, err = strconv.Atoi(sp.Rows)
+		if err != nil {
+			return schematicbus.ScoreFilter{}, 0, validate.NewFieldsError("rows", err)
+		}
+	}
+
+	if rows < 1 || rows > maxScoreRows {
+		return schematicbus.ScoreFilter{}, 0, validate.NewFieldsError("rows", fmt.Errorf("rows must be between 1 and %d", maxScoreRows))
+	}
+
+	return filter, rows, nil
+}
@@ -0,0 +1,298 @@
+package schematicapp
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+	"github.com/godwinrob/harvester/foundation/validate"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Page         string
+	Rows         string
+	OrderBy      string
+	Cursor       string
+	ID           string
+	Name         string
+	ResourceType string
+}
+
+// ScoreParams represents the set of possible query strings for scoring a
+// schematic.
+type ScoreParams struct {
+	GalaxyID  string
+	Available string
+	Rows      string
+}
+
+// Weights represents the experimentation weight, in percent, of each stat.
+type Weights struct {
+	CR int16 `json:"cr,omitempty" validate:"min=0,max=100"`
+	CD int16 `json:"cd,omitempty" validate:"min=0,max=100"`
+	DR int16 `json:"dr,omitempty" validate:"min=0,max=100"`
+	FL int16 `json:"fl,omitempty" validate:"min=0,max=100"`
+	HR int16 `json:"hr,omitempty" validate:"min=0,max=100"`
+	MA int16 `json:"ma,omitempty" validate:"min=0,max=100"`
+	PE int16 `json:"pe,omitempty" validate:"min=0,max=100"`
+	OQ int16 `json:"oq,omitempty" validate:"min=0,max=100"`
+	SR int16 `json:"sr,omitempty" validate:"min=0,max=100"`
+	UT int16 `json:"ut,omitempty" validate:"min=0,max=100"`
+	ER int16 `json:"er,omitempty" validate:"min=0,max=100"`
+}
+
+// Slot represents a resource slot of a schematic. Exactly one of ResourceType
+// or ResourceGroup is set.
+type Slot struct {
+	Name          string  `json:"name" validate:"max=255"`
+	ResourceType  string  `json:"resourceType,omitempty" validate:"max=63"`
+	ResourceGroup string  `json:"resourceGroup,omitempty" validate:"max=63"`
+	Weights       Weights `json:"weights"`
+}
+
+// Schematic represents information about an individual schematic.
+type Schematic struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Slots       []Slot `json:"slots"`
+	DateCreated string `json:"dateCreated"`
+	DateUpdated string `json:"dateUpdated"`
+}
+
+// Encode implements the encoder interface.
+func (app Schematic) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppWeights(bus resourcebus.Weights) Weights {
+	return Weights{
+		CR: bus.CR,
+		CD: bus.CD,
+		DR: bus.DR,
+		FL: bus.FL,
+		HR: bus.HR,
+		MA: bus.MA,
+		PE: bus.PE,
+		OQ: bus.OQ,
+		SR: bus.SR,
+		UT: bus.UT,
+		ER: bus.ER,
+	}
+}
+
+func toAppSlot(bus schematicbus.Slot) Slot {
+	return Slot{
+		Name:          bus.Name,
+		ResourceType:  bus.ResourceType,
+		ResourceGroup: bus.ResourceGroup,
+		Weights:       toAppWeights(bus.Weights),
+	}
+}
+
+func toAppSchematic(bus schematicbus.Schematic) Schematic {
+	slots := make([]Slot, len(bus.Slots))
+	for i, slot := range bus.Slots {
+		slots[i] = toAppSlot(slot)
+	}
+
+	return Schematic{
+		ID:          bus.ID.String(),
+		Name:        bus.Name,
+		Slots:       slots,
+		DateCreated: bus.DateCreated.Format(time.RFC3339),
+		DateUpdated: bus.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+func toAppSchematics(schematics []schematicbus.Schematic) []Schematic {
+	app := make([]Schematic, len(schematics))
+	for i, sch := range schematics {
+		app[i] = toAppSchematic(sch)
+	}
+
+	return app
+}
+
+func toBusSlots(app []Slot) []schematicbus.Slot {
+	if app == nil {
+		return nil
+	}
+
+	bus := make([]schematicbus.Slot, len(app))
+	for i, slot := range app {
+		bus[i] = schematicbus.Slot{
+			Name:          slot.Name,
+			ResourceType:  slot.ResourceType,
+			ResourceGroup: slot.ResourceGroup,
+			Weights: resourcebus.Weights{
+				CR: slot.Weights.CR,
+				CD: slot.Weights.CD,
+				DR: slot.Weights.DR,
+				FL: slot.Weights.FL,
+				HR: slot.Weights.HR,
+				MA: slot.Weights.MA,
+				PE: slot.Weights.PE,
+				OQ: slot.Weights.OQ,
+				SR: slot.Weights.SR,
+				UT: slot.Weights.UT,
+				ER: slot.Weights.ER,
+			},
+		}
+	}
+
+	return bus
+}
+
+// =============================================================================
+
+// NewSchematic defines the data needed to add a new schematic.
+type NewSchematic struct {
+	Name  string `json:"name" validate:"required,max=255"`
+	Slots []Slot `json:"slots" validate:"required,min=1,max=20,dive"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewSchematic) Decode(data []byte) error {
+	return json.Unmarshal(data, &app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewSchematic) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+func toBusNewSchematic(app NewSchematic) schematicbus.NewSchematic {
+	return schematicbus.NewSchematic{
+		Name:  app.Name,
+		Slots: toBusSlots(app.Slots),
+	}
+}
+
+// =============================================================================
+
+// UpdateSchematic defines the data needed to update a schematic. Supplying
+// slots replaces all the slots of the schematic.
+type UpdateSchematic struct {
+	Name  *string `json:"name" validate:"omitempty,max=255"`
+	Slots []Slot  `json:"slots" validate:"omitempty,min=1,max=20,dive"`
+}
+
+// Decode implements the decoder interface.
+func (app *UpdateSchematic) Decode(data []byte) error {
+	return json.Unmarshal(data, &app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app UpdateSchematic) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+func toBusUpdateSchematic(app UpdateSchematic) schematicbus.UpdateSchematic {
+	return schematicbus.UpdateSchematic{
+		Name:  app.Name,
+		Slots: toBusSlots(app.Slots),
+	}
+}
+
+// =============================================================================
+
+// Candidate represents a resource scored for a schematic slot.
+type Candidate struct {
+	ResourceID    string  `json:"resourceID"`
+	Name          string  `json:"name"`
+	ResourceType  string  `json:"resourceType"`
+	UnavailableAt string  `json:"unavailableAt"`
+	Score         float64 `json:"score"`
+	CR            int16   `json:"cr"`
+	CD            int16   `json:"cd"`
+	DR            int16   `json:"dr"`
+	FL            int16   `json:"fl"`
+	HR            int16   `json:"hr"`
+	MA            int16   `json:"ma"`
+	PE            int16   `json:"pe"`
+	OQ            int16   `json:"oq"`
+	SR            int16   `json:"sr"`
+	UT            int16   `json:"ut"`
+	ER            int16   `json:"er"`
+}
+
+// SlotScore represents the best candidate resources for a schematic slot.
+type SlotScore struct {
+	Slot       Slot        `json:"slot"`
+	Candidates []Candidate `json:"candidates"`
+}
+
+// Score represents the scored candidate resources for every slot of a
+// schematic.
+type Score struct {
+	SchematicID string      `json:"schematicID"`
+	GalaxyID    string      `json:"galaxyID"`
+	Slots       []SlotScore `json:"slots"`
+}
+
+// Encode implements the encoder interface.
+func (app Score) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppCandidate(bus resourcebus.ScoredResource) Candidate {
+	var unavailableAt string
+	if !bus.Resource.UnavailableAt.IsZero() {
+		unavailableAt = bus.Resource.UnavailableAt.Format(time.RFC3339)
+	}
+
+	res := bus.Resource
+
+	return Candidate{
+		ResourceID:    res.ID.String(),
+		Name:          res.Name.String(),
+		ResourceType:  res.ResourceType,
+		UnavailableAt: unavailableAt,
+		Score:         bus.Score,
+		CR:            res.CR,
+		CD:            res.CD,
+		DR:            res.DR,
+		FL:            res.FL,
+		HR:            res.HR,
+		MA:            res.MA,
+		PE:            res.PE,
+		OQ:            res.OQ,
+		SR:            res.SR,
+		UT:            res.UT,
+		ER:            res.ER,
+	}
+}
+
+func toAppScore(sch schematicbus.Schematic, galaxyID string, bus []schematicbus.SlotScore) Score {
+	slots := make([]SlotScore, len(bus))
+	for i, ss := range bus {
+		candidates := make([]Candidate, len(ss.Candidates))
+		for j, c := range ss.Candidates {
+			candidates[j] = toAppCandidate(c)
+		}
+
+		slots[i] = SlotScore{
+			Slot:       toAppSlot(ss.Slot),
+			Candidates: candidates,
+		}
+	}
+
+	return Score{
+		SchematicID: sch.ID.String(),
+		GalaxyID:    galaxyID,
+		Slots:       slots,
+	}
+}
@@ -0,0 +1,17 @@
+package schematicapp
+
+import (
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+)
+
+var defaultOrderBy = order.NewBy(schematicbus.OrderByID, order.ASC)
+
+var orderByFields = map[string]string{
+	"schematic_id":   schematicbus.OrderByID,
+	"id":             schematicbus.OrderByID,
+	"schematic_name": schematicbus.OrderByName,
+	"name":           schematicbus.OrderByName,
+	"dateCreated":    schematicbus.OrderByDateCreated,
+	"date_created":   schematicbus.OrderByDateCreated,
+}
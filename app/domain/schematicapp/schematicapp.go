@@ -0,0 +1,173 @@
+package schematicapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/page"
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// App manages the set of app layer api functions for the schematic domain.
+type App struct {
+	schematicBus *schematicbus.Business
+	pageLimits   page.Limits
+}
+
+// NewApp constructs a schematic app API for use.
+func NewApp(schematicBus *schematicbus.Business, pageLimits page.Limits) *App {
+	return &App{
+		schematicBus: schematicBus,
+		pageLimits:   pageLimits,
+	}
+}
+
+// Create adds a new schematic to the system.
+func (a *App) Create(ctx context.Context, app NewSchematic) (Schematic, error) {
+	sch, err := a.schematicBus.Create(ctx, toBusNewSchematic(app))
+	if err != nil {
+		if errors.Is(err, schematicbus.ErrUniqueName) {
+			return Schematic{}, errs.New(errs.Aborted, schematicbus.ErrUniqueName)
+		}
+		if fe := validate.GetFieldErrors(err); fe != nil {
+			return Schematic{}, errs.New(errs.FailedPrecondition, fe)
+		}
+		return Schematic{}, errs.Newf(errs.Internal, "create: sch[%+v]: %s", app, err)
+	}
+
+	return toAppSchematic(sch), nil
+}
+
+// Update updates an existing schematic.
+func (a *App) Update(ctx context.Context, schematicID string, app UpdateSchematic) (Schematic, error) {
+	sch, err := a.queryByID(ctx, schematicID)
+	if err != nil {
+		return Schematic{}, err
+	}
+
+	updSch, err := a.schematicBus.Update(ctx, sch, toBusUpdateSchematic(app))
+	if err != nil {
+		if errors.Is(err, schematicbus.ErrUniqueName) {
+			return Schematic{}, errs.New(errs.Aborted, schematicbus.ErrUniqueName)
+		}
+		if fe := validate.GetFieldErrors(err); fe != nil {
+			return Schematic{}, errs.New(errs.FailedPrecondition, fe)
+		}
+		return Schematic{}, errs.Newf(errs.Internal, "update: schematicID[%s]: %s", sch.ID, err)
+	}
+
+	return toAppSchematic(updSch), nil
+}
+
+// Delete removes a schematic from the system.
+func (a *App) Delete(ctx context.Context, schematicID string) error {
+	sch, err := a.queryByID(ctx, schematicID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.schematicBus.Delete(ctx, sch); err != nil {
+		return errs.Newf(errs.Internal, "delete: schematicID[%s]: %s", sch.ID, err)
+	}
+
+	return nil
+}
+
+// Query returns a list of schematics with paging.
+func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[Schematic], error) {
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
+	if err != nil {
+		return page.Document[Schematic]{}, err
+	}
+
+	filter, err := parseFilter(qp)
+	if err != nil {
+		return page.Document[Schematic]{}, err
+	}
+
+	orderBy, err := order.Parse(orderByFields, qp.OrderBy, defaultOrderBy)
+	if err != nil {
+		return page.Document[Schematic]{}, err
+	}
+
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[Schematic]{}, err
+	}
+
+	var schematics []schematicbus.Schematic
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		schematics, err = a.schematicBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		schematics, next, err = a.schematicBus.QueryAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
+	if err != nil {
+		return page.Document[Schematic]{}, errs.Newf(errs.Internal, "query: %s", err)
+	}
+
+	total, err := a.schematicBus.Count(ctx, filter)
+	if err != nil {
+		return page.Document[Schematic]{}, errs.Newf(errs.Internal, "count: %s", err)
+	}
+
+	doc := page.NewDocument(toAppSchematics(schematics), total, pg.Number, pg.RowsPerPage)
+	if len(schematics) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
+	}
+
+	return doc, nil
+}
+
+// QueryByID returns a schematic by its id.
+func (a *App) QueryByID(ctx context.Context, schematicID string) (Schematic, error) {
+	sch, err := a.queryByID(ctx, schematicID)
+	if err != nil {
+		return Schematic{}, err
+	}
+
+	return toAppSchematic(sch), nil
+}
+
+// Score rates the resources of a galaxy against each slot of a schematic and
+// returns the best candidates per slot.
+func (a *App) Score(ctx context.Context, schematicID string, sp ScoreParams) (Score, error) {
+	filter, rows, err := parseScoreFilter(sp)
+	if err != nil {
+		return Score{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	sch, err := a.queryByID(ctx, schematicID)
+	if err != nil {
+		return Score{}, err
+	}
+
+	scores, err := a.schematicBus.Score(ctx, sch, filter, rows)
+	if err != nil {
+		return Score{}, errs.Newf(errs.Internal, "score: schematicID[%s]: %s", sch.ID, err)
+	}
+
+	return toAppScore(sch, filter.GalaxyID.String(), scores), nil
+}
+
+func (a *App) queryByID(ctx context.Context, schematicID string) (schematicbus.Schematic, error) {
+	id, err := uuid.Parse(schematicID)
+	if err != nil {
+		return schematicbus.Schematic{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	sch, err := a.schematicBus.QueryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, schematicbus.ErrNotFound) {
+			return schematicbus.Schematic{}, errs.New(errs.NotFound, err)
+		}
+		return schematicbus.Schematic{}, errs.Newf(errs.Internal, "querybyid: schematicID[%s]: %s", id, err)
+	}
+
+	return sch, nil
+}
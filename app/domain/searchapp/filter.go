@@ -0,0 +1,59 @@
+package searchapp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/godwinrob/harvester/business/domain/searchbus"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// Bounds on a search. Shorter text has too few trigrams to rank on.
+const (
+	minTextLen  = 2
+	maxTextLen  = 100
+	maxRows     = 50
+	defaultRows = 20
+)
+
+func parseQuery(qp QueryParams) (searchbus.Query, int, error) {
+	text := strings.TrimSpace(qp.Q)
+
+	switch n := utf8.RuneCountInString(text); {
+	case n == 0:
+		return searchbus.Query{}, 0, validate.NewFieldsError("q", errors.New("q is required"))
+	case n < minTextLen || n > maxTextLen:
+		return searchbus.Query{}, 0, validate.NewFieldsError("q", fmt.Errorf("q must be between %d and %d characters", minTextLen, maxTextLen))
+	}
+
+	query := searchbus.Query{
+		Text: text,
+	}
+
+	if qp.GalaxyID != "" {
+		galaxyID, err := uuid.Parse(qp.GalaxyID)
+		if err != nil {
+			return searchbus.Query{}, 0, validate.NewFieldsError("galaxy_id", err)
+		}
+		query.GalaxyID = &galaxyID
+	}
+
+	rows := defaultRows
+	if qp.Rows != "" {
+		var err error
+		rows, err = strconv.Atoi(qp.Rows)
+		if err != nil {
+			return searchbus.Query{}, 0, validate.NewFieldsError("rows", err)
+		}
+	}
+
+	if rows < 1 || rows > maxRows {
+		return searchbus.Query{}, 0, validate.NewFieldsError("rows", fmt.Errorf("rows must be between 1 and %d", maxRows))
+	}
+
+	return query, rows, nil
+}
@@ -0,0 +1,64 @@
+package searchapp
+
+import (
+	"encoding/json"
+
+	"github.com/godwinrob/harvester/business/domain/searchbus"
+	"github.com/google/uuid"
+)
+
+// QueryParams represents the set of possible query strings.
+type QueryParams struct {
+	Q        string
+	GalaxyID string
+	Rows     string
+}
+
+// Hit represents one resource, resource type or resource group a search
+// found.
+type Hit struct {
+	Kind         string  `json:"kind"`
+	ID           string  `json:"id"`
+	Name         string  `json:"name"`
+	GalaxyID     string  `json:"galaxyID,omitempty"`
+	ResourceType string  `json:"resourceType,omitempty"`
+	Score        float64 `json:"score"`
+}
+
+// Hits represents the results of a search, best match first.
+type Hits struct {
+	Items []Hit `json:"items"`
+}
+
+// Encode implements the encoder interface.
+func (app Hits) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppHit(bus searchbus.Hit) Hit {
+	var galaxyID string
+	if bus.GalaxyID != uuid.Nil {
+		galaxyID = bus.GalaxyID.String()
+	}
+
+	return Hit{
+		Kind:         bus.Kind.String(),
+		ID:           bus.ID,
+		Name:         bus.Name,
+		GalaxyID:     galaxyID,
+		ResourceType: bus.ResourceType,
+		Score:        bus.Score,
+	}
+}
+
+func toAppHits(bus []searchbus.Hit) Hits {
+	items := make([]Hit, len(bus))
+	for i, hit := range bus {
+		items[i] = toAppHit(hit)
+	}
+
+	return Hits{
+		Items: items,
+	}
+}
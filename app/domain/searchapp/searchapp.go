@@ -0,0 +1,37 @@
+// Package searchapp maintains the app layer api for the search domain.
+package searchapp
+
+import (
+	"context"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/business/domain/searchbus"
+)
+
+// App manages the set of app layer api functions for the search domain.
+type App struct {
+	searchBus *searchbus.Business
+}
+
+// NewApp constructs a search app API for use.
+func NewApp(searchBus *searchbus.Business) *App {
+	return &App{
+		searchBus: searchBus,
+	}
+}
+
+// Search returns the resources, resource types and resource groups whose
+// names best match the query text.
+func (a *App) Search(ctx context.Context, qp QueryParams) (Hits, error) {
+	query, rows, err := parseQuery(qp)
+	if err != nil {
+		return Hits{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	hits, err := a.searchBus.Search(ctx, query, rows)
+	if err != nil {
+		return Hits{}, errs.Newf(errs.Internal, "search: %s", err)
+	}
+
+	return toAppHits(hits), nil
+}
@@ -6,6 +6,7 @@ import (
 	"net/mail"
 	"time"
 
+	"github.com/godwinrob/harvester/app/sdk/auth"
 	"github.com/godwinrob/harvester/app/sdk/errs"
 	"github.com/godwinrob/harvester/business/domain/userbus"
 	"github.com/godwinrob/harvester/foundation/validate"
@@ -16,6 +17,7 @@ type QueryParams struct {
 	Page             string
 	Rows             string
 	OrderBy          string
+	Cursor           string
 	ID               string
 	Name             string
 	Email            string
@@ -312,3 +314,44 @@ func (app BulkDeleteResult) Encode() ([]byte, string, error) {
 	data, err := json.Marshal(app)
 	return data, "application/json", err
 }
+
+// =============================================================================
+
+// Login defines the credentials needed to request a token.
+type Login struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required"`
+}
+
+// Decode implments the decoder interface.
+func (app *Login) Decode(data []byte) error {
+	return json.Unmarshal(data, &app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app Login) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+// Token represents a signed token issued to an authenticated user.
+type Token struct {
+	Token     string `json:"token"`
+	ExpiresAt string `json:"expiresAt"`
+}
+
+// Encode implements the encoder interface.
+func (app Token) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppToken(token string, claims auth.Claims) Token {
+	return Token{
+		Token:     token,
+		ExpiresAt: claims.ExpiresAt.Format(time.RFC3339),
+	}
+}
@@ -3,8 +3,12 @@ package userapp
 import (
 	"context"
 	"errors"
+	"net/mail"
+
+	"github.com/godwinrob/harvester/app/sdk/auth"
 	"github.com/godwinrob/harvester/app/sdk/bulk"
 	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/mid"
 	"github.com/godwinrob/harvester/app/sdk/page"
 	"github.com/godwinrob/harvester/business/domain/userbus"
 	"github.com/godwinrob/harvester/business/sdk/order"
@@ -13,23 +17,62 @@ import (
 
 // App manages the set of app layer api functions for the user domain.
 type App struct {
-	userBus *userbus.Business
+	userBus    *userbus.Business
+	auth       *auth.Auth
+	pageLimits page.Limits
 }
 
 // NewApp constructs a user app API for use.
-func NewApp(userBus *userbus.Business) *App {
+func NewApp(userBus *userbus.Business, pageLimits page.Limits) *App {
 	return &App{
-		userBus: userBus,
+		userBus:    userBus,
+		pageLimits: pageLimits,
 	}
 }
 
 // NewAppWithAuth constructs a user app API for use with auth support.
-func NewAppWithAuth(userBus *userbus.Business) *App {
+func NewAppWithAuth(userBus *userbus.Business, ath *auth.Auth, pageLimits page.Limits) *App {
 	return &App{
-		userBus: userBus,
+		userBus:    userBus,
+		auth:       ath,
+		pageLimits: pageLimits,
 	}
 }
 
+// Token authenticates the user by email and password and returns a signed
+// token carrying the user's claims.
+func (a *App) Token(ctx context.Context, app Login) (Token, error) {
+	if a.auth == nil {
+		return Token{}, errs.Newf(errs.Internal, "token: auth not configured")
+	}
+
+	addr, err := mail.ParseAddress(app.Email)
+	if err != nil {
+		return Token{}, errs.New(errs.Unauthenticated, userbus.ErrAuthenticationFailure)
+	}
+
+	usr, err := a.userBus.Authenticate(ctx, *addr, app.Password)
+	if err != nil {
+		switch {
+		case errors.Is(err, userbus.ErrNotFound),
+			errors.Is(err, userbus.ErrAuthenticationFailure),
+			errors.Is(err, userbus.ErrUserDisabled):
+			return Token{}, errs.New(errs.Unauthenticated, userbus.ErrAuthenticationFailure)
+		default:
+			return Token{}, errs.Newf(errs.Internal, "authenticate: %s", err)
+		}
+	}
+
+	claims := a.auth.NewClaims(usr)
+
+	tkn, err := a.auth.GenerateToken(claims)
+	if err != nil {
+		return Token{}, errs.Newf(errs.Internal, "generatetoken: %s", err)
+	}
+
+	return toAppToken(tkn, claims), nil
+}
+
 // Create adds a new user to the system.
 func (a *App) Create(ctx context.Context, app NewUser) (User, error) {
 	nc, err := toBusNewUser(app)
@@ -48,19 +91,19 @@ func (a *App) Create(ctx context.Context, app NewUser) (User, error) {
 	return toAppUser(usr), nil
 }
 
-// Update updates an existing user.
-func (a *App) Update(ctx context.Context, userID string, app UpdateUser) (User, error) {
-	uu, err := toBusUpdateUser(app)
-	if err != nil {
-		return User{}, errs.New(errs.FailedPrecondition, err)
+// Update updates an existing user. Only an admin can enable or disable a
+// user, so a disabled user can't enable themselves again.
+func (a *App) Update(ctx context.Context, app UpdateUser) (User, error) {
+	if app.Enabled != nil && !mid.GetClaims(ctx).HasRole(userbus.Roles.Admin) {
+		return User{}, errs.Newf(errs.PermissionDenied, "only an admin can change whether a user is enabled")
 	}
 
-	id, err := uuid.Parse(userID)
+	uu, err := toBusUpdateUser(app)
 	if err != nil {
 		return User{}, errs.New(errs.FailedPrecondition, err)
 	}
 
-	usr, err := a.userBus.QueryByID(ctx, id)
+	usr, err := mid.GetUser(ctx)
 	if err != nil {
 		return User{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
 	}
@@ -74,18 +117,13 @@ func (a *App) Update(ctx context.Context, userID string, app UpdateUser) (User,
 }
 
 // UpdateRole updates an existing user's role.
-func (a *App) UpdateRole(ctx context.Context, userID string, app UpdateUserRole) (User, error) {
+func (a *App) UpdateRole(ctx context.Context, app UpdateUserRole) (User, error) {
 	uu, err := toBusUpdateUserRole(app)
 	if err != nil {
 		return User{}, errs.New(errs.FailedPrecondition, err)
 	}
 
-	id, err := uuid.Parse(userID)
-	if err != nil {
-		return User{}, errs.New(errs.FailedPrecondition, err)
-	}
-
-	usr, err := a.userBus.QueryByID(ctx, id)
+	usr, err := mid.GetUser(ctx)
 	if err != nil {
 		return User{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
 	}
@@ -99,13 +137,8 @@ func (a *App) UpdateRole(ctx context.Context, userID string, app UpdateUserRole)
 }
 
 // Delete removes a user from the system.
-func (a *App) Delete(ctx context.Context, userID string) error {
-	id, err := uuid.Parse(userID)
-	if err != nil {
-		return errs.New(errs.FailedPrecondition, err)
-	}
-
-	usr, err := a.userBus.QueryByID(ctx, id)
+func (a *App) Delete(ctx context.Context) error {
+	usr, err := mid.GetUser(ctx)
 	if err != nil {
 		return errs.Newf(errs.Internal, "user missing in context: %s", err)
 	}
@@ -119,7 +152,7 @@ func (a *App) Delete(ctx context.Context, userID string) error {
 
 // Query returns a list of users with paging.
 func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[User], error) {
-	pg, err := page.Parse(qp.Page, qp.Rows)
+	pg, err := a.pageLimits.Parse(qp.Page, qp.Rows)
 	if err != nil {
 		return page.Document[User]{}, err
 	}
@@ -134,7 +167,19 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[User], e
 		return page.Document[User]{}, err
 	}
 
-	usrs, err := a.userBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	cursor, err := order.ParseCursor(qp.Cursor, orderBy)
+	if err != nil {
+		return page.Document[User]{}, err
+	}
+
+	var usrs []userbus.User
+	var next order.Cursor
+	switch {
+	case cursor.IsZero() && pg.Number > 1:
+		usrs, err = a.userBus.Query(ctx, filter, orderBy, pg.Number, pg.RowsPerPage)
+	default:
+		usrs, next, err = a.userBus.QueryAfter(ctx, filter, orderBy, cursor, pg.RowsPerPage)
+	}
 	if err != nil {
 		return page.Document[User]{}, errs.Newf(errs.Internal, "query: %s", err)
 	}
@@ -144,17 +189,18 @@ func (a *App) Query(ctx context.Context, qp QueryParams) (page.Document[User], e
 		return page.Document[User]{}, errs.Newf(errs.Internal, "count: %s", err)
 	}
 
-	return page.NewDocument(toAppUsers(usrs), total, pg.Number, pg.RowsPerPage), nil
-}
-
-// QueryByID returns a user by its Ia.
-func (a *App) QueryByID(ctx context.Context, userID string) (User, error) {
-	id, err := uuid.Parse(userID)
-	if err != nil {
-		return User{}, errs.New(errs.FailedPrecondition, err)
+	doc := page.NewDocument(toAppUsers(usrs), total, pg.Number, pg.RowsPerPage)
+	if len(usrs) == pg.RowsPerPage {
+		doc.NextCursor = next.String()
 	}
 
-	usr, err := a.userBus.QueryByID(ctx, id)
+	return doc, nil
+}
+
+// QueryByID returns the user loaded into the context by the authorization
+// middleware.
+func (a *App) QueryByID(ctx context.Context) (User, error) {
+	usr, err := mid.GetUser(ctx)
 	if err != nil {
 		return User{}, errs.Newf(errs.Internal, "user missing in context: %s", err)
 	}
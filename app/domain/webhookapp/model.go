@@ -0,0 +1,171 @@
+package webhookapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/business/domain/webhookbus"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// Webhook represents information about an individual webhook. The secret is
+// only returned when the webhook is created.
+type Webhook struct {
+	ID          string   `json:"id"`
+	GalaxyID    string   `json:"galaxyID"`
+	URL         string   `json:"url"`
+	Secret      string   `json:"secret,omitempty"`
+	Events      []string `json:"events"`
+	CreatedBy   string   `json:"createdBy"`
+	DateCreated string   `json:"dateCreated"`
+	DateUpdated string   `json:"dateUpdated"`
+}
+
+// Encode implements the encoder interface.
+func (app Webhook) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppWebhook(bus webhookbus.Webhook) Webhook {
+	events := make([]string, len(bus.Events))
+	for i, e := range bus.Events {
+		events[i] = e.String()
+	}
+
+	return Webhook{
+		ID:          bus.ID.String(),
+		GalaxyID:    bus.GalaxyID.String(),
+		URL:         bus.URL,
+		Events:      events,
+		CreatedBy:   bus.CreatedBy.String(),
+		DateCreated: bus.DateCreated.Format(time.RFC3339),
+		DateUpdated: bus.DateUpdated.Format(time.RFC3339),
+	}
+}
+
+// Webhooks represents the webhooks of a galaxy.
+type Webhooks struct {
+	Items []Webhook `json:"items"`
+}
+
+// Encode implements the encoder interface.
+func (app Webhooks) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppWebhooks(whs []webhookbus.Webhook) Webhooks {
+	items := make([]Webhook, len(whs))
+	for i, wh := range whs {
+		items[i] = toAppWebhook(wh)
+	}
+
+	return Webhooks{
+		Items: items,
+	}
+}
+
+// =============================================================================
+
+// NewWebhook defines the data needed to add a webhook to a galaxy.
+type NewWebhook struct {
+	URL    string   `json:"url" validate:"required,url"`
+	Events []string `json:"events" validate:"required,min=1"`
+}
+
+// Decode implements the decoder interface.
+func (app *NewWebhook) Decode(data []byte) error {
+	return json.Unmarshal(data, &app)
+}
+
+// Validate checks the data in the model is considered clean.
+func (app NewWebhook) Validate() error {
+	if err := validate.Check(app); err != nil {
+		return errs.Newf(errs.FailedPrecondition, "validate: %s", err)
+	}
+
+	return nil
+}
+
+func toBusNewWebhook(app NewWebhook, galaxyID uuid.UUID, createdBy uuid.UUID) (webhookbus.NewWebhook, error) {
+	events := make([]webhookbus.Event, len(app.Events))
+	for i, e := range app.Events {
+		event, err := webhookbus.Events.Parse(e)
+		if err != nil {
+			return webhookbus.NewWebhook{}, fmt.Errorf("parse: %w", err)
+		}
+		events[i] = event
+	}
+
+	bus := webhookbus.NewWebhook{
+		GalaxyID:  galaxyID,
+		URL:       app.URL,
+		Events:    events,
+		CreatedBy: createdBy,
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+
+// Delivery represents one queued or sent payload of a webhook.
+type Delivery struct {
+	ID            string          `json:"id"`
+	Event         string          `json:"event"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttempt   string          `json:"nextAttempt,omitempty"`
+	LastError     string          `json:"lastError,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	DateCreated   string          `json:"dateCreated"`
+	DateDelivered string          `json:"dateDelivered,omitempty"`
+}
+
+func toAppDelivery(bus webhookbus.Delivery) Delivery {
+	app := Delivery{
+		ID:          bus.ID.String(),
+		Event:       bus.Event.String(),
+		Status:      bus.Status.String(),
+		Attempts:    bus.Attempts,
+		LastError:   bus.LastError,
+		Payload:     bus.Payload,
+		DateCreated: bus.DateCreated.Format(time.RFC3339),
+	}
+
+	if bus.Status.Equal(webhookbus.Statuses.Pending) {
+		app.NextAttempt = bus.NextAttempt.Format(time.RFC3339)
+	}
+
+	if !bus.DateDelivered.IsZero() {
+		app.DateDelivered = bus.DateDelivered.Format(time.RFC3339)
+	}
+
+	return app
+}
+
+// Deliveries represents the most recent deliveries of a webhook.
+type Deliveries struct {
+	Items []Delivery `json:"items"`
+}
+
+// Encode implements the encoder interface.
+func (app Deliveries) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(app)
+	return data, "application/json", err
+}
+
+func toAppDeliveries(deliveries []webhookbus.Delivery) Deliveries {
+	items := make([]Delivery, len(deliveries))
+	for i, d := range deliveries {
+		items[i] = toAppDelivery(d)
+	}
+
+	return Deliveries{
+		Items: items,
+	}
+}
@@ -0,0 +1,170 @@
+// Package webhookapp maintains the app layer api for the webhook domain.
+package webhookapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/mid"
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/godwinrob/harvester/business/domain/webhookbus"
+	"github.com/google/uuid"
+)
+
+// deliveryRows is the number of recent deliveries returned for a webhook.
+const deliveryRows = 50
+
+// App manages the set of app layer api functions for the webhook domain.
+type App struct {
+	webhookBus *webhookbus.Business
+	galaxyBus  *galaxybus.Business
+}
+
+// NewApp constructs a webhook app API for use.
+func NewApp(webhookBus *webhookbus.Business, galaxyBus *galaxybus.Business) *App {
+	return &App{
+		webhookBus: webhookBus,
+		galaxyBus:  galaxyBus,
+	}
+}
+
+// Create adds a webhook to a galaxy. The response carries the signing secret,
+// which is not returned again.
+func (a *App) Create(ctx context.Context, galaxyID string, app NewWebhook) (Webhook, error) {
+	gal, userID, err := a.checkManage(ctx, galaxyID)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	nw, err := toBusNewWebhook(app, gal.ID, userID)
+	if err != nil {
+		return Webhook{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	wh, err := a.webhookBus.Create(ctx, nw)
+	if err != nil {
+		if errors.Is(err, webhookbus.ErrInvalidURL) || errors.Is(err, webhookbus.ErrNoEvents) {
+			return Webhook{}, errs.New(errs.FailedPrecondition, err)
+		}
+		return Webhook{}, errs.Newf(errs.Internal, "create: galaxyID[%s]: %s", gal.ID, err)
+	}
+
+	resp := toAppWebhook(wh)
+	resp.Secret = wh.Secret
+
+	return resp, nil
+}
+
+// Delete removes a webhook from a galaxy.
+func (a *App) Delete(ctx context.Context, galaxyID string, webhookID string) error {
+	wh, err := a.queryWebhook(ctx, galaxyID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if err := a.webhookBus.Delete(ctx, wh); err != nil {
+		return errs.Newf(errs.Internal, "delete: webhookID[%s]: %s", wh.ID, err)
+	}
+
+	return nil
+}
+
+// Query returns the webhooks of a galaxy.
+func (a *App) Query(ctx context.Context, galaxyID string) (Webhooks, error) {
+	gal, _, err := a.checkManage(ctx, galaxyID)
+	if err != nil {
+		return Webhooks{}, err
+	}
+
+	whs, err := a.webhookBus.QueryByGalaxy(ctx, gal.ID)
+	if err != nil {
+		return Webhooks{}, errs.Newf(errs.Internal, "querybygalaxy: galaxyID[%s]: %s", gal.ID, err)
+	}
+
+	return toAppWebhooks(whs), nil
+}
+
+// QueryDeliveries returns the most recent deliveries of a webhook.
+func (a *App) QueryDeliveries(ctx context.Context, galaxyID string, webhookID string) (Deliveries, error) {
+	wh, err := a.queryWebhook(ctx, galaxyID, webhookID)
+	if err != nil {
+		return Deliveries{}, err
+	}
+
+	deliveries, err := a.webhookBus.QueryDeliveries(ctx, wh.ID, deliveryRows)
+	if err != nil {
+		return Deliveries{}, errs.Newf(errs.Internal, "querydeliveries: webhookID[%s]: %s", wh.ID, err)
+	}
+
+	return toAppDeliveries(deliveries), nil
+}
+
+// =============================================================================
+
+// checkManage returns the galaxy if the caller is one of its owners or an
+// admin. Webhook URLs and secrets are only visible to them.
+func (a *App) checkManage(ctx context.Context, galaxyID string) (galaxybus.Galaxy, uuid.UUID, error) {
+	userID, err := mid.GetUserID(ctx)
+	if err != nil {
+		return galaxybus.Galaxy{}, uuid.Nil, errs.Newf(errs.Unauthenticated, "user id missing in context: %s", err)
+	}
+
+	id, err := uuid.Parse(galaxyID)
+	if err != nil {
+		return galaxybus.Galaxy{}, uuid.Nil, errs.New(errs.FailedPrecondition, err)
+	}
+
+	gal, err := a.galaxyBus.QueryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, galaxybus.ErrNotFound) {
+			return galaxybus.Galaxy{}, uuid.Nil, errs.New(errs.NotFound, galaxybus.ErrNotFound)
+		}
+		return galaxybus.Galaxy{}, uuid.Nil, errs.Newf(errs.Internal, "querybyid: galaxyID[%s]: %s", id, err)
+	}
+
+	if mid.GetClaims(ctx).HasRole(userbus.Roles.Admin) {
+		return gal, userID, nil
+	}
+
+	mem, err := a.galaxyBus.QueryMember(ctx, gal.ID, userID)
+	if err != nil {
+		if errors.Is(err, galaxybus.ErrMemberNotFound) {
+			return galaxybus.Galaxy{}, uuid.Nil, errs.Newf(errs.PermissionDenied, "user is not an owner of galaxy %s", gal.ID)
+		}
+		return galaxybus.Galaxy{}, uuid.Nil, errs.Newf(errs.Internal, "querymember: galaxyID[%s] userID[%s]: %s", gal.ID, userID, err)
+	}
+
+	if !mem.CanManage() {
+		return galaxybus.Galaxy{}, uuid.Nil, errs.Newf(errs.PermissionDenied, "user is not an owner of galaxy %s", gal.ID)
+	}
+
+	return gal, userID, nil
+}
+
+func (a *App) queryWebhook(ctx context.Context, galaxyID string, webhookID string) (webhookbus.Webhook, error) {
+	gal, _, err := a.checkManage(ctx, galaxyID)
+	if err != nil {
+		return webhookbus.Webhook{}, err
+	}
+
+	id, err := uuid.Parse(webhookID)
+	if err != nil {
+		return webhookbus.Webhook{}, errs.New(errs.FailedPrecondition, err)
+	}
+
+	wh, err := a.webhookBus.QueryByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, webhookbus.ErrNotFound) {
+			return webhookbus.Webhook{}, errs.New(errs.NotFound, webhookbus.ErrNotFound)
+		}
+		return webhookbus.Webhook{}, errs.Newf(errs.Internal, "querybyid: webhookID[%s]: %s", id, err)
+	}
+
+	if wh.GalaxyID != gal.ID {
+		return webhookbus.Webhook{}, errs.New(errs.NotFound, webhookbus.ErrNotFound)
+	}
+
+	return wh, nil
+}
@@ -0,0 +1,206 @@
+// Package auth provides authentication and authorization support.
+// Authentication: You are who you say you are.
+// Authorization:  You have permission to do what you are requesting to do.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
+)
+
+// ErrForbidden is returned when a auth issue is identified.
+var ErrForbidden = errors.New("attempted action is not allowed")
+
+// Claims represents the authorization claims transmitted via a JWT.
+type Claims struct {
+	jwt.RegisteredClaims
+	Roles []userbus.Role `json:"roles"`
+}
+
+// HasRole checks if the specified role exists in the claims.
+func (c Claims) HasRole(role userbus.Role) bool {
+	for _, r := range c.Roles {
+		if r.Equal(role) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Config represents information required to initialize auth.
+type Config struct {
+	Log      *logger.Logger
+	UserBus  *userbus.Business
+	Secret   string
+	Issuer   string
+	Duration time.Duration
+}
+
+// Auth is used to authenticate clients. It can generate a token for a
+// set of user claims and recreate the claims by parsing the token.
+type Auth struct {
+	log      *logger.Logger
+	userBus  *userbus.Business
+	secret   []byte
+	issuer   string
+	duration time.Duration
+	method   jwt.SigningMethod
+	parser   *jwt.Parser
+}
+
+// New creates an Auth to support authentication/authorization.
+func New(cfg Config) (*Auth, error) {
+	if cfg.Secret == "" {
+		return nil, errors.New("auth secret must be provided")
+	}
+
+	if cfg.Duration <= 0 {
+		return nil, errors.New("auth token duration must be positive")
+	}
+
+	if cfg.UserBus == nil {
+		return nil, errors.New("auth user business must be provided")
+	}
+
+	a := Auth{
+		log:      cfg.Log,
+		userBus:  cfg.UserBus,
+		secret:   []byte(cfg.Secret),
+		issuer:   cfg.Issuer,
+		duration: cfg.Duration,
+		method:   jwt.SigningMethodHS256,
+		parser:   jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Name})),
+	}
+
+	return &a, nil
+}
+
+// Issuer provides the configured issuer used to authenticate tokens.
+func (a *Auth) Issuer() string {
+	return a.issuer
+}
+
+// NewClaims constructs the claims for the specified user with the configured
+// issuer and expiration.
+func (a *Auth) NewClaims(usr userbus.User) Claims {
+	now := time.Now()
+
+	return Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   usr.ID.String(),
+			Issuer:    a.issuer,
+			ExpiresAt: jwt.NewNumericDate(now.Add(a.duration).UTC()),
+			IssuedAt:  jwt.NewNumericDate(now.UTC()),
+		},
+		Roles: usr.Roles,
+	}
+}
+
+// GenerateToken generates a signed JWT token string representing the user Claims.
+func (a *Auth) GenerateToken(claims Claims) (string, error) {
+	token := jwt.NewWithClaims(a.method, claims)
+
+	str, err := token.SignedString(a.secret)
+	if err != nil {
+		return "", fmt.Errorf("signing token: %w", err)
+	}
+
+	return str, nil
+}
+
+// Authenticate processes the token to validate the sender's token is valid
+// and that the user it was issued to is still enabled. The roles of the
+// returned claims are the user's current roles, so a change of role or a
+// disabled account takes effect before the token expires.
+func (a *Auth) Authenticate(ctx context.Context, bearerToken string) (Claims, error) {
+	claims, err := a.ParseToken(bearerToken)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	usr, err := a.userBus.QueryByID(ctx, uuid.MustParse(claims.Subject))
+	if err != nil {
+		return Claims{}, fmt.Errorf("query user: subject[%s]: %w", claims.Subject, err)
+	}
+
+	if !usr.Enabled {
+		return Claims{}, fmt.Errorf("subject[%s]: %w", claims.Subject, userbus.ErrUserDisabled)
+	}
+
+	claims.Roles = usr.Roles
+
+	return claims, nil
+}
+
+// ParseToken validates the signature, issuer and subject of the token and
+// returns its claims without consulting the user. Use Authenticate to let a
+// caller in.
+func (a *Auth) ParseToken(bearerToken string) (Claims, error) {
+	parts := strings.Split(bearerToken, " ")
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "bearer") {
+		return Claims{}, errors.New("expected authorization header format: Bearer <token>")
+	}
+
+	var claims Claims
+	token, err := a.parser.ParseWithClaims(parts[1], &claims, func(*jwt.Token) (any, error) {
+		return a.secret, nil
+	})
+	if err != nil {
+		return Claims{}, fmt.Errorf("error parsing token: %w", err)
+	}
+
+	if !token.Valid {
+		return Claims{}, errors.New("invalid token")
+	}
+
+	if !claims.VerifyIssuer(a.issuer, true) {
+		return Claims{}, fmt.Errorf("invalid issuer %q", claims.Issuer)
+	}
+
+	if _, err := uuid.Parse(claims.Subject); err != nil {
+		return Claims{}, fmt.Errorf("invalid subject %q: %w", claims.Subject, err)
+	}
+
+	return claims, nil
+}
+
+// Authorize validates the claims against the specified rule. The userID is
+// only used by rules that allow the subject of the request to act on itself.
+func (a *Auth) Authorize(ctx context.Context, claims Claims, userID uuid.UUID, rule string) error {
+	isAdmin := claims.HasRole(userbus.Roles.Admin)
+	isUser := claims.HasRole(userbus.Roles.User)
+
+	var allowed bool
+	switch rule {
+	case RuleAny:
+		allowed = isAdmin || isUser
+
+	case RuleAdminOnly:
+		allowed = isAdmin
+
+	case RuleUserOnly:
+		allowed = isUser
+
+	case RuleAdminOrSubject:
+		allowed = isAdmin || (isUser && claims.Subject == userID.String())
+
+	default:
+		return fmt.Errorf("unknown rule %q", rule)
+	}
+
+	if !allowed {
+		a.log.Info(ctx, "auth: authorize", "rule", rule, "subject", claims.Subject, "roles", claims.Roles)
+		return ErrForbidden
+	}
+
+	return nil
+}
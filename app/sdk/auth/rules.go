@@ -0,0 +1,9 @@
+package auth
+
+// These are the current set of rules we have for auth.
+const (
+	RuleAny            = "rule_any"
+	RuleAdminOnly      = "rule_admin_only"
+	RuleUserOnly       = "rule_user_only"
+	RuleAdminOrSubject = "rule_admin_or_subject"
+)
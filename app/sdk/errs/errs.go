@@ -141,3 +141,39 @@ func (bve *BulkValidationError) Encode() ([]byte, string, error) {
 func (bve *BulkValidationError) HTTPStatus() int {
 	return httpStatus[bve.Code]
 }
+
+// =============================================================================
+
+// PartialError represents an operation that failed partway, carrying the
+// result of the part that was done so the caller knows what is left.
+type PartialError struct {
+	Code    ErrCode `json:"code"`
+	Message string  `json:"message"`
+	Result  any     `json:"result"`
+}
+
+// NewPartialError constructs a partial error with the result of the part of
+// the operation that was done.
+func NewPartialError(code ErrCode, result any, format string, v ...any) *PartialError {
+	return &PartialError{
+		Code:    code,
+		Message: fmt.Sprintf(format, v...),
+		Result:  result,
+	}
+}
+
+// Error implements the error interface.
+func (pe *PartialError) Error() string {
+	return pe.Message
+}
+
+// Encode implements the encoder interface.
+func (pe *PartialError) Encode() ([]byte, string, error) {
+	data, err := json.Marshal(pe)
+	return data, "application/json", err
+}
+
+// HTTPStatus implements the web package httpStatus interface.
+func (pe *PartialError) HTTPStatus() int {
+	return httpStatus[pe.Code]
+}
@@ -0,0 +1,50 @@
+// Package metrics records the metrics of the requests the API serves, for
+// Prometheus to scrape from the debug host.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var requests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "harvester",
+	Subsystem: "http",
+	Name:      "requests_total",
+	Help:      "Number of requests served, by route pattern and status code.",
+}, []string{"route", "code"})
+
+var requestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "harvester",
+	Subsystem: "http",
+	Name:      "request_duration_seconds",
+	Help:      "Time taken to serve a request, by route pattern and status code.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"route", "code"})
+
+var rateLimitErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "harvester",
+	Subsystem: "ratelimit",
+	Name:      "errors_total",
+	Help:      "Number of requests whose rate limit bucket couldn't be reached, by budget.",
+}, []string{"budget"})
+
+// AddRequest records a request to the route that was answered with the
+// status code after the duration. The route is the pattern the request
+// matched, like "GET /v1/resources/{resource_id}", so the number of series
+// doesn't grow with the ids requested.
+func AddRequest(route string, statusCode int, d time.Duration) {
+	code := strconv.Itoa(statusCode)
+
+	requests.WithLabelValues(route, code).Inc()
+	requestDuration.WithLabelValues(route, code).Observe(d.Seconds())
+}
+
+// AddRateLimitError records a request whose bucket of the budget couldn't be
+// reached, so it wasn't rate limited.
+func AddRateLimitError(budget string) {
+	rateLimitErrors.WithLabelValues(budget).Inc()
+}
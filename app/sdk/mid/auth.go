@@ -0,0 +1,81 @@
+package mid
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/google/uuid"
+)
+
+// Authenticate validates the bearer token in the authorization header and
+// puts the claims and the caller's user id into the context. The caller is
+// also made the actor of the changes the request audits.
+func Authenticate(ctx context.Context, ath *auth.Auth, authorization string, next Handler) (Encoder, error) {
+	claims, err := ath.Authenticate(ctx, authorization)
+	if err != nil {
+		return nil, errs.New(errs.Unauthenticated, err)
+	}
+
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, errs.Newf(errs.Unauthenticated, "parsing subject: %s", err)
+	}
+
+	ctx = setClaims(ctx, claims)
+	ctx = setUserID(ctx, userID)
+	ctx = auditbus.SetActor(ctx, userID)
+
+	return next(ctx)
+}
+
+// Authorize validates that the authenticated caller satisfies the specified
+// rule.
+func Authorize(ctx context.Context, ath *auth.Auth, rule string, next Handler) (Encoder, error) {
+	userID, err := GetUserID(ctx)
+	if err != nil {
+		return nil, errs.New(errs.Unauthenticated, err)
+	}
+
+	if err := ath.Authorize(ctx, GetClaims(ctx), userID, rule); err != nil {
+		return nil, errs.Newf(errs.PermissionDenied, "authorize: you are not authorized for that action, claims[%v] rule[%v]: %s", GetClaims(ctx).Roles, rule, err)
+	}
+
+	return next(ctx)
+}
+
+// AuthorizeUser loads the user identified by the request and validates the
+// authenticated caller satisfies the rule against that user. The loaded user
+// is placed in the context for the handler to use.
+func AuthorizeUser(ctx context.Context, ath *auth.Auth, userBus *userbus.Business, rule string, id string, next Handler) (Encoder, error) {
+	var userID uuid.UUID
+
+	if id != "" {
+		var err error
+		userID, err = uuid.Parse(id)
+		if err != nil {
+			return nil, errs.New(errs.FailedPrecondition, err)
+		}
+
+		usr, err := userBus.QueryByID(ctx, userID)
+		if err != nil {
+			switch {
+			case errors.Is(err, userbus.ErrNotFound):
+				return nil, errs.New(errs.NotFound, err)
+			default:
+				return nil, errs.Newf(errs.Internal, "querybyid: userID[%s]: %s", userID, err)
+			}
+		}
+
+		ctx = setUser(ctx, usr)
+	}
+
+	if err := ath.Authorize(ctx, GetClaims(ctx), userID, rule); err != nil {
+		return nil, errs.Newf(errs.PermissionDenied, "authorize: you are not authorized for that action, claims[%v] rule[%v]: %s", GetClaims(ctx).Roles, rule, err)
+	}
+
+	return next(ctx)
+}
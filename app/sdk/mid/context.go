@@ -0,0 +1,60 @@
+package mid
+
+import (
+	"context"
+	"errors"
+
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/google/uuid"
+)
+
+type ctxKey int
+
+const (
+	claimKey ctxKey = iota + 1
+	userIDKey
+	userKey
+)
+
+func setClaims(ctx context.Context, claims auth.Claims) context.Context {
+	return context.WithValue(ctx, claimKey, claims)
+}
+
+// GetClaims returns the claims from the context.
+func GetClaims(ctx context.Context) auth.Claims {
+	v, ok := ctx.Value(claimKey).(auth.Claims)
+	if !ok {
+		return auth.Claims{}
+	}
+	return v
+}
+
+func setUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// GetUserID returns the user id of the authenticated caller from the context.
+func GetUserID(ctx context.Context) (uuid.UUID, error) {
+	v, ok := ctx.Value(userIDKey).(uuid.UUID)
+	if !ok {
+		return uuid.UUID{}, errors.New("user id not found in context")
+	}
+
+	return v, nil
+}
+
+func setUser(ctx context.Context, usr userbus.User) context.Context {
+	return context.WithValue(ctx, userKey, usr)
+}
+
+// GetUser returns the user loaded by the AuthorizeUser middleware from the
+// context.
+func GetUser(ctx context.Context) (userbus.User, error) {
+	v, ok := ctx.Value(userKey).(userbus.User)
+	if !ok {
+		return userbus.User{}, errors.New("user not found in context")
+	}
+
+	return v, nil
+}
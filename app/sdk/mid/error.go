@@ -17,6 +17,19 @@ func Errors(ctx context.Context, log *logger.Logger, next Handler) (Encoder, err
 		return resp, nil
 	}
 
+	// Bulk validation errors carry their own status and per item detail.
+	if bve, ok := err.(*errs.BulkValidationError); ok {
+		log.Info(ctx, "message", "BULK VALIDATION", bve.Errors)
+		return nil, bve
+	}
+
+	// Partial errors carry the result of the part of the operation that was
+	// done.
+	if pe, ok := err.(*errs.PartialError); ok {
+		log.Error(ctx, "message", "PARTIAL", pe.Message)
+		return nil, pe
+	}
+
 	v, ok := err.(*errs.Error)
 	if !ok {
 		v = errs.New(errs.Internal, err)
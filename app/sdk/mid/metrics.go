@@ -0,0 +1,47 @@
+package mid
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/godwinrob/harvester/app/sdk/metrics"
+)
+
+// httpStatus is implemented by the errors and encoders that carry the status
+// code they are sent with.
+type httpStatus interface {
+	HTTPStatus() int
+}
+
+// Metrics records the request to the route in the metrics, with the status
+// code the response will be sent with.
+func Metrics(ctx context.Context, route string, next Handler) (Encoder, error) {
+	now := time.Now()
+
+	resp, err := next(ctx)
+
+	metrics.AddRequest(route, statusCode(resp, err), time.Since(now))
+
+	return resp, err
+}
+
+// statusCode works out the status code the web package responds with.
+func statusCode(resp Encoder, err error) int {
+	switch {
+	case err != nil:
+		if v, ok := err.(httpStatus); ok {
+			return v.HTTPStatus()
+		}
+		return http.StatusInternalServerError
+
+	case resp == nil:
+		return http.StatusNoContent
+	}
+
+	if v, ok := resp.(httpStatus); ok {
+		return v.HTTPStatus()
+	}
+
+	return http.StatusOK
+}
@@ -0,0 +1,44 @@
+package mid
+
+import (
+	"context"
+
+	"github.com/godwinrob/harvester/app/sdk/auth"
+	"github.com/godwinrob/harvester/app/sdk/errs"
+	"github.com/godwinrob/harvester/app/sdk/metrics"
+	"github.com/godwinrob/harvester/business/sdk/ratelimit"
+	"github.com/godwinrob/harvester/foundation/logger"
+)
+
+// RateLimit takes a token of the budget from the caller's bucket and returns
+// the quota left, with an error once the bucket is empty. The caller is the
+// user of a valid bearer token in the authorization header, or else the
+// client IP, so one user's budget is shared by all their addresses. A validly
+// signed token is enough to key the bucket, the user is checked later by
+// Authenticate.
+//
+// When the buckets can't be reached the error is counted, and with failOpen
+// the request is let through with a zero quota rather than failing.
+func RateLimit(ctx context.Context, log *logger.Logger, ath *auth.Auth, limiter *ratelimit.Limiter, budget ratelimit.Budget, failOpen bool, authorization string, clientIP string) (ratelimit.Quota, error) {
+	key := "ip:" + clientIP
+	if claims, err := ath.ParseToken(authorization); err == nil {
+		key = "user:" + claims.Subject
+	}
+
+	quota, err := limiter.Allow(ctx, key, budget)
+	if err != nil {
+		metrics.AddRateLimitError(budget.Name)
+		log.Error(ctx, "ratelimit", "key", key, "budget", budget.Name, "failOpen", failOpen, "ERROR", err)
+
+		if failOpen {
+			return ratelimit.Quota{}, nil
+		}
+		return ratelimit.Quota{}, errs.Newf(errs.Unavailable, "rate limit unavailable")
+	}
+
+	if !quota.Allowed {
+		return quota, errs.Newf(errs.ResourceExhausted, "rate limit of %d %s requests per %s exceeded, retry in %s", budget.Limit, budget.Name, budget.Period, quota.RetryAfter)
+	}
+
+	return quota, nil
+}
@@ -3,6 +3,9 @@ package page
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
 	"strconv"
 
 	"github.com/godwinrob/harvester/foundation/validate"
@@ -14,9 +17,48 @@ type Page struct {
 	RowsPerPage int
 }
 
-// Parse parses the request for the page and rows query string. The
-// defaults are provided as well.
-func Parse(page string, rows string) (Page, error) {
+// Limits bounds the rows per page a query may ask for, and sets how many rows
+// a query returns when it doesn't ask.
+type Limits struct {
+	DefaultRows int
+	MinRows     int
+	MaxRows     int
+}
+
+// NewLimits constructs limits, checking the default lies between the
+// minimum and maximum.
+func NewLimits(defaultRows int, minRows int, maxRows int) (Limits, error) {
+	switch {
+	case minRows < 1:
+		return Limits{}, fmt.Errorf("min rows %d must be at least 1", minRows)
+	case maxRows < minRows:
+		return Limits{}, fmt.Errorf("max rows %d is below min rows %d", maxRows, minRows)
+	case defaultRows < minRows || defaultRows > maxRows:
+		return Limits{}, fmt.Errorf("default rows %d must be between %d and %d", defaultRows, minRows, maxRows)
+	}
+
+	l := Limits{
+		DefaultRows: defaultRows,
+		MinRows:     minRows,
+		MaxRows:     maxRows,
+	}
+
+	return l, nil
+}
+
+// Rows returns the rows query string of the request. Requests that name it
+// row, as list endpoints used to, are read the same.
+func Rows(values url.Values) string {
+	if rows := values.Get("rows"); rows != "" {
+		return rows
+	}
+
+	return values.Get("row")
+}
+
+// Parse parses the request for the page and rows query string. Pages start
+// at 1 and rows must fall within the limits.
+func (l Limits) Parse(page string, rows string) (Page, error) {
 	number := 1
 	if page != "" {
 		var err error
@@ -26,7 +68,11 @@ func Parse(page string, rows string) (Page, error) {
 		}
 	}
 
-	rowsPerPage := 10
+	if number < 1 {
+		return Page{}, validate.NewFieldsError("page", errors.New("page must be 1 or more"))
+	}
+
+	rowsPerPage := l.DefaultRows
 	if rows != "" {
 		var err error
 		rowsPerPage, err = strconv.Atoi(rows)
@@ -35,6 +81,10 @@ func Parse(page string, rows string) (Page, error) {
 		}
 	}
 
+	if rowsPerPage < l.MinRows || rowsPerPage > l.MaxRows {
+		return Page{}, validate.NewFieldsError("rows", fmt.Errorf("rows must be between %d and %d", l.MinRows, l.MaxRows))
+	}
+
 	p := Page{
 		Number:      number,
 		RowsPerPage: rowsPerPage,
@@ -45,10 +95,11 @@ func Parse(page string, rows string) (Page, error) {
 
 // Document is the form used for API responses from query API calls.
 type Document[T any] struct {
-	Items       []T `json:"items"`
-	Total       int `json:"total"`
-	Page        int `json:"page"`
-	RowsPerPage int `json:"rowsPerPage"`
+	Items       []T    `json:"items"`
+	Total       int    `json:"total"`
+	Page        int    `json:"page"`
+	RowsPerPage int    `json:"rowsPerPage"`
+	NextCursor  string `json:"nextCursor,omitempty"`
 }
 
 // NewDocument constructs a response value for a web paging trusted.
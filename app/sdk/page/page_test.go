@@ -0,0 +1,110 @@
+package page
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/godwinrob/harvester/foundation/validate"
+)
+
+func TestNewLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultRows int
+		minRows     int
+		maxRows     int
+		wantErr     bool
+	}{
+		{"valid", 10, 1, 100, false},
+		{"single size", 5, 5, 5, false},
+		{"min below 1", 10, 0, 100, true},
+		{"max below min", 10, 20, 15, true},
+		{"default below min", 1, 5, 100, true},
+		{"default above max", 200, 1, 100, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewLimits(tt.defaultRows, tt.minRows, tt.maxRows)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLimits(%d, %d, %d) error = %v, want error %t", tt.defaultRows, tt.minRows, tt.maxRows, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLimitsParse(t *testing.T) {
+	limits := Limits{
+		DefaultRows: 10,
+		MinRows:     1,
+		MaxRows:     100,
+	}
+
+	tests := []struct {
+		name      string
+		page      string
+		rows      string
+		want      Page
+		wantField string
+	}{
+		{"defaults", "", "", Page{Number: 1, RowsPerPage: 10}, ""},
+		{"page and rows", "3", "25", Page{Number: 3, RowsPerPage: 25}, ""},
+		{"min rows", "1", "1", Page{Number: 1, RowsPerPage: 1}, ""},
+		{"max rows", "1", "100", Page{Number: 1, RowsPerPage: 100}, ""},
+		{"page not a number", "one", "", Page{}, "page"},
+		{"page zero", "0", "", Page{}, "page"},
+		{"page negative", "-2", "", Page{}, "page"},
+		{"rows not a number", "", "ten", Page{}, "rows"},
+		{"rows zero", "", "0", Page{}, "rows"},
+		{"rows negative", "", "-5", Page{}, "rows"},
+		{"rows above max", "", "101", Page{}, "rows"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := limits.Parse(tt.page, tt.rows)
+
+			if tt.wantField == "" {
+				if err != nil {
+					t.Fatalf("Parse(%q, %q): %s", tt.page, tt.rows, err)
+				}
+
+				if got != tt.want {
+					t.Errorf("Parse(%q, %q) = %+v, want %+v", tt.page, tt.rows, got, tt.want)
+				}
+				return
+			}
+
+			fe := validate.GetFieldErrors(err)
+			if len(fe) != 1 || fe[0].Field != tt.wantField {
+				t.Errorf("Parse(%q, %q) error = %v, want a field error on %s", tt.page, tt.rows, err, tt.wantField)
+			}
+		})
+	}
+}
+
+func TestRows(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"rows=25", "25"},
+		{"row=25", "25"},
+		{"rows=25&row=50", "25"},
+		{"rows=&row=50", "50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery: %s", err)
+			}
+
+			if got := Rows(values); got != tt.want {
+				t.Errorf("Rows(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
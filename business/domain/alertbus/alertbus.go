@@ -0,0 +1,286 @@
+// Package alertbus provides business access to watch rules and the alerts
+// raised when new resources match them.
+package alertbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/domain/resourcegroupbus"
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+	"github.com/godwinrob/harvester/business/sdk/delegate"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/godwinrob/harvester/foundation/validate"
+
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrRuleNotFound         = errors.New("alert rule not found")
+	ErrAlertNotFound        = errors.New("alert not found")
+	ErrUnknownGalaxy        = errors.New("galaxy does not exist")
+	ErrUnknownResourceType  = errors.New("resource type does not exist")
+	ErrUnknownResourceGroup = errors.New("resource group does not exist")
+	ErrRuleSource           = errors.New("rule must name either a resource type or a resource group")
+	ErrMinScoreOutOfRange   = errors.New("minimum score must be between 0 and 1000")
+	ErrMinScoreNeedsWeights = errors.New("minimum score requires weights")
+)
+
+// Storer interface declares the behavior this package needs to perists and
+// retrieve data.
+type Storer interface {
+	CreateRule(ctx context.Context, rule Rule) error
+	DeleteRule(ctx context.Context, rule Rule) error
+	QueryRules(ctx context.Context, filter RuleFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Rule, error)
+	QueryRulesAfter(ctx context.Context, filter RuleFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Rule, order.Cursor, error)
+	CountRules(ctx context.Context, filter RuleFilter) (int, error)
+	QueryRuleByID(ctx context.Context, ruleID uuid.UUID) (Rule, error)
+	QueryRulesByGalaxies(ctx context.Context, galaxyIDs []uuid.UUID) ([]Rule, error)
+	CreateAlerts(ctx context.Context, alerts []Alert) error
+	DeleteAlert(ctx context.Context, alert Alert) error
+	QueryAlerts(ctx context.Context, filter AlertFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Alert, error)
+	QueryAlertsAfter(ctx context.Context, filter AlertFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Alert, order.Cursor, error)
+	CountAlerts(ctx context.Context, filter AlertFilter) (int, error)
+	QueryAlertByID(ctx context.Context, alertID uuid.UUID) (Alert, error)
+}
+
+// Business manages the set of APIs for alert access.
+type Business struct {
+	log              *logger.Logger
+	galaxyBus        *galaxybus.Business
+	resourceBus      *resourcebus.Business
+	resourceTypeBus  *resourcetypebus.Business
+	resourceGroupBus *resourcegroupbus.Business
+	storer           Storer
+}
+
+// NewBusiness constructs an alert business API for use. It registers with the
+// delegate to match new resources against the saved rules.
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, galaxyBus *galaxybus.Business, resourceBus *resourcebus.Business, resourceTypeBus *resourcetypebus.Business, resourceGroupBus *resourcegroupbus.Business, storer Storer) *Business {
+	b := Business{
+		log:              log,
+		galaxyBus:        galaxyBus,
+		resourceBus:      resourceBus,
+		resourceTypeBus:  resourceTypeBus,
+		resourceGroupBus: resourceGroupBus,
+		storer:           storer,
+	}
+
+	delegate.Register(resourcebus.DomainName, resourcebus.ActionCreated, b.resourcesCreated)
+
+	return &b
+}
+
+// CreateRule adds a new watch rule to the system.
+func (b *Business) CreateRule(ctx context.Context, nr NewRule) (Rule, error) {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.createrule")
+	defer span.End()
+
+	now := time.Now()
+
+	rule := Rule{
+		ID:            uuid.New(),
+		UserID:        nr.UserID,
+		Name:          nr.Name,
+		GalaxyID:      nr.GalaxyID,
+		ResourceType:  nr.ResourceType,
+		ResourceGroup: nr.ResourceGroup,
+		MinStats:      nr.MinStats,
+		Weights:       nr.Weights,
+		MinScore:      nr.MinScore,
+		DateCreated:   now,
+		DateUpdated:   now,
+	}
+
+	if err := b.checkRule(ctx, rule); err != nil {
+		return Rule{}, fmt.Errorf("validate: %w", err)
+	}
+
+	if err := b.storer.CreateRule(ctx, rule); err != nil {
+		return Rule{}, fmt.Errorf("createrule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// DeleteRule removes the specified watch rule and its alerts.
+func (b *Business) DeleteRule(ctx context.Context, rule Rule) error {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.deleterule")
+	defer span.End()
+
+	if err := b.storer.DeleteRule(ctx, rule); err != nil {
+		return fmt.Errorf("deleterule: %w", err)
+	}
+
+	return nil
+}
+
+// QueryRules retrieves a list of existing watch rules.
+func (b *Business) QueryRules(ctx context.Context, filter RuleFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Rule, error) {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.queryrules")
+	defer span.End()
+
+	rules, err := b.storer.QueryRules(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("queryrules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// QueryRulesAfter retrieves the page of watch rules that follows the cursor
+// in the order, and the cursor for the page after it. The zero cursor starts
+// at the first watch rule.
+func (b *Business) QueryRulesAfter(ctx context.Context, filter RuleFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Rule, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.queryrulesafter")
+	defer span.End()
+
+	rules, next, err := b.storer.QueryRulesAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryrulesafter: %w", err)
+	}
+
+	return rules, next, nil
+}
+
+// CountRules returns the total number of watch rules.
+func (b *Business) CountRules(ctx context.Context, filter RuleFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.countrules")
+	defer span.End()
+
+	return b.storer.CountRules(ctx, filter)
+}
+
+// QueryRuleByID finds the watch rule by the specified ID.
+func (b *Business) QueryRuleByID(ctx context.Context, ruleID uuid.UUID) (Rule, error) {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.queryrulebyid")
+	defer span.End()
+
+	rule, err := b.storer.QueryRuleByID(ctx, ruleID)
+	if err != nil {
+		return Rule{}, fmt.Errorf("query: ruleID[%s]: %w", ruleID, err)
+	}
+
+	return rule, nil
+}
+
+// DeleteAlert removes the specified alert from its user's inbox.
+func (b *Business) DeleteAlert(ctx context.Context, alert Alert) error {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.deletealert")
+	defer span.End()
+
+	if err := b.storer.DeleteAlert(ctx, alert); err != nil {
+		return fmt.Errorf("deletealert: %w", err)
+	}
+
+	return nil
+}
+
+// QueryAlerts retrieves a list of raised alerts.
+func (b *Business) QueryAlerts(ctx context.Context, filter AlertFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Alert, error) {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.queryalerts")
+	defer span.End()
+
+	alerts, err := b.storer.QueryAlerts(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("queryalerts: %w", err)
+	}
+
+	return alerts, nil
+}
+
+// QueryAlertsAfter retrieves the page of alerts that follows the cursor in the
+// order, and the cursor for the page after it. The zero cursor starts at the
+// first alert.
+func (b *Business) QueryAlertsAfter(ctx context.Context, filter AlertFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Alert, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.queryalertsafter")
+	defer span.End()
+
+	alerts, next, err := b.storer.QueryAlertsAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryalertsafter: %w", err)
+	}
+
+	return alerts, next, nil
+}
+
+// CountAlerts returns the total number of raised alerts.
+func (b *Business) CountAlerts(ctx context.Context, filter AlertFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.countalerts")
+	defer span.End()
+
+	return b.storer.CountAlerts(ctx, filter)
+}
+
+// QueryAlertByID finds the alert by the specified ID.
+func (b *Business) QueryAlertByID(ctx context.Context, alertID uuid.UUID) (Alert, error) {
+	ctx, span := otel.AddSpan(ctx, "business.alertbus.queryalertbyid")
+	defer span.End()
+
+	alert, err := b.storer.QueryAlertByID(ctx, alertID)
+	if err != nil {
+		return Alert{}, fmt.Errorf("query: alertID[%s]: %w", alertID, err)
+	}
+
+	return alert, nil
+}
+
+// checkRule validates a watch rule. Every failing field is reported in the
+// returned validate.FieldErrors.
+func (b *Business) checkRule(ctx context.Context, rule Rule) error {
+	var fields validate.FieldErrors
+
+	if _, err := b.galaxyBus.QueryByID(ctx, rule.GalaxyID); err != nil {
+		if !errors.Is(err, galaxybus.ErrNotFound) {
+			return fmt.Errorf("querybyid: galaxyID[%s]: %w", rule.GalaxyID, err)
+		}
+		fields = append(fields, validate.FieldError{Field: "galaxyID", Err: ErrUnknownGalaxy.Error()})
+	}
+
+	switch {
+	case (rule.ResourceType == "") == (rule.ResourceGroup == ""):
+		fields = append(fields, validate.FieldError{Field: "resourceType", Err: ErrRuleSource.Error()})
+
+	case rule.ResourceType != "":
+		if _, err := b.resourceTypeBus.QueryByID(ctx, rule.ResourceType); err != nil {
+			if !errors.Is(err, resourcetypebus.ErrNotFound) {
+				return fmt.Errorf("querybyid: resourceType[%s]: %w", rule.ResourceType, err)
+			}
+			fields = append(fields, validate.FieldError{Field: "resourceType", Err: ErrUnknownResourceType.Error()})
+		}
+
+	default:
+		if _, err := b.resourceGroupBus.QueryByID(ctx, rule.ResourceGroup); err != nil {
+			if !errors.Is(err, resourcegroupbus.ErrNotFound) {
+				return fmt.Errorf("querybyid: resourceGroup[%s]: %w", rule.ResourceGroup, err)
+			}
+			fields = append(fields, validate.FieldError{Field: "resourceGroup", Err: ErrUnknownResourceGroup.Error()})
+		}
+	}
+
+	if rule.Scored() {
+		if err := rule.Weights.Validate(); err != nil {
+			fields = append(fields, validate.FieldError{Field: "weights", Err: err.Error()})
+		}
+	}
+
+	switch {
+	case rule.MinScore < 0 || rule.MinScore > 1000:
+		fields = append(fields, validate.FieldError{Field: "minScore", Err: ErrMinScoreOutOfRange.Error()})
+	case rule.MinScore > 0 && !rule.Scored():
+		fields = append(fields, validate.FieldError{Field: "minScore", Err: ErrMinScoreNeedsWeights.Error()})
+	}
+
+	if len(fields) > 0 {
+		return fields
+	}
+
+	return nil
+}
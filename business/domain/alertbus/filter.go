@@ -0,0 +1,21 @@
+package alertbus
+
+import (
+	"github.com/google/uuid"
+)
+
+// RuleFilter holds the available fields a rule query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type RuleFilter struct {
+	ID       *uuid.UUID
+	UserID   *uuid.UUID
+	GalaxyID *uuid.UUID
+}
+
+// AlertFilter holds the available fields an alert query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type AlertFilter struct {
+	ID     *uuid.UUID
+	UserID *uuid.UUID
+	RuleID *uuid.UUID
+}
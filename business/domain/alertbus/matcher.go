@@ -0,0 +1,113 @@
+package alertbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/sdk/delegate"
+	"github.com/google/uuid"
+)
+
+// resourcesCreated receives the resource created action and raises an alert
+// for every rule the new resources match.
+func (b *Business) resourcesCreated(ctx context.Context, data delegate.Data) error {
+	var params resourcebus.ActionParms
+	if err := json.Unmarshal(data.RawParams, &params); err != nil {
+		return fmt.Errorf("unmarshal: %w", err)
+	}
+
+	if len(params.ResourceIDs) == 0 {
+		return nil
+	}
+
+	alerts, err := b.match(ctx, params.ResourceIDs)
+	if err != nil {
+		return fmt.Errorf("match: %w", err)
+	}
+
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	if err := b.storer.CreateAlerts(ctx, alerts); err != nil {
+		return fmt.Errorf("createalerts: %w", err)
+	}
+
+	b.log.Info(ctx, "alertbus: alerts raised", "resources", len(params.ResourceIDs), "alerts", len(alerts))
+
+	return nil
+}
+
+// match runs every rule watching the galaxies of the resources through the
+// resource query, restricted to the resources, and returns an alert for each
+// resource a rule matches.
+func (b *Business) match(ctx context.Context, resourceIDs []uuid.UUID) ([]Alert, error) {
+	resources, err := b.resourceBus.Query(ctx, resourcebus.QueryFilter{IDs: resourceIDs}, resourcebus.DefaultOrderBy, 1, len(resourceIDs))
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	galaxies := make(map[uuid.UUID][]uuid.UUID)
+	for _, res := range resources {
+		galaxies[res.GalaxyID] = append(galaxies[res.GalaxyID], res.ID)
+	}
+
+	galaxyIDs := make([]uuid.UUID, 0, len(galaxies))
+	for id := range galaxies {
+		galaxyIDs = append(galaxyIDs, id)
+	}
+
+	rules, err := b.storer.QueryRulesByGalaxies(ctx, galaxyIDs)
+	if err != nil {
+		return nil, fmt.Errorf("queryrulesbygalaxies: %w", err)
+	}
+
+	now := time.Now()
+
+	var alerts []Alert
+	for _, rule := range rules {
+		ids := galaxies[rule.GalaxyID]
+
+		filter := rule.Filter()
+		filter.IDs = ids
+
+		newAlert := func(resourceID uuid.UUID, score float64) Alert {
+			return Alert{
+				ID:          uuid.New(),
+				RuleID:      rule.ID,
+				UserID:      rule.UserID,
+				ResourceID:  resourceID,
+				Score:       score,
+				DateCreated: now,
+			}
+		}
+
+		if !rule.Scored() {
+			matched, err := b.resourceBus.Query(ctx, filter, resourcebus.DefaultOrderBy, 1, len(ids))
+			if err != nil {
+				return nil, fmt.Errorf("query: ruleID[%s]: %w", rule.ID, err)
+			}
+
+			for _, res := range matched {
+				alerts = append(alerts, newAlert(res.ID, 0))
+			}
+			continue
+		}
+
+		scored, err := b.resourceBus.QueryBest(ctx, filter, rule.Weights, len(ids))
+		if err != nil {
+			return nil, fmt.Errorf("querybest: ruleID[%s]: %w", rule.ID, err)
+		}
+
+		for _, sr := range scored {
+			if sr.Score >= rule.MinScore {
+				alerts = append(alerts, newAlert(sr.Resource.ID, sr.Score))
+			}
+		}
+	}
+
+	return alerts, nil
+}
@@ -0,0 +1,117 @@
+package alertbus
+
+import (
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/google/uuid"
+)
+
+// Stats holds the minimum value of each resource stat a rule requires. A
+// zero stat has no minimum.
+type Stats struct {
+	CR int16
+	CD int16
+	DR int16
+	FL int16
+	HR int16
+	MA int16
+	PE int16
+	OQ int16
+	SR int16
+	UT int16
+	ER int16
+}
+
+// Rule represents a user's watch rule. A rule watches a galaxy for new
+// resources of a resource type or a resource group, exactly one of which is
+// set, that meet its minimum stats. When the rule carries weights, the
+// resource's weighted score must also reach MinScore.
+type Rule struct {
+	ID            uuid.UUID
+	UserID        uuid.UUID
+	Name          string
+	GalaxyID      uuid.UUID
+	ResourceType  string
+	ResourceGroup string
+	MinStats      Stats
+	Weights       resourcebus.Weights
+	MinScore      float64
+	DateCreated   time.Time
+	DateUpdated   time.Time
+}
+
+// Filter returns the resource filter matching the resources the rule watches.
+// Saved searches and alerts share the same filter so they agree on what
+// matches.
+func (r Rule) Filter() resourcebus.QueryFilter {
+	galaxyID := r.GalaxyID
+
+	filter := resourcebus.QueryFilter{
+		GalaxyID: &galaxyID,
+		CR:       minStat(r.MinStats.CR),
+		CD:       minStat(r.MinStats.CD),
+		DR:       minStat(r.MinStats.DR),
+		FL:       minStat(r.MinStats.FL),
+		HR:       minStat(r.MinStats.HR),
+		MA:       minStat(r.MinStats.MA),
+		PE:       minStat(r.MinStats.PE),
+		OQ:       minStat(r.MinStats.OQ),
+		SR:       minStat(r.MinStats.SR),
+		UT:       minStat(r.MinStats.UT),
+		ER:       minStat(r.MinStats.ER),
+	}
+
+	switch {
+	case r.ResourceType != "":
+		resourceType := r.ResourceType
+		filter.ResourceType = &resourceType
+	default:
+		resourceGroup := r.ResourceGroup
+		filter.ResourceGroup = &resourceGroup
+	}
+
+	return filter
+}
+
+// Scored reports whether the rule ranks resources by a weighted score.
+func (r Rule) Scored() bool {
+	return r.Weights.Total() > 0
+}
+
+func minStat(v int16) *int16 {
+	if v == 0 {
+		return nil
+	}
+
+	return &v
+}
+
+// NewRule contains information needed to create a new watch rule.
+type NewRule struct {
+	UserID        uuid.UUID
+	Name          string
+	GalaxyID      uuid.UUID
+	ResourceType  string
+	ResourceGroup string
+	MinStats      Stats
+	Weights       resourcebus.Weights
+	MinScore      float64
+}
+
+// Alert represents a resource that matched a watch rule when it was added.
+// Score is zero for rules without weights. RuleName, ResourceName, GalaxyID
+// and ResourceType are read from the rule and resource when alerts are
+// queried.
+type Alert struct {
+	ID           uuid.UUID
+	RuleID       uuid.UUID
+	UserID       uuid.UUID
+	ResourceID   uuid.UUID
+	Score        float64
+	DateCreated  time.Time
+	RuleName     string
+	ResourceName string
+	GalaxyID     uuid.UUID
+	ResourceType string
+}
@@ -0,0 +1,18 @@
+package alertbus
+
+import "github.com/godwinrob/harvester/business/sdk/order"
+
+// DefaultRuleOrderBy represents the default way rules are sorted.
+var DefaultRuleOrderBy = order.NewBy(OrderByDateCreated, order.ASC)
+
+// DefaultAlertOrderBy represents the default way alerts are sorted, newest
+// first.
+var DefaultAlertOrderBy = order.NewBy(OrderByDateCreated, order.DESC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "id"
+	OrderByName        = "rule_name"
+	OrderByScore       = "score"
+	OrderByDateCreated = "date_created"
+)
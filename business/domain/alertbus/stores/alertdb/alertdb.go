@@ -0,0 +1,391 @@
+// Package alertdb contains alert related CRUD functionality.
+package alertdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/business/sdk/sqldb/dbarray"
+	"github.com/godwinrob/harvester/foundation/logger"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const ruleColumns = `
+		rule_id, user_id, rule_name, galaxy_id, resource_type, resource_group,
+		cr_min, cd_min, dr_min, fl_min, hr_min, ma_min, pe_min, oq_min, sr_min, ut_min, er_min,
+		w_cr, w_cd, w_dr, w_fl, w_hr, w_ma, w_pe, w_oq, w_sr, w_ut, w_er,
+		min_score, date_created, date_updated`
+
+// alertsFrom joins each alert with its rule and resource so the inbox can be
+// read without further lookups.
+const alertsFrom = `
+	(
+		SELECT
+			a.alert_id, a.rule_id, a.user_id, a.resource_id, a.score, a.date_created,
+			ar.rule_name, r.resource_name, r.galaxy_id, r.resource_type
+		FROM
+			alerts a
+		JOIN
+			alert_rules ar ON ar.rule_id = a.rule_id
+		JOIN
+			resources r ON r.resource_id = a.resource_id
+	) AS inbox`
+
+// Store manages the set of APIs for alert database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the api for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// CreateRule inserts a new watch rule into the database.
+func (s *Store) CreateRule(ctx context.Context, r alertbus.Rule) error {
+	const q = `
+	INSERT INTO alert_rules
+		(rule_id, user_id, rule_name, galaxy_id, resource_type, resource_group,
+		cr_min, cd_min, dr_min, fl_min, hr_min, ma_min, pe_min, oq_min, sr_min, ut_min, er_min,
+		w_cr, w_cd, w_dr, w_fl, w_hr, w_ma, w_pe, w_oq, w_sr, w_ut, w_er,
+		min_score, date_created, date_updated)
+	VALUES
+		(:rule_id, :user_id, :rule_name, :galaxy_id, :resource_type, :resource_group,
+		:cr_min, :cd_min, :dr_min, :fl_min, :hr_min, :ma_min, :pe_min, :oq_min, :sr_min, :ut_min, :er_min,
+		:w_cr, :w_cd, :w_dr, :w_fl, :w_hr, :w_ma, :w_pe, :w_oq, :w_sr, :w_ut, :w_er,
+		:min_score, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBRule(r)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteRule removes a watch rule and, through the foreign key, its alerts
+// from the database.
+func (s *Store) DeleteRule(ctx context.Context, r alertbus.Rule) error {
+	const q = `
+	DELETE FROM
+		alert_rules
+	WHERE
+		rule_id = :rule_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBRule(r)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryRules retrieves a list of existing watch rules from the database.
+func (s *Store) QueryRules(ctx context.Context, filter alertbus.RuleFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]alertbus.Rule, error) {
+	data := map[string]any{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT` + ruleColumns + `
+	FROM
+		alert_rules`
+
+	buf := bytes.NewBufferString(q)
+	applyRuleFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(ruleOrderByFields, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbRules []rule
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbRules); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusRules(dbRules), nil
+}
+
+// QueryRulesAfter retrieves the page of watch rules that follows the cursor
+// in the order, along with the cursor that follows the last of them.
+func (s *Store) QueryRulesAfter(ctx context.Context, filter alertbus.RuleFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]alertbus.Rule, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT` + ruleColumns + `
+	FROM
+		alert_rules`
+
+	buf := bytes.NewBufferString(q)
+	applyRuleFilter(filter, data, buf)
+
+	keyset, err := keyset(ruleOrderByFields, "rule_id", orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedRule
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbRules := make([]rule, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbRules[i] = k.rule
+	}
+
+	return toBusRules(dbRules), dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
+// CountRules returns the total number of watch rules in the DB.
+func (s *Store) CountRules(ctx context.Context, filter alertbus.RuleFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		alert_rules`
+
+	buf := bytes.NewBufferString(q)
+	applyRuleFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryRuleByID gets the specified watch rule from the database.
+func (s *Store) QueryRuleByID(ctx context.Context, ruleID uuid.UUID) (alertbus.Rule, error) {
+	data := struct {
+		ID string `db:"rule_id"`
+	}{
+		ID: ruleID.String(),
+	}
+
+	const q = `
+	SELECT` + ruleColumns + `
+	FROM
+		alert_rules
+	WHERE 
+		rule_id = :rule_id`
+
+	var dbRule rule
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbRule); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return alertbus.Rule{}, fmt.Errorf("db: %w", alertbus.ErrRuleNotFound)
+		}
+		return alertbus.Rule{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toBusRule(dbRule), nil
+}
+
+// QueryRulesByGalaxies retrieves every watch rule on the specified galaxies.
+func (s *Store) QueryRulesByGalaxies(ctx context.Context, galaxyIDs []uuid.UUID) ([]alertbus.Rule, error) {
+	ids := make(dbarray.String, len(galaxyIDs))
+	for i, id := range galaxyIDs {
+		ids[i] = id.String()
+	}
+
+	data := struct {
+		GalaxyIDs dbarray.String `db:"galaxy_ids"`
+	}{
+		GalaxyIDs: ids,
+	}
+
+	const q = `
+	SELECT` + ruleColumns + `
+	FROM
+		alert_rules
+	WHERE
+		galaxy_id = ANY(CAST(:galaxy_ids AS UUID[]))`
+
+	var dbRules []rule
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbRules); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusRules(dbRules), nil
+}
+
+// CreateAlerts inserts raised alerts into the database in a single
+// transaction. A resource only raises one alert per rule.
+func (s *Store) CreateAlerts(ctx context.Context, alerts []alertbus.Alert) error {
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("create alerts requires *sqlx.DB")
+	}
+
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		const q = `
+		INSERT INTO alerts
+			(alert_id, rule_id, user_id, resource_id, score, date_created)
+		VALUES
+			(:alert_id, :rule_id, :user_id, :resource_id, :score, :date_created)
+		ON CONFLICT (rule_id, resource_id) DO NOTHING`
+
+		for i, a := range alerts {
+			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBAlert(a)); err != nil {
+				return fmt.Errorf("item[%d]: %w", i, err)
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteAlert removes an alert from the database.
+func (s *Store) DeleteAlert(ctx context.Context, a alertbus.Alert) error {
+	const q = `
+	DELETE FROM
+		alerts
+	WHERE
+		alert_id = :alert_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBAlert(a)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryAlerts retrieves a list of raised alerts from the database.
+func (s *Store) QueryAlerts(ctx context.Context, filter alertbus.AlertFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]alertbus.Alert, error) {
+	data := map[string]any{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		alert_id, rule_id, user_id, resource_id, score, date_created,
+		rule_name, resource_name, galaxy_id, resource_type
+	FROM` + alertsFrom
+
+	buf := bytes.NewBufferString(q)
+	applyAlertFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(alertOrderByFields, orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbAlerts []alert
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbAlerts); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusAlerts(dbAlerts), nil
+}
+
+// QueryAlertsAfter retrieves the page of raised alerts that follows the cursor
+// in the order, along with the cursor that follows the last of them.
+func (s *Store) QueryAlertsAfter(ctx context.Context, filter alertbus.AlertFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]alertbus.Alert, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		alert_id, rule_id, user_id, resource_id, score, date_created,
+		rule_name, resource_name, galaxy_id, resource_type
+	FROM` + alertsFrom
+
+	buf := bytes.NewBufferString(q)
+	applyAlertFilter(filter, data, buf)
+
+	keyset, err := keyset(alertOrderByFields, "alert_id", orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedAlert
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbAlerts := make([]alert, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbAlerts[i] = k.alert
+	}
+
+	return toBusAlerts(dbAlerts), dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
+// CountAlerts returns the total number of raised alerts in the DB.
+func (s *Store) CountAlerts(ctx context.Context, filter alertbus.AlertFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		alerts`
+
+	buf := bytes.NewBufferString(q)
+	applyAlertFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryAlertByID gets the specified alert from the database.
+func (s *Store) QueryAlertByID(ctx context.Context, alertID uuid.UUID) (alertbus.Alert, error) {
+	data := struct {
+		ID string `db:"alert_id"`
+	}{
+		ID: alertID.String(),
+	}
+
+	const q = `
+	SELECT
+		alert_id, rule_id, user_id, resource_id, score, date_created,
+		rule_name, resource_name, galaxy_id, resource_type
+	FROM` + alertsFrom + `
+	WHERE
+		alert_id = :alert_id`
+
+	var dbAlert alert
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbAlert); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return alertbus.Alert{}, fmt.Errorf("db: %w", alertbus.ErrAlertNotFound)
+		}
+		return alertbus.Alert{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toBusAlert(dbAlert), nil
+}
@@ -0,0 +1,56 @@
+package alertdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+)
+
+func applyRuleFilter(filter alertbus.RuleFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["rule_id"] = *filter.ID
+		wc = append(wc, "rule_id = :rule_id")
+	}
+
+	if filter.UserID != nil {
+		data["user_id"] = *filter.UserID
+		wc = append(wc, "user_id = :user_id")
+	}
+
+	if filter.GalaxyID != nil {
+		data["galaxy_id"] = *filter.GalaxyID
+		wc = append(wc, "galaxy_id = :galaxy_id")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
+
+func applyAlertFilter(filter alertbus.AlertFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["alert_id"] = *filter.ID
+		wc = append(wc, "alert_id = :alert_id")
+	}
+
+	if filter.UserID != nil {
+		data["user_id"] = *filter.UserID
+		wc = append(wc, "user_id = :user_id")
+	}
+
+	if filter.RuleID != nil {
+		data["rule_id"] = *filter.RuleID
+		wc = append(wc, "rule_id = :rule_id")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
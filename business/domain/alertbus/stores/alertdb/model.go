@@ -0,0 +1,193 @@
+package alertdb
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/google/uuid"
+)
+
+type rule struct {
+	ID            uuid.UUID      `db:"rule_id"`
+	UserID        uuid.UUID      `db:"user_id"`
+	Name          string         `db:"rule_name"`
+	GalaxyID      uuid.UUID      `db:"galaxy_id"`
+	ResourceType  sql.NullString `db:"resource_type"`
+	ResourceGroup sql.NullString `db:"resource_group"`
+	CRMin         int16          `db:"cr_min"`
+	CDMin         int16          `db:"cd_min"`
+	DRMin         int16          `db:"dr_min"`
+	FLMin         int16          `db:"fl_min"`
+	HRMin         int16          `db:"hr_min"`
+	MAMin         int16          `db:"ma_min"`
+	PEMin         int16          `db:"pe_min"`
+	OQMin         int16          `db:"oq_min"`
+	SRMin         int16          `db:"sr_min"`
+	UTMin         int16          `db:"ut_min"`
+	ERMin         int16          `db:"er_min"`
+	CRWeight      int16          `db:"w_cr"`
+	CDWeight      int16          `db:"w_cd"`
+	DRWeight      int16          `db:"w_dr"`
+	FLWeight      int16          `db:"w_fl"`
+	HRWeight      int16          `db:"w_hr"`
+	MAWeight      int16          `db:"w_ma"`
+	PEWeight      int16          `db:"w_pe"`
+	OQWeight      int16          `db:"w_oq"`
+	SRWeight      int16          `db:"w_sr"`
+	UTWeight      int16          `db:"w_ut"`
+	ERWeight      int16          `db:"w_er"`
+	MinScore      float64        `db:"min_score"`
+	DateCreated   time.Time      `db:"date_created"`
+	DateUpdated   time.Time      `db:"date_updated"`
+}
+
+type keyedRule struct {
+	rule
+	sqldb.Keyed
+}
+
+func toDBRule(bus alertbus.Rule) rule {
+	return rule{
+		ID:            bus.ID,
+		UserID:        bus.UserID,
+		Name:          bus.Name,
+		GalaxyID:      bus.GalaxyID,
+		ResourceType:  sql.NullString{String: bus.ResourceType, Valid: bus.ResourceType != ""},
+		ResourceGroup: sql.NullString{String: bus.ResourceGroup, Valid: bus.ResourceGroup != ""},
+		CRMin:         bus.MinStats.CR,
+		CDMin:         bus.MinStats.CD,
+		DRMin:         bus.MinStats.DR,
+		FLMin:         bus.MinStats.FL,
+		HRMin:         bus.MinStats.HR,
+		MAMin:         bus.MinStats.MA,
+		PEMin:         bus.MinStats.PE,
+		OQMin:         bus.MinStats.OQ,
+		SRMin:         bus.MinStats.SR,
+		UTMin:         bus.MinStats.UT,
+		ERMin:         bus.MinStats.ER,
+		CRWeight:      bus.Weights.CR,
+		CDWeight:      bus.Weights.CD,
+		DRWeight:      bus.Weights.DR,
+		FLWeight:      bus.Weights.FL,
+		HRWeight:      bus.Weights.HR,
+		MAWeight:      bus.Weights.MA,
+		PEWeight:      bus.Weights.PE,
+		OQWeight:      bus.Weights.OQ,
+		SRWeight:      bus.Weights.SR,
+		UTWeight:      bus.Weights.UT,
+		ERWeight:      bus.Weights.ER,
+		MinScore:      bus.MinScore,
+		DateCreated:   bus.DateCreated.UTC(),
+		DateUpdated:   bus.DateUpdated.UTC(),
+	}
+}
+
+func toBusRule(db rule) alertbus.Rule {
+	return alertbus.Rule{
+		ID:            db.ID,
+		UserID:        db.UserID,
+		Name:          db.Name,
+		GalaxyID:      db.GalaxyID,
+		ResourceType:  db.ResourceType.String,
+		ResourceGroup: db.ResourceGroup.String,
+		MinStats: alertbus.Stats{
+			CR: db.CRMin,
+			CD: db.CDMin,
+			DR: db.DRMin,
+			FL: db.FLMin,
+			HR: db.HRMin,
+			MA: db.MAMin,
+			PE: db.PEMin,
+			OQ: db.OQMin,
+			SR: db.SRMin,
+			UT: db.UTMin,
+			ER: db.ERMin,
+		},
+		Weights: resourcebus.Weights{
+			CR: db.CRWeight,
+			CD: db.CDWeight,
+			DR: db.DRWeight,
+			FL: db.FLWeight,
+			HR: db.HRWeight,
+			MA: db.MAWeight,
+			PE: db.PEWeight,
+			OQ: db.OQWeight,
+			SR: db.SRWeight,
+			UT: db.UTWeight,
+			ER: db.ERWeight,
+		},
+		MinScore:    db.MinScore,
+		DateCreated: db.DateCreated.In(time.Local),
+		DateUpdated: db.DateUpdated.In(time.Local),
+	}
+}
+
+func toBusRules(dbs []rule) []alertbus.Rule {
+	bus := make([]alertbus.Rule, len(dbs))
+
+	for i, db := range dbs {
+		bus[i] = toBusRule(db)
+	}
+
+	return bus
+}
+
+// =============================================================================
+
+type alert struct {
+	ID           uuid.UUID `db:"alert_id"`
+	RuleID       uuid.UUID `db:"rule_id"`
+	UserID       uuid.UUID `db:"user_id"`
+	ResourceID   uuid.UUID `db:"resource_id"`
+	Score        float64   `db:"score"`
+	DateCreated  time.Time `db:"date_created"`
+	RuleName     string    `db:"rule_name"`
+	ResourceName string    `db:"resource_name"`
+	GalaxyID     uuid.UUID `db:"galaxy_id"`
+	ResourceType string    `db:"resource_type"`
+}
+
+type keyedAlert struct {
+	alert
+	sqldb.Keyed
+}
+
+func toDBAlert(bus alertbus.Alert) alert {
+	return alert{
+		ID:          bus.ID,
+		RuleID:      bus.RuleID,
+		UserID:      bus.UserID,
+		ResourceID:  bus.ResourceID,
+		Score:       bus.Score,
+		DateCreated: bus.DateCreated.UTC(),
+	}
+}
+
+func toBusAlert(db alert) alertbus.Alert {
+	return alertbus.Alert{
+		ID:           db.ID,
+		RuleID:       db.RuleID,
+		UserID:       db.UserID,
+		ResourceID:   db.ResourceID,
+		Score:        db.Score,
+		DateCreated:  db.DateCreated.In(time.Local),
+		RuleName:     db.RuleName,
+		ResourceName: db.ResourceName,
+		GalaxyID:     db.GalaxyID,
+		ResourceType: strings.TrimRight(db.ResourceType, " "), // resource_type is a padded bpchar column.
+	}
+}
+
+func toBusAlerts(dbs []alert) []alertbus.Alert {
+	bus := make([]alertbus.Alert, len(dbs))
+
+	for i, db := range dbs {
+		bus[i] = toBusAlert(db)
+	}
+
+	return bus
+}
@@ -0,0 +1,40 @@
+package alertdb
+
+import (
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/alertbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+)
+
+var ruleOrderByFields = map[string]string{
+	alertbus.OrderByID:          "rule_id",
+	alertbus.OrderByName:        "rule_name",
+	alertbus.OrderByDateCreated: "date_created",
+}
+
+var alertOrderByFields = map[string]string{
+	alertbus.OrderByID:          "alert_id",
+	alertbus.OrderByName:        "rule_name",
+	alertbus.OrderByScore:       "score",
+	alertbus.OrderByDateCreated: "date_created",
+}
+
+func orderByClause(fields map[string]string, orderBy order.By) (string, error) {
+	by, exists := fields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
+
+func keyset(fields map[string]string, id string, orderBy order.By) (sqldb.Keyset, error) {
+	by, exists := fields[orderBy.Field]
+	if !exists {
+		return sqldb.Keyset{}, fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return sqldb.Keyset{Expr: by, ID: id, Direction: orderBy.Direction}, nil
+}
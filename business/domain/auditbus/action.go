@@ -0,0 +1,52 @@
+package auditbus
+
+import "fmt"
+
+type actionSet struct {
+	Create Action
+	Update Action
+	Delete Action
+}
+
+// Actions represents the set of mutations the audit log records.
+var Actions = actionSet{
+	Create: newAction("CREATE"),
+	Update: newAction("UPDATE"),
+	Delete: newAction("DELETE"),
+}
+
+// Parse parses the string value and returns an action if one exists.
+func (actionSet) Parse(value string) (Action, error) {
+	action, exists := actions[value]
+	if !exists {
+		return Action{}, fmt.Errorf("invalid action %q", value)
+	}
+
+	return action, nil
+}
+
+// =============================================================================
+
+// Set of known actions.
+var actions = make(map[string]Action)
+
+// Action represents the kind of mutation an audit entry records.
+type Action struct {
+	name string
+}
+
+func newAction(action string) Action {
+	a := Action{action}
+	actions[action] = a
+	return a
+}
+
+// String returns the name of the action.
+func (a Action) String() string {
+	return a.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (a Action) Equal(a2 Action) bool {
+	return a.name == a2.name
+}
@@ -0,0 +1,71 @@
+// Package auditbus provides business access to the audit log of the
+// mutations made to users, galaxies, resources and resource types.
+package auditbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
+)
+
+// Storer interface declares the behavior this package needs to retrieve
+// data. Entries are written by the stores of the audited domains, in the
+// transaction that makes the change.
+type Storer interface {
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Entry, error)
+	QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Entry, order.Cursor, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+}
+
+// Business manages the set of APIs for audit log access.
+type Business struct {
+	log    *logger.Logger
+	storer Storer
+}
+
+// NewBusiness constructs an audit business API for use.
+func NewBusiness(log *logger.Logger, storer Storer) *Business {
+	return &Business{
+		log:    log,
+		storer: storer,
+	}
+}
+
+// Query retrieves a list of audit entries.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Entry, error) {
+	ctx, span := otel.AddSpan(ctx, "business.auditbus.query")
+	defer span.End()
+
+	entries, err := b.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return entries, nil
+}
+
+// QueryAfter retrieves the page of audit entries that follows the cursor in
+// the order, and the cursor for the page after it. The zero cursor starts at
+// the first entry.
+func (b *Business) QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Entry, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.auditbus.queryafter")
+	defer span.End()
+
+	entries, next, err := b.storer.QueryAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryafter: %w", err)
+	}
+
+	return entries, next, nil
+}
+
+// Count returns the total number of audit entries.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.auditbus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
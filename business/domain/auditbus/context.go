@@ -0,0 +1,28 @@
+package auditbus
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type ctxKey int
+
+const actorKey ctxKey = 1
+
+// SetActor puts the user making the changes into the context, so the audit
+// entries written for those changes name them.
+func SetActor(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, actorKey, userID)
+}
+
+// GetActor returns the user making the changes from the context, or
+// uuid.Nil when the changes are not made on behalf of a user.
+func GetActor(ctx context.Context) uuid.UUID {
+	v, ok := ctx.Value(actorKey).(uuid.UUID)
+	if !ok {
+		return uuid.Nil
+	}
+
+	return v
+}
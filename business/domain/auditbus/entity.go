@@ -0,0 +1,56 @@
+package auditbus
+
+import "fmt"
+
+type entitySet struct {
+	User         Entity
+	Galaxy       Entity
+	GalaxyMember Entity
+	Resource     Entity
+	ResourceType Entity
+}
+
+// Entities represents the set of things whose mutations are audited.
+var Entities = entitySet{
+	User:         newEntity("USER"),
+	Galaxy:       newEntity("GALAXY"),
+	GalaxyMember: newEntity("GALAXY_MEMBER"),
+	Resource:     newEntity("RESOURCE"),
+	ResourceType: newEntity("RESOURCE_TYPE"),
+}
+
+// Parse parses the string value and returns an entity if one exists.
+func (entitySet) Parse(value string) (Entity, error) {
+	entity, exists := entities[value]
+	if !exists {
+		return Entity{}, fmt.Errorf("invalid entity %q", value)
+	}
+
+	return entity, nil
+}
+
+// =============================================================================
+
+// Set of known entities.
+var entities = make(map[string]Entity)
+
+// Entity represents the type of thing an audit entry is about.
+type Entity struct {
+	name string
+}
+
+func newEntity(entity string) Entity {
+	e := Entity{entity}
+	entities[entity] = e
+	return e
+}
+
+// String returns the name of the entity.
+func (e Entity) String() string {
+	return e.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (e Entity) Equal(e2 Entity) bool {
+	return e.name == e2.name
+}
@@ -0,0 +1,17 @@
+package auditbus
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QueryFilter holds the available fields a query can be filtered on.
+// We are using pointer semantics because the With API mutates the value.
+type QueryFilter struct {
+	Entity    *Entity
+	EntityID  *string
+	ActorID   *uuid.UUID
+	StartDate *time.Time
+	EndDate   *time.Time
+}
@@ -0,0 +1,143 @@
+package auditbus
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/google/uuid"
+)
+
+// redacted replaces the value of a secret field in an audit entry.
+const redacted = "[redacted]"
+
+// secretFields are the fields whose values are never written to the audit
+// log. A change to one is still recorded, with both values redacted.
+var secretFields = map[string]bool{
+	"password_hash": true,
+}
+
+// Entry represents a single mutation of an entity. Before holds the fields of
+// the entity the mutation changed as they were and After holds them as they
+// became; Before is empty for a create and After is empty for a delete.
+// ActorID is uuid.Nil when the mutation was not made on behalf of a user.
+type Entry struct {
+	ID          uuid.UUID
+	ActorID     uuid.UUID
+	Action      Action
+	Entity      Entity
+	EntityID    string
+	Before      json.RawMessage
+	After       json.RawMessage
+	TraceID     string
+	DateCreated time.Time
+}
+
+// NewEntry constructs the audit entry for a mutation of the entity, taking
+// the actor and the trace id from the context. Before and after hold every
+// field of the entity by name; for an update only the fields whose values
+// differ are kept.
+func NewEntry(ctx context.Context, action Action, entity Entity, entityID string, before map[string]any, after map[string]any) (Entry, error) {
+	if action.Equal(Actions.Update) {
+		var err error
+		before, after, err = diff(before, after)
+		if err != nil {
+			return Entry{}, fmt.Errorf("diff: %w", err)
+		}
+	}
+
+	beforeData, err := marshal(before)
+	if err != nil {
+		return Entry{}, fmt.Errorf("marshal before: %w", err)
+	}
+
+	afterData, err := marshal(after)
+	if err != nil {
+		return Entry{}, fmt.Errorf("marshal after: %w", err)
+	}
+
+	entry := Entry{
+		ID:          uuid.New(),
+		ActorID:     GetActor(ctx),
+		Action:      action,
+		Entity:      entity,
+		EntityID:    entityID,
+		Before:      beforeData,
+		After:       afterData,
+		TraceID:     otel.GetTraceID(ctx),
+		DateCreated: time.Now(),
+	}
+
+	return entry, nil
+}
+
+// =============================================================================
+
+// diff returns the fields of before and after whose values differ.
+func diff(before map[string]any, after map[string]any) (map[string]any, map[string]any, error) {
+	changedBefore := make(map[string]any)
+	changedAfter := make(map[string]any)
+
+	for field, value := range after {
+		old, exists := before[field]
+		if exists {
+			same, err := equal(old, value)
+			if err != nil {
+				return nil, nil, fmt.Errorf("field %q: %w", field, err)
+			}
+
+			if same {
+				continue
+			}
+
+			changedBefore[field] = old
+		}
+
+		changedAfter[field] = value
+	}
+
+	for field, value := range before {
+		if _, exists := after[field]; !exists {
+			changedBefore[field] = value
+		}
+	}
+
+	return changedBefore, changedAfter, nil
+}
+
+// equal reports whether two values encode to the same JSON, so values read
+// back from the database compare equal to the values that were written.
+func equal(a any, b any) (bool, error) {
+	aData, err := json.Marshal(a)
+	if err != nil {
+		return false, err
+	}
+
+	bData, err := json.Marshal(b)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(aData, bData), nil
+}
+
+// marshal encodes the fields with the secret ones redacted. No fields encode
+// as nil, which the store keeps as NULL.
+func marshal(fields map[string]any) (json.RawMessage, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	out := make(map[string]any, len(fields))
+	for field, value := range fields {
+		if secretFields[field] {
+			value = redacted
+		}
+		out[field] = value
+	}
+
+	return json.Marshal(out)
+}
@@ -0,0 +1,112 @@
+package auditbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewEntry(t *testing.T) {
+	added := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		action     Action
+		before     map[string]any
+		after      map[string]any
+		wantBefore string
+		wantAfter  string
+	}{
+		{
+			name:      "create keeps every field",
+			action:    Actions.Create,
+			after:     map[string]any{"name": "Abcdef", "cr": 100, "planets": []int16{1, 2}},
+			wantAfter: `{"cr":100,"name":"Abcdef","planets":[1,2]}`,
+		},
+		{
+			name:       "delete keeps every field",
+			action:     Actions.Delete,
+			before:     map[string]any{"name": "Abcdef", "enabled": true},
+			wantBefore: `{"enabled":true,"name":"Abcdef"}`,
+		},
+		{
+			name:       "update keeps changed fields",
+			action:     Actions.Update,
+			before:     map[string]any{"name": "Abcdef", "cr": 100, "oq": 900, "added_at": added},
+			after:      map[string]any{"name": "Abcdef", "cr": 150, "oq": 900, "added_at": added},
+			wantBefore: `{"cr":100}`,
+			wantAfter:  `{"cr":150}`,
+		},
+		{
+			name:   "update compares encoded values",
+			action: Actions.Update,
+			before: map[string]any{"cr": int16(100), "planets": []int16{1, 2}, "added_at": added},
+			after:  map[string]any{"cr": 100, "planets": []int{1, 2}, "added_at": added.In(time.UTC)},
+		},
+		{
+			name:       "update with added and removed fields",
+			action:     Actions.Update,
+			before:     map[string]any{"name": "Abcdef", "removed": "x"},
+			after:      map[string]any{"name": "Abcdef", "added": "y"},
+			wantBefore: `{"removed":"x"}`,
+			wantAfter:  `{"added":"y"}`,
+		},
+		{
+			name:      "create redacts secrets",
+			action:    Actions.Create,
+			after:     map[string]any{"email": "a@example.com", "password_hash": []byte("$2a$10$hash")},
+			wantAfter: `{"email":"a@example.com","password_hash":"[redacted]"}`,
+		},
+		{
+			name:       "update redacts a changed secret",
+			action:     Actions.Update,
+			before:     map[string]any{"email": "a@example.com", "password_hash": []byte("$2a$10$old")},
+			after:      map[string]any{"email": "a@example.com", "password_hash": []byte("$2a$10$new")},
+			wantBefore: `{"password_hash":"[redacted]"}`,
+			wantAfter:  `{"password_hash":"[redacted]"}`,
+		},
+		{
+			name:       "update leaves out an unchanged secret",
+			action:     Actions.Update,
+			before:     map[string]any{"email": "a@example.com", "password_hash": []byte("$2a$10$same")},
+			after:      map[string]any{"email": "b@example.com", "password_hash": []byte("$2a$10$same")},
+			wantBefore: `{"email":"a@example.com"}`,
+			wantAfter:  `{"email":"b@example.com"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actorID := uuid.New()
+			ctx := SetActor(context.Background(), actorID)
+
+			entry, err := NewEntry(ctx, tt.action, Entities.Resource, "42", tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("NewEntry: %s", err)
+			}
+
+			if string(entry.Before) != tt.wantBefore {
+				t.Errorf("before = %s, want %s", entry.Before, tt.wantBefore)
+			}
+
+			if string(entry.After) != tt.wantAfter {
+				t.Errorf("after = %s, want %s", entry.After, tt.wantAfter)
+			}
+
+			if entry.ActorID != actorID || entry.EntityID != "42" || !entry.Action.Equal(tt.action) || !entry.Entity.Equal(Entities.Resource) {
+				t.Errorf("entry = %+v", entry)
+			}
+		})
+	}
+}
+
+func TestNewEntryUnencodable(t *testing.T) {
+	before := map[string]any{"fn": 1}
+	after := map[string]any{"fn": func() {}}
+
+	if _, err := NewEntry(context.Background(), Actions.Update, Entities.Resource, "42", before, after); err == nil {
+		t.Error("NewEntry with an unencodable value returned no error")
+	}
+}
@@ -0,0 +1,14 @@
+package auditbus
+
+import "github.com/godwinrob/harvester/business/sdk/order"
+
+// DefaultOrderBy represents the default way we sort, newest entry first.
+var DefaultOrderBy = order.NewBy(OrderByDateCreated, order.DESC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "audit_id"
+	OrderByDateCreated = "date_created"
+	OrderByActorID     = "actor_id"
+	OrderByEntity      = "entity_type"
+)
@@ -0,0 +1,124 @@
+// Package auditdb contains audit log related CRUD functionality.
+package auditdb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for audit log database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the api for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// Query retrieves a list of audit entries from the database.
+func (s *Store) Query(ctx context.Context, filter auditbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]auditbus.Entry, error) {
+	data := map[string]any{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		audit_id, actor_id, action, entity_type, entity_id, before_data, after_data, trace_id, date_created
+	FROM
+		audit_log`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbEntries []entry
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbEntries); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusEntries(dbEntries)
+}
+
+// QueryAfter retrieves the page of audit entries that follows the cursor in
+// the order, along with the cursor that follows the last of them.
+func (s *Store) QueryAfter(ctx context.Context, filter auditbus.QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]auditbus.Entry, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		audit_id, actor_id, action, entity_type, entity_id, before_data, after_data, trace_id, date_created
+	FROM
+		audit_log`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	keyset, err := keyset(orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedEntry
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbEntries := make([]entry, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbEntries[i] = k.entry
+	}
+
+	bus, err := toBusEntries(dbEntries)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	return bus, dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
+// Count returns the total number of audit entries in the DB.
+func (s *Store) Count(ctx context.Context, filter auditbus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		audit_log`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
@@ -0,0 +1,42 @@
+package auditdb
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+)
+
+func applyFilter(filter auditbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.Entity != nil {
+		data["entity_type"] = filter.Entity.String()
+		wc = append(wc, "entity_type = :entity_type")
+	}
+
+	if filter.EntityID != nil {
+		data["entity_id"] = *filter.EntityID
+		wc = append(wc, "entity_id = :entity_id")
+	}
+
+	if filter.ActorID != nil {
+		data["actor_id"] = *filter.ActorID
+		wc = append(wc, "actor_id = :actor_id")
+	}
+
+	if filter.StartDate != nil {
+		data["start_date"] = filter.StartDate.UTC()
+		wc = append(wc, "date_created >= :start_date")
+	}
+
+	if filter.EndDate != nil {
+		data["end_date"] = filter.EndDate.UTC()
+		wc = append(wc, "date_created <= :end_date")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
@@ -0,0 +1,98 @@
+package auditdb
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/google/uuid"
+)
+
+type entry struct {
+	ID          uuid.UUID      `db:"audit_id"`
+	ActorID     uuid.NullUUID  `db:"actor_id"`
+	Action      string         `db:"action"`
+	EntityType  string         `db:"entity_type"`
+	EntityID    string         `db:"entity_id"`
+	Before      sql.NullString `db:"before_data"`
+	After       sql.NullString `db:"after_data"`
+	TraceID     string         `db:"trace_id"`
+	DateCreated time.Time      `db:"date_created"`
+}
+
+type keyedEntry struct {
+	entry
+	sqldb.Keyed
+}
+
+func toDBEntry(bus auditbus.Entry) entry {
+	return entry{
+		ID: bus.ID,
+		ActorID: uuid.NullUUID{
+			UUID:  bus.ActorID,
+			Valid: bus.ActorID != uuid.Nil,
+		},
+		Action:     bus.Action.String(),
+		EntityType: bus.Entity.String(),
+		EntityID:   bus.EntityID,
+		Before: sql.NullString{
+			String: string(bus.Before),
+			Valid:  bus.Before != nil,
+		},
+		After: sql.NullString{
+			String: string(bus.After),
+			Valid:  bus.After != nil,
+		},
+		TraceID:     bus.TraceID,
+		DateCreated: bus.DateCreated.UTC(),
+	}
+}
+
+func toBusEntry(db entry) (auditbus.Entry, error) {
+	action, err := auditbus.Actions.Parse(db.Action)
+	if err != nil {
+		return auditbus.Entry{}, fmt.Errorf("parse action: %w", err)
+	}
+
+	entity, err := auditbus.Entities.Parse(db.EntityType)
+	if err != nil {
+		return auditbus.Entry{}, fmt.Errorf("parse entity: %w", err)
+	}
+
+	bus := auditbus.Entry{
+		ID:          db.ID,
+		ActorID:     db.ActorID.UUID,
+		Action:      action,
+		Entity:      entity,
+		EntityID:    db.EntityID,
+		TraceID:     db.TraceID,
+		DateCreated: db.DateCreated.In(time.Local),
+	}
+
+	if db.Before.Valid {
+		bus.Before = json.RawMessage(db.Before.String)
+	}
+
+	if db.After.Valid {
+		bus.After = json.RawMessage(db.After.String)
+	}
+
+	return bus, nil
+}
+
+func toBusEntries(dbs []entry) ([]auditbus.Entry, error) {
+	bus := make([]auditbus.Entry, len(dbs))
+
+	for i, db := range dbs {
+		var err error
+		bus[i], err = toBusEntry(db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bus, nil
+}
@@ -0,0 +1,34 @@
+package auditdb
+
+import (
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+)
+
+var orderByFields = map[string]string{
+	auditbus.OrderByID:          "audit_id",
+	auditbus.OrderByDateCreated: "date_created",
+	auditbus.OrderByActorID:     "COALESCE(actor_id, CAST('00000000-0000-0000-0000-000000000000' AS UUID))",
+	auditbus.OrderByEntity:      "entity_type",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
+
+func keyset(orderBy order.By) (sqldb.Keyset, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return sqldb.Keyset{}, fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return sqldb.Keyset{Expr: by, ID: "audit_id", Direction: orderBy.Direction}, nil
+}
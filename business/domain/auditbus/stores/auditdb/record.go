@@ -0,0 +1,121 @@
+package auditdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/auditbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// Recorder records the audit entries of one kind of entity for the store that
+// mutates it, so the store needn't depend on this package.
+type Recorder struct {
+	log    *logger.Logger
+	entity auditbus.Entity
+}
+
+// NewRecorder constructs a recorder of the audit entries of the entity.
+func NewRecorder(log *logger.Logger, entity auditbus.Entity) *Recorder {
+	return &Recorder{
+		log:    log,
+		entity: entity,
+	}
+}
+
+// CreateWithTx records the creation of an entity as part of the transaction.
+func (r *Recorder) CreateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, after any) error {
+	return RecordWithTx(ctx, r.log, tx, auditbus.Actions.Create, r.entity, entityID, nil, after)
+}
+
+// UpdateWithTx records the update of an entity as part of the transaction.
+func (r *Recorder) UpdateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any, after any) error {
+	return RecordWithTx(ctx, r.log, tx, auditbus.Actions.Update, r.entity, entityID, before, after)
+}
+
+// DeleteWithTx records the deletion of an entity as part of the transaction.
+func (r *Recorder) DeleteWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any) error {
+	return RecordWithTx(ctx, r.log, tx, auditbus.Actions.Delete, r.entity, entityID, before, nil)
+}
+
+// RecordWithTx writes the audit entry for a mutation of an entity as part of
+// the transaction that makes it. Before and after are the database models of
+// the entity, whose db tags name the fields of the entry; before is nil for a
+// create and after is nil for a delete.
+func RecordWithTx(ctx context.Context, log *logger.Logger, tx *sqlx.Tx, action auditbus.Action, entity auditbus.Entity, entityID string, before any, after any) error {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return fmt.Errorf("before: %w", err)
+	}
+
+	afterFields, err := fields(after)
+	if err != nil {
+		return fmt.Errorf("after: %w", err)
+	}
+
+	e, err := auditbus.NewEntry(ctx, action, entity, entityID, beforeFields, afterFields)
+	if err != nil {
+		return fmt.Errorf("newentry: %w", err)
+	}
+
+	const q = `
+	INSERT INTO audit_log
+		(audit_id, actor_id, action, entity_type, entity_id, before_data, after_data, trace_id, date_created)
+	VALUES
+		(:audit_id, :actor_id, :action, :entity_type, :entity_id, :before_data, :after_data, :trace_id, :date_created)`
+
+	if err := sqldb.NamedExecContextWithTx(ctx, log, tx, q, toDBEntry(e)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// fields returns the columns of a database model by their db tags, holding
+// the values the database stores for them.
+func fields(model any) (map[string]any, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	v := reflect.ValueOf(model)
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("model is a %s, not a struct", v.Kind())
+	}
+
+	t := v.Type()
+	out := make(map[string]any, t.NumField())
+
+	for i := range t.NumField() {
+		column := t.Field(i).Tag.Get("db")
+		if column == "" || column == "-" {
+			continue
+		}
+
+		// Arrays are kept as they are so they encode as JSON arrays rather
+		// than as the text the database is sent.
+		value := v.Field(i).Interface()
+		if valuer, ok := value.(driver.Valuer); ok && v.Field(i).Kind() != reflect.Slice {
+			var err error
+			value, err = valuer.Value()
+			if err != nil {
+				return nil, fmt.Errorf("field %q: %w", column, err)
+			}
+		}
+
+		// Times are kept in UTC so a time read back from the database
+		// compares equal to the local time that was written.
+		if t, ok := value.(time.Time); ok {
+			value = t.UTC()
+		}
+
+		out[column] = value
+	}
+
+	return out, nil
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
 	"github.com/google/uuid"
 	"time"
 
+	"github.com/godwinrob/harvester/business/domain/userbus"
 	"github.com/godwinrob/harvester/business/sdk/order"
 )
 
@@ -16,8 +18,17 @@ var (
 	ErrNotFound              = errors.New("galaxy not found")
 	ErrUniqueName            = errors.New("galaxy name is not unique")
 	ErrAuthenticationFailure = errors.New("authentication failed")
+	ErrDisabled              = errors.New("galaxy is disabled")
+	ErrMemberNotFound        = errors.New("galaxy member not found")
+	ErrAlreadyMember         = errors.New("user is already a member of the galaxy")
+	ErrRemoveOwner           = errors.New("galaxy owner can not be removed")
+	ErrUnknownUser           = errors.New("user does not exist")
 )
 
+// DefaultVerificationThreshold is the number of confirmations a resource needs
+// to be verified in a galaxy that does not configure its own.
+const DefaultVerificationThreshold = 1
+
 // Storer interface declares the behavior this package needs to perists and
 // retrieve data.
 type Storer interface {
@@ -25,39 +36,56 @@ type Storer interface {
 	Update(ctx context.Context, gal Galaxy) error
 	Delete(ctx context.Context, gal Galaxy) error
 	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Galaxy, error)
+	QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Galaxy, order.Cursor, error)
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, galaxyID uuid.UUID) (Galaxy, error)
 	QueryByName(ctx context.Context, galaxyName string) (Galaxy, error)
 	BulkCreate(ctx context.Context, galaxies []Galaxy) error
 	BulkUpdate(ctx context.Context, galaxies []Galaxy) error
 	BulkDelete(ctx context.Context, ids []uuid.UUID) error
+	CreateMember(ctx context.Context, mem Member) error
+	UpdateMember(ctx context.Context, mem Member) error
+	DeleteMember(ctx context.Context, mem Member) error
+	QueryMembers(ctx context.Context, galaxyID uuid.UUID) ([]Member, error)
+	QueryMember(ctx context.Context, galaxyID uuid.UUID, userID uuid.UUID) (Member, error)
 }
 
 // Business manages the set of APIs for galaxy access.
 type Business struct {
-	log    *logger.Logger
-	storer Storer
+	log     *logger.Logger
+	userBus *userbus.Business
+	storer  Storer
 }
 
 // NewBusiness constructs a galaxy business API for use.
-func NewBusiness(log *logger.Logger, storer Storer) *Business {
+func NewBusiness(log *logger.Logger, userBus *userbus.Business, storer Storer) *Business {
 	return &Business{
-		log:    log,
-		storer: storer,
+		log:     log,
+		userBus: userBus,
+		storer:  storer,
 	}
 }
 
-// Create adds a new galaxy to the system.
+// Create adds a new galaxy to the system. The owner is added as a joined
+// member with the owner role.
 func (b *Business) Create(ctx context.Context, nu NewGalaxy) (Galaxy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.create")
+	defer span.End()
+
+	if err := b.checkUser(ctx, nu.OwnerUserID); err != nil {
+		return Galaxy{}, fmt.Errorf("owner: %w", err)
+	}
+
 	now := time.Now()
 
 	gal := Galaxy{
-		ID:          uuid.New(),
-		Name:        nu.Name,
-		OwnerUserID: nu.OwnerUserID,
-		Enabled:     true,
-		DateCreated: now,
-		DateUpdated: now,
+		ID:                    uuid.New(),
+		Name:                  nu.Name,
+		OwnerUserID:           nu.OwnerUserID,
+		Enabled:               true,
+		VerificationThreshold: verificationThreshold(nu.VerificationThreshold),
+		DateCreated:           now,
+		DateUpdated:           now,
 	}
 
 	if err := b.storer.Create(ctx, gal); err != nil {
@@ -67,19 +95,30 @@ func (b *Business) Create(ctx context.Context, nu NewGalaxy) (Galaxy, error) {
 	return gal, nil
 }
 
-// Update modifies information about a galaxy.
+// Update modifies information about a galaxy. A new owner is made a joined
+// member with the owner role.
 func (b *Business) Update(ctx context.Context, gal Galaxy, uu UpdateGalaxy) (Galaxy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.update")
+	defer span.End()
+
 	if uu.Name != nil {
 		gal.Name = *uu.Name
 	}
 
 	if uu.OwnerUserID != nil {
+		if err := b.checkUser(ctx, *uu.OwnerUserID); err != nil {
+			return Galaxy{}, fmt.Errorf("owner: %w", err)
+		}
 		gal.OwnerUserID = *uu.OwnerUserID
 	}
 
 	if uu.Enabled != nil {
 		gal.Enabled = *uu.Enabled
 	}
+
+	if uu.VerificationThreshold != nil {
+		gal.VerificationThreshold = *uu.VerificationThreshold
+	}
 	gal.DateUpdated = time.Now()
 
 	if err := b.storer.Update(ctx, gal); err != nil {
@@ -91,6 +130,9 @@ func (b *Business) Update(ctx context.Context, gal Galaxy, uu UpdateGalaxy) (Gal
 
 // Delete removes the specified galaxy.
 func (b *Business) Delete(ctx context.Context, gal Galaxy) error {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.delete")
+	defer span.End()
+
 	if err := b.storer.Delete(ctx, gal); err != nil {
 		return fmt.Errorf("delete: %w", err)
 	}
@@ -100,7 +142,8 @@ func (b *Business) Delete(ctx context.Context, gal Galaxy) error {
 
 // Query retrieves a list of existing galaxies.
 func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Galaxy, error) {
-	// TODO: DON'T ALLOW MORE THAN N RECORDS REGARDLESS
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.query")
+	defer span.End()
 
 	galaxies, err := b.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
 	if err != nil {
@@ -110,13 +153,34 @@ func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.
 	return galaxies, nil
 }
 
+// QueryAfter retrieves the page of galaxies that follows the cursor in the
+// order, and the cursor for the page after it. The zero cursor starts at the
+// first galaxy.
+func (b *Business) QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Galaxy, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.queryafter")
+	defer span.End()
+
+	galaxies, next, err := b.storer.QueryAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryafter: %w", err)
+	}
+
+	return galaxies, next, nil
+}
+
 // Count returns the total number of galaxies.
 func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.count")
+	defer span.End()
+
 	return b.storer.Count(ctx, filter)
 }
 
 // QueryByID finds the galaxy by the specified Ib.
 func (b *Business) QueryByID(ctx context.Context, galaxyID uuid.UUID) (Galaxy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.querybyid")
+	defer span.End()
+
 	galaxy, err := b.storer.QueryByID(ctx, galaxyID)
 	if err != nil {
 		return Galaxy{}, fmt.Errorf("query: galaxyID[%s]: %w", galaxyID, err)
@@ -127,6 +191,9 @@ func (b *Business) QueryByID(ctx context.Context, galaxyID uuid.UUID) (Galaxy, e
 
 // QueryByName finds the galaxy by the specified Ib.
 func (b *Business) QueryByName(ctx context.Context, galaxyName string) (Galaxy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.querybyname")
+	defer span.End()
+
 	galaxy, err := b.storer.QueryByName(ctx, galaxyName)
 	if err != nil {
 		return Galaxy{}, fmt.Errorf("query: galaxyName[%s]: %w", galaxyName, err)
@@ -137,17 +204,25 @@ func (b *Business) QueryByName(ctx context.Context, galaxyName string) (Galaxy,
 
 // BulkCreate adds multiple new galaxies to the system in a single transaction.
 func (b *Business) BulkCreate(ctx context.Context, newGalaxies []NewGalaxy) ([]Galaxy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.bulkcreate")
+	defer span.End()
+
 	galaxies := make([]Galaxy, len(newGalaxies))
 	now := time.Now()
 
 	for i, ng := range newGalaxies {
+		if err := b.checkUser(ctx, ng.OwnerUserID); err != nil {
+			return nil, fmt.Errorf("item[%d]: owner: %w", i, err)
+		}
+
 		galaxies[i] = Galaxy{
-			ID:          uuid.New(),
-			Name:        ng.Name,
-			OwnerUserID: ng.OwnerUserID,
-			Enabled:     true,
-			DateCreated: now,
-			DateUpdated: now,
+			ID:                    uuid.New(),
+			Name:                  ng.Name,
+			OwnerUserID:           ng.OwnerUserID,
+			Enabled:               true,
+			VerificationThreshold: verificationThreshold(ng.VerificationThreshold),
+			DateCreated:           now,
+			DateUpdated:           now,
 		}
 	}
 
@@ -160,6 +235,9 @@ func (b *Business) BulkCreate(ctx context.Context, newGalaxies []NewGalaxy) ([]G
 
 // BulkUpdate modifies multiple galaxies in a single transaction.
 func (b *Business) BulkUpdate(ctx context.Context, updates []UpdateGalaxyWithID) ([]Galaxy, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.bulkupdate")
+	defer span.End()
+
 	galaxies := make([]Galaxy, len(updates))
 
 	for i, upd := range updates {
@@ -172,11 +250,17 @@ func (b *Business) BulkUpdate(ctx context.Context, updates []UpdateGalaxyWithID)
 			gal.Name = *upd.Data.Name
 		}
 		if upd.Data.OwnerUserID != nil {
+			if err := b.checkUser(ctx, *upd.Data.OwnerUserID); err != nil {
+				return nil, fmt.Errorf("item[%d]: owner: %w", i, err)
+			}
 			gal.OwnerUserID = *upd.Data.OwnerUserID
 		}
 		if upd.Data.Enabled != nil {
 			gal.Enabled = *upd.Data.Enabled
 		}
+		if upd.Data.VerificationThreshold != nil {
+			gal.VerificationThreshold = *upd.Data.VerificationThreshold
+		}
 		gal.DateUpdated = time.Now()
 
 		galaxies[i] = gal
@@ -191,9 +275,33 @@ func (b *Business) BulkUpdate(ctx context.Context, updates []UpdateGalaxyWithID)
 
 // BulkDelete removes multiple galaxies in a single transaction.
 func (b *Business) BulkDelete(ctx context.Context, ids []uuid.UUID) error {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.bulkdelete")
+	defer span.End()
+
 	if err := b.storer.BulkDelete(ctx, ids); err != nil {
 		return fmt.Errorf("bulkdelete: %w", err)
 	}
 
 	return nil
 }
+
+// verificationThreshold returns the threshold to use for a new galaxy.
+func verificationThreshold(threshold int16) int16 {
+	if threshold == 0 {
+		return DefaultVerificationThreshold
+	}
+
+	return threshold
+}
+
+// checkUser confirms the user exists.
+func (b *Business) checkUser(ctx context.Context, userID uuid.UUID) error {
+	if _, err := b.userBus.QueryByID(ctx, userID); err != nil {
+		if errors.Is(err, userbus.ErrNotFound) {
+			return ErrUnknownUser
+		}
+		return fmt.Errorf("querybyid: userID[%s]: %w", userID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package galaxybus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/google/uuid"
+)
+
+// Invite adds a user to a galaxy as an invited member. The user gains the
+// role once they join.
+func (b *Business) Invite(ctx context.Context, gal Galaxy, nm NewMember) (Member, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.invite")
+	defer span.End()
+
+	if err := b.checkUser(ctx, nm.UserID); err != nil {
+		return Member{}, fmt.Errorf("invite: %w", err)
+	}
+
+	now := time.Now()
+
+	mem := Member{
+		GalaxyID:    gal.ID,
+		UserID:      nm.UserID,
+		Role:        nm.Role,
+		InvitedBy:   nm.InvitedBy,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := b.storer.CreateMember(ctx, mem); err != nil {
+		return Member{}, fmt.Errorf("createmember: %w", err)
+	}
+
+	return mem, nil
+}
+
+// Join accepts the user's invitation to a galaxy.
+func (b *Business) Join(ctx context.Context, gal Galaxy, userID uuid.UUID) (Member, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.join")
+	defer span.End()
+
+	mem, err := b.storer.QueryMember(ctx, gal.ID, userID)
+	if err != nil {
+		return Member{}, fmt.Errorf("querymember: galaxyID[%s] userID[%s]: %w", gal.ID, userID, err)
+	}
+
+	if mem.Joined {
+		return Member{}, ErrAlreadyMember
+	}
+
+	mem.Joined = true
+	mem.DateUpdated = time.Now()
+
+	if err := b.storer.UpdateMember(ctx, mem); err != nil {
+		return Member{}, fmt.Errorf("updatemember: %w", err)
+	}
+
+	return mem, nil
+}
+
+// RemoveMember removes the user from a galaxy, withdrawing any pending
+// invitation. The owner of the galaxy can not be removed; ownership must be
+// transferred first.
+func (b *Business) RemoveMember(ctx context.Context, gal Galaxy, userID uuid.UUID) error {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.removemember")
+	defer span.End()
+
+	if gal.OwnerUserID == userID {
+		return ErrRemoveOwner
+	}
+
+	mem, err := b.storer.QueryMember(ctx, gal.ID, userID)
+	if err != nil {
+		return fmt.Errorf("querymember: galaxyID[%s] userID[%s]: %w", gal.ID, userID, err)
+	}
+
+	if err := b.storer.DeleteMember(ctx, mem); err != nil {
+		return fmt.Errorf("deletemember: %w", err)
+	}
+
+	return nil
+}
+
+// QueryMembers retrieves the members and pending invitations of a galaxy.
+func (b *Business) QueryMembers(ctx context.Context, galaxyID uuid.UUID) ([]Member, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.querymembers")
+	defer span.End()
+
+	members, err := b.storer.QueryMembers(ctx, galaxyID)
+	if err != nil {
+		return nil, fmt.Errorf("querymembers: galaxyID[%s]: %w", galaxyID, err)
+	}
+
+	return members, nil
+}
+
+// QueryMember finds the membership of the user in a galaxy.
+func (b *Business) QueryMember(ctx context.Context, galaxyID uuid.UUID, userID uuid.UUID) (Member, error) {
+	ctx, span := otel.AddSpan(ctx, "business.galaxybus.querymember")
+	defer span.End()
+
+	mem, err := b.storer.QueryMember(ctx, galaxyID, userID)
+	if err != nil {
+		return Member{}, fmt.Errorf("querymember: galaxyID[%s] userID[%s]: %w", galaxyID, userID, err)
+	}
+
+	return mem, nil
+}
@@ -0,0 +1,63 @@
+package galaxybus
+
+import "fmt"
+
+type memberRoleSet struct {
+	Owner  MemberRole
+	Editor MemberRole
+	Viewer MemberRole
+}
+
+// MemberRoles represents the set of roles a member can hold in a galaxy.
+var MemberRoles = memberRoleSet{
+	Owner:  newMemberRole("OWNER"),
+	Editor: newMemberRole("EDITOR"),
+	Viewer: newMemberRole("VIEWER"),
+}
+
+// Parse parses the string value and returns a member role if one exists.
+func (memberRoleSet) Parse(value string) (MemberRole, error) {
+	role, exists := memberRoles[value]
+	if !exists {
+		return MemberRole{}, fmt.Errorf("invalid member role %q", value)
+	}
+
+	return role, nil
+}
+
+// MustParse parses the string value and returns a member role if one exists.
+// If an error occurs the function panics.
+func (memberRoleSet) MustParse(value string) MemberRole {
+	role, err := MemberRoles.Parse(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return role
+}
+
+// =============================================================================
+
+// Set of known member roles.
+var memberRoles = make(map[string]MemberRole)
+
+// MemberRole represents the role a member holds in a galaxy.
+type MemberRole struct {
+	name string
+}
+
+func newMemberRole(role string) MemberRole {
+	r := MemberRole{role}
+	memberRoles[role] = r
+	return r
+}
+
+// String returns the name of the member role.
+func (r MemberRole) String() string {
+	return r.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (r MemberRole) Equal(r2 MemberRole) bool {
+	return r.name == r2.name
+}
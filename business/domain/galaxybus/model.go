@@ -7,26 +7,32 @@ import (
 )
 
 // Galaxy represents information about an individual galaxy.
+// VerificationThreshold is the number of confirmations, over and above any
+// disputes, a resource in the galaxy needs to be verified.
 type Galaxy struct {
-	ID          uuid.UUID
-	Name        Name
-	OwnerUserID uuid.UUID
-	Enabled     bool
-	DateCreated time.Time
-	DateUpdated time.Time
+	ID                    uuid.UUID
+	Name                  Name
+	OwnerUserID           uuid.UUID
+	Enabled               bool
+	VerificationThreshold int16
+	DateCreated           time.Time
+	DateUpdated           time.Time
 }
 
-// NewGalaxy contains information needed to create a new galaxy.
+// NewGalaxy contains information needed to create a new galaxy. A zero
+// VerificationThreshold uses DefaultVerificationThreshold.
 type NewGalaxy struct {
-	Name        Name
-	OwnerUserID uuid.UUID
+	Name                  Name
+	OwnerUserID           uuid.UUID
+	VerificationThreshold int16
 }
 
 // UpdateGalaxy contains information needed to update a galaxy.
 type UpdateGalaxy struct {
-	Name        *Name
-	OwnerUserID *uuid.UUID
-	Enabled     *bool
+	Name                  *Name
+	OwnerUserID           *uuid.UUID
+	Enabled               *bool
+	VerificationThreshold *int16
 }
 
 // UpdateGalaxyWithID contains an ID and update data for bulk update operations.
@@ -34,3 +40,33 @@ type UpdateGalaxyWithID struct {
 	ID   uuid.UUID
 	Data UpdateGalaxy
 }
+
+// Member represents a user's membership of a galaxy. Invited members have
+// not joined yet and hold no access until they do.
+type Member struct {
+	GalaxyID    uuid.UUID
+	UserID      uuid.UUID
+	Role        MemberRole
+	InvitedBy   uuid.UUID
+	Joined      bool
+	DateCreated time.Time
+	DateUpdated time.Time
+}
+
+// CanWrite reports whether the member may add or change resources in the
+// galaxy.
+func (m Member) CanWrite() bool {
+	return m.Joined && (m.Role == MemberRoles.Owner || m.Role == MemberRoles.Editor)
+}
+
+// CanManage reports whether the member may invite and remove members.
+func (m Member) CanManage() bool {
+	return m.Joined && m.Role == MemberRoles.Owner
+}
+
+// NewMember contains information needed to invite a user to a galaxy.
+type NewMember struct {
+	UserID    uuid.UUID
+	Role      MemberRole
+	InvitedBy uuid.UUID
+}
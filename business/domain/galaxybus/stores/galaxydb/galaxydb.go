@@ -14,39 +14,70 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// Recorder records the audit entries of galaxy or galaxy member mutations as
+// part of the transaction that makes them. Before and after are the database
+// models of the galaxy or member.
+type Recorder interface {
+	CreateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, after any) error
+	UpdateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any, after any) error
+	DeleteWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any) error
+}
+
 // Store manages the set of APIs for galaxy database access.
 type Store struct {
-	log *logger.Logger
-	db  sqlx.ExtContext
+	log            *logger.Logger
+	db             sqlx.ExtContext
+	recorder       Recorder
+	memberRecorder Recorder
 }
 
-// NewStore constructs the api for data access.
-func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+// NewStore constructs the api for data access. The recorders record the
+// mutations of galaxies and of their members.
+func NewStore(log *logger.Logger, db *sqlx.DB, recorder Recorder, memberRecorder Recorder) *Store {
 	return &Store{
-		log: log,
-		db:  db,
+		log:            log,
+		db:             db,
+		recorder:       recorder,
+		memberRecorder: memberRecorder,
 	}
 }
 
-// Create inserts a new galaxy into the database.
+// Create inserts a new galaxy and its owner membership into the database.
 func (s *Store) Create(ctx context.Context, gal galaxybus.Galaxy) error {
 	const q = `
 	INSERT INTO galaxies
-		(galaxy_id, galaxy_name, owner_user_id, enabled, date_created, date_updated)
+		(galaxy_id, galaxy_name, owner_user_id, enabled, verification_threshold, date_created, date_updated)
 	VALUES
-		(:galaxy_id, :galaxy_name, :owner_user_id, :enabled, :date_created, :date_updated)`
+		(:galaxy_id, :galaxy_name, :owner_user_id, :enabled, :verification_threshold, :date_created, :date_updated)`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBGalaxy(gal)); err != nil {
-		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
-			return fmt.Errorf("namedexeccontext: %w", galaxybus.ErrUniqueName)
-		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("create requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbGal := toDBGalaxy(gal)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbGal); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return fmt.Errorf("namedexeccontext: %w", galaxybus.ErrUniqueName)
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.setOwner(ctx, tx, gal); err != nil {
+			return fmt.Errorf("setowner: %w", err)
+		}
+
+		if err := s.recorder.CreateWithTx(ctx, tx, gal.ID.String(), dbGal); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
-// Update replaces a galaxy document in the database.
+// Update replaces a galaxy document and its owner membership in the database.
 func (s *Store) Update(ctx context.Context, gal galaxybus.Galaxy) error {
 	const q = `
 	UPDATE
@@ -55,18 +86,41 @@ func (s *Store) Update(ctx context.Context, gal galaxybus.Galaxy) error {
 		"galaxy_name" = :galaxy_name,
 		"owner_user_id" = :owner_user_id,
 		"enabled" = :enabled,
+		"verification_threshold" = :verification_threshold,
 		"date_updated" = :date_updated
 	WHERE
 		galaxy_id = :galaxy_id`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBGalaxy(gal)); err != nil {
-		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
-			return galaxybus.ErrUniqueName
-		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("update requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		before, err := s.queryByID(ctx, tx, gal.ID)
+		if err != nil {
+			return fmt.Errorf("querybyid: %w", err)
+		}
+
+		dbGal := toDBGalaxy(gal)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbGal); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return galaxybus.ErrUniqueName
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.setOwner(ctx, tx, gal); err != nil {
+			return fmt.Errorf("setowner: %w", err)
+		}
+
+		if err := s.recorder.UpdateWithTx(ctx, tx, gal.ID.String(), before, dbGal); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Delete removes a galaxy from the database.
@@ -77,11 +131,24 @@ func (s *Store) Delete(ctx context.Context, gal galaxybus.Galaxy) error {
 	WHERE
 		galaxy_id = :galaxy_id`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBGalaxy(gal)); err != nil {
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("delete requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbGal := toDBGalaxy(gal)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbGal); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.recorder.DeleteWithTx(ctx, tx, gal.ID.String(), dbGal); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Query retrieves a list of existing galaxies from the database.
@@ -93,7 +160,7 @@ func (s *Store) Query(ctx context.Context, filter galaxybus.QueryFilter, orderBy
 
 	const q = `
 	SELECT
-		galaxy_id, galaxy_name, owner_user_id, enabled, date_created, date_updated
+		galaxy_id, galaxy_name, owner_user_id, enabled, verification_threshold, date_created, date_updated
 	FROM
 		galaxies`
 
@@ -116,6 +183,47 @@ func (s *Store) Query(ctx context.Context, filter galaxybus.QueryFilter, orderBy
 	return toBusGalaxies(dbUsrs)
 }
 
+// QueryAfter retrieves the page of galaxies that follows the cursor in the
+// order, along with the cursor that follows the last of them.
+func (s *Store) QueryAfter(ctx context.Context, filter galaxybus.QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]galaxybus.Galaxy, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		galaxy_id, galaxy_name, owner_user_id, enabled, verification_threshold, date_created, date_updated
+	FROM
+		galaxies`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	keyset, err := keyset(orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedGalaxy
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbUsrs := make([]galaxy, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbUsrs[i] = k.galaxy
+	}
+
+	bus, err := toBusGalaxies(dbUsrs)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	return bus, dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
 // Count returns the total number of galaxies in the DB.
 func (s *Store) Count(ctx context.Context, filter galaxybus.QueryFilter) (int, error) {
 	data := map[string]any{}
@@ -141,6 +249,17 @@ func (s *Store) Count(ctx context.Context, filter galaxybus.QueryFilter) (int, e
 
 // QueryByID gets the specified galaxy from the database.
 func (s *Store) QueryByID(ctx context.Context, galaxyID uuid.UUID) (galaxybus.Galaxy, error) {
+	dbGal, err := s.queryByID(ctx, s.db, galaxyID)
+	if err != nil {
+		return galaxybus.Galaxy{}, err
+	}
+
+	return toBusGalaxy(dbGal)
+}
+
+// queryByID gets the specified galaxy from the database, or from within a
+// transaction when db is one.
+func (s *Store) queryByID(ctx context.Context, db sqlx.ExtContext, galaxyID uuid.UUID) (galaxy, error) {
 	data := struct {
 		ID string `db:"galaxy_id"`
 	}{
@@ -149,21 +268,21 @@ func (s *Store) QueryByID(ctx context.Context, galaxyID uuid.UUID) (galaxybus.Ga
 
 	const q = `
 	SELECT
-        galaxy_id, galaxy_name, owner_user_id, enabled, date_created, date_updated
+        galaxy_id, galaxy_name, owner_user_id, enabled, verification_threshold, date_created, date_updated
 	FROM
 		galaxies
 	WHERE 
 		galaxy_id = :galaxy_id`
 
-	var dbUsr galaxy
-	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbUsr); err != nil {
+	var dbGal galaxy
+	if err := sqldb.NamedQueryStruct(ctx, s.log, db, q, data, &dbGal); err != nil {
 		if errors.Is(err, sqldb.ErrDBNotFound) {
-			return galaxybus.Galaxy{}, fmt.Errorf("db: %w", galaxybus.ErrNotFound)
+			return galaxy{}, fmt.Errorf("db: %w", galaxybus.ErrNotFound)
 		}
-		return galaxybus.Galaxy{}, fmt.Errorf("db: %w", err)
+		return galaxy{}, fmt.Errorf("db: %w", err)
 	}
 
-	return toBusGalaxy(dbUsr)
+	return dbGal, nil
 }
 
 // QueryByName gets the specified galaxy from the database.
@@ -176,7 +295,7 @@ func (s *Store) QueryByName(ctx context.Context, galaxyName string) (galaxybus.G
 
 	const q = `
 	SELECT
-        galaxy_id, galaxy_name, owner_user_id, enabled, date_created, date_updated
+        galaxy_id, galaxy_name, owner_user_id, enabled, verification_threshold, date_created, date_updated
 	FROM
 		galaxies
 	WHERE
@@ -203,17 +322,27 @@ func (s *Store) BulkCreate(ctx context.Context, galaxies []galaxybus.Galaxy) err
 	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
 		const q = `
 		INSERT INTO galaxies
-			(galaxy_id, galaxy_name, owner_user_id, enabled, date_created, date_updated)
+			(galaxy_id, galaxy_name, owner_user_id, enabled, verification_threshold, date_created, date_updated)
 		VALUES
-			(:galaxy_id, :galaxy_name, :owner_user_id, :enabled, :date_created, :date_updated)`
+			(:galaxy_id, :galaxy_name, :owner_user_id, :enabled, :verification_threshold, :date_created, :date_updated)`
 
 		for i, gal := range galaxies {
-			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBGalaxy(gal)); err != nil {
+			dbGal := toDBGalaxy(gal)
+
+			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbGal); err != nil {
 				if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
 					return fmt.Errorf("item[%d]: %w", i, galaxybus.ErrUniqueName)
 				}
 				return fmt.Errorf("item[%d]: %w", i, err)
 			}
+
+			if err := s.setOwner(ctx, tx, gal); err != nil {
+				return fmt.Errorf("item[%d]: setowner: %w", i, err)
+			}
+
+			if err := s.recorder.CreateWithTx(ctx, tx, gal.ID.String(), dbGal); err != nil {
+				return fmt.Errorf("item[%d]: audit: %w", i, err)
+			}
 		}
 		return nil
 	})
@@ -234,17 +363,33 @@ func (s *Store) BulkUpdate(ctx context.Context, galaxies []galaxybus.Galaxy) err
 			"galaxy_name" = :galaxy_name,
 			"owner_user_id" = :owner_user_id,
 			"enabled" = :enabled,
+			"verification_threshold" = :verification_threshold,
 			"date_updated" = :date_updated
 		WHERE
 			galaxy_id = :galaxy_id`
 
 		for i, gal := range galaxies {
-			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBGalaxy(gal)); err != nil {
+			before, err := s.queryByID(ctx, tx, gal.ID)
+			if err != nil {
+				return fmt.Errorf("item[%d]: querybyid: %w", i, err)
+			}
+
+			dbGal := toDBGalaxy(gal)
+
+			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbGal); err != nil {
 				if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
 					return fmt.Errorf("item[%d]: %w", i, galaxybus.ErrUniqueName)
 				}
 				return fmt.Errorf("item[%d]: %w", i, err)
 			}
+
+			if err := s.setOwner(ctx, tx, gal); err != nil {
+				return fmt.Errorf("item[%d]: setowner: %w", i, err)
+			}
+
+			if err := s.recorder.UpdateWithTx(ctx, tx, gal.ID.String(), before, dbGal); err != nil {
+				return fmt.Errorf("item[%d]: audit: %w", i, err)
+			}
 		}
 		return nil
 	})
@@ -267,11 +412,30 @@ func (s *Store) BulkDelete(ctx context.Context, ids []uuid.UUID) error {
 			data.IDs[i] = id.String()
 		}
 
+		const qSelect = `
+		SELECT
+			galaxy_id, galaxy_name, owner_user_id, enabled, verification_threshold, date_created, date_updated
+		FROM
+			galaxies
+		WHERE
+			galaxy_id IN (:ids)`
+
+		var dbGals []galaxy
+		if err := sqldb.NamedQuerySliceUsingIn(ctx, s.log, tx, qSelect, data, &dbGals); err != nil {
+			return fmt.Errorf("namedqueryslice: %w", err)
+		}
+
 		const q = `DELETE FROM galaxies WHERE galaxy_id IN (:ids)`
 
 		if err := sqldb.NamedExecContextUsingInWithTx(ctx, s.log, tx, q, data); err != nil {
 			return fmt.Errorf("namedexeccontextusingintx: %w", err)
 		}
+
+		for _, dbGal := range dbGals {
+			if err := s.recorder.DeleteWithTx(ctx, tx, dbGal.ID.String(), dbGal); err != nil {
+				return fmt.Errorf("galaxyID[%s]: audit: %w", dbGal.ID, err)
+			}
+		}
 		return nil
 	})
 }
@@ -0,0 +1,210 @@
+package galaxydb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// CreateMember inserts a new galaxy member into the database.
+func (s *Store) CreateMember(ctx context.Context, mem galaxybus.Member) error {
+	const q = `
+	INSERT INTO galaxy_members
+		(galaxy_id, user_id, role, invited_by, joined, date_created, date_updated)
+	VALUES
+		(:galaxy_id, :user_id, :role, :invited_by, :joined, :date_created, :date_updated)`
+
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("create member requires *sqlx.DB")
+	}
+
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbMem := toDBMember(mem)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbMem); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return fmt.Errorf("namedexeccontext: %w", galaxybus.ErrAlreadyMember)
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.memberRecorder.CreateWithTx(ctx, tx, memberID(mem), dbMem); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// UpdateMember replaces a galaxy member in the database.
+func (s *Store) UpdateMember(ctx context.Context, mem galaxybus.Member) error {
+	const q = `
+	UPDATE
+		galaxy_members
+	SET
+		"role" = :role,
+		"joined" = :joined,
+		"date_updated" = :date_updated
+	WHERE
+		galaxy_id = :galaxy_id AND user_id = :user_id`
+
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("update member requires *sqlx.DB")
+	}
+
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		before, err := s.queryMember(ctx, tx, mem.GalaxyID, mem.UserID)
+		if err != nil {
+			return fmt.Errorf("querymember: %w", err)
+		}
+
+		dbMem := toDBMember(mem)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbMem); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.memberRecorder.UpdateWithTx(ctx, tx, memberID(mem), before, dbMem); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// DeleteMember removes a galaxy member from the database.
+func (s *Store) DeleteMember(ctx context.Context, mem galaxybus.Member) error {
+	const q = `
+	DELETE FROM
+		galaxy_members
+	WHERE
+		galaxy_id = :galaxy_id AND user_id = :user_id`
+
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("delete member requires *sqlx.DB")
+	}
+
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbMem := toDBMember(mem)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbMem); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.memberRecorder.DeleteWithTx(ctx, tx, memberID(mem), dbMem); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// QueryMembers retrieves the members of a galaxy, owners first.
+func (s *Store) QueryMembers(ctx context.Context, galaxyID uuid.UUID) ([]galaxybus.Member, error) {
+	data := struct {
+		ID string `db:"galaxy_id"`
+	}{
+		ID: galaxyID.String(),
+	}
+
+	const q = `
+	SELECT
+		galaxy_id, user_id, role, invited_by, joined, date_created, date_updated
+	FROM
+		galaxy_members
+	WHERE
+		galaxy_id = :galaxy_id
+	ORDER BY
+		role = 'OWNER' DESC, date_created`
+
+	var dbMembers []member
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbMembers); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusMembers(dbMembers)
+}
+
+// QueryMember gets the membership of a user in a galaxy from the database.
+func (s *Store) QueryMember(ctx context.Context, galaxyID uuid.UUID, userID uuid.UUID) (galaxybus.Member, error) {
+	dbMember, err := s.queryMember(ctx, s.db, galaxyID, userID)
+	if err != nil {
+		return galaxybus.Member{}, err
+	}
+
+	return toBusMember(dbMember)
+}
+
+// =============================================================================
+
+// setOwner makes the owner of the galaxy a joined member with the owner role.
+func (s *Store) setOwner(ctx context.Context, tx *sqlx.Tx, gal galaxybus.Galaxy) error {
+	const q = `
+	INSERT INTO galaxy_members
+		(galaxy_id, user_id, role, invited_by, joined, date_created, date_updated)
+	VALUES
+		(:galaxy_id, :user_id, :role, :invited_by, :joined, :date_created, :date_updated)
+	ON CONFLICT (galaxy_id, user_id) DO UPDATE SET
+		role = EXCLUDED.role,
+		joined = EXCLUDED.joined,
+		date_updated = EXCLUDED.date_updated`
+
+	mem := galaxybus.Member{
+		GalaxyID:    gal.ID,
+		UserID:      gal.OwnerUserID,
+		Role:        galaxybus.MemberRoles.Owner,
+		InvitedBy:   gal.OwnerUserID,
+		Joined:      true,
+		DateCreated: gal.DateUpdated,
+		DateUpdated: gal.DateUpdated,
+	}
+
+	if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBMember(mem)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// queryMember gets the membership of a user in a galaxy from the database,
+// or from within a transaction when db is one.
+func (s *Store) queryMember(ctx context.Context, db sqlx.ExtContext, galaxyID uuid.UUID, userID uuid.UUID) (member, error) {
+	data := struct {
+		GalaxyID string `db:"galaxy_id"`
+		UserID   string `db:"user_id"`
+	}{
+		GalaxyID: galaxyID.String(),
+		UserID:   userID.String(),
+	}
+
+	const q = `
+	SELECT
+		galaxy_id, user_id, role, invited_by, joined, date_created, date_updated
+	FROM
+		galaxy_members
+	WHERE
+		galaxy_id = :galaxy_id AND user_id = :user_id`
+
+	var dbMember member
+	if err := sqldb.NamedQueryStruct(ctx, s.log, db, q, data, &dbMember); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return member{}, fmt.Errorf("db: %w", galaxybus.ErrMemberNotFound)
+		}
+		return member{}, fmt.Errorf("db: %w", err)
+	}
+
+	return dbMember, nil
+}
+
+// memberID is the id the audit log knows a membership by.
+func memberID(mem galaxybus.Member) string {
+	return mem.GalaxyID.String() + "/" + mem.UserID.String()
+}
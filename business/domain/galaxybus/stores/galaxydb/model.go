@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
 	"github.com/google/uuid"
 )
 
@@ -13,10 +14,16 @@ type galaxy struct {
 	Name        string    `db:"galaxy_name"`
 	OwnerUserID uuid.UUID `db:"owner_user_id"`
 	Enabled     bool      `db:"enabled"`
+	Threshold   int16     `db:"verification_threshold"`
 	DateCreated time.Time `db:"date_created"`
 	DateUpdated time.Time `db:"date_updated"`
 }
 
+type keyedGalaxy struct {
+	galaxy
+	sqldb.Keyed
+}
+
 func toDBGalaxy(bus galaxybus.Galaxy) galaxy {
 
 	return galaxy{
@@ -24,6 +31,7 @@ func toDBGalaxy(bus galaxybus.Galaxy) galaxy {
 		Name:        bus.Name.String(),
 		OwnerUserID: bus.OwnerUserID,
 		Enabled:     bus.Enabled,
+		Threshold:   bus.VerificationThreshold,
 		DateCreated: bus.DateCreated.UTC(),
 		DateUpdated: bus.DateUpdated.UTC(),
 	}
@@ -37,12 +45,13 @@ func toBusGalaxy(db galaxy) (galaxybus.Galaxy, error) {
 	}
 
 	bus := galaxybus.Galaxy{
-		ID:          db.ID,
-		Name:        name,
-		OwnerUserID: db.OwnerUserID,
-		Enabled:     db.Enabled,
-		DateCreated: db.DateCreated.In(time.Local),
-		DateUpdated: db.DateUpdated.In(time.Local),
+		ID:                    db.ID,
+		Name:                  name,
+		OwnerUserID:           db.OwnerUserID,
+		Enabled:               db.Enabled,
+		VerificationThreshold: db.Threshold,
+		DateCreated:           db.DateCreated.In(time.Local),
+		DateUpdated:           db.DateUpdated.In(time.Local),
 	}
 
 	return bus, nil
@@ -61,3 +70,60 @@ func toBusGalaxies(dbs []galaxy) ([]galaxybus.Galaxy, error) {
 
 	return bus, nil
 }
+
+// =============================================================================
+
+type member struct {
+	GalaxyID    uuid.UUID `db:"galaxy_id"`
+	UserID      uuid.UUID `db:"user_id"`
+	Role        string    `db:"role"`
+	InvitedBy   uuid.UUID `db:"invited_by"`
+	Joined      bool      `db:"joined"`
+	DateCreated time.Time `db:"date_created"`
+	DateUpdated time.Time `db:"date_updated"`
+}
+
+func toDBMember(bus galaxybus.Member) member {
+	return member{
+		GalaxyID:    bus.GalaxyID,
+		UserID:      bus.UserID,
+		Role:        bus.Role.String(),
+		InvitedBy:   bus.InvitedBy,
+		Joined:      bus.Joined,
+		DateCreated: bus.DateCreated.UTC(),
+		DateUpdated: bus.DateUpdated.UTC(),
+	}
+}
+
+func toBusMember(db member) (galaxybus.Member, error) {
+	role, err := galaxybus.MemberRoles.Parse(db.Role)
+	if err != nil {
+		return galaxybus.Member{}, fmt.Errorf("parse role: %w", err)
+	}
+
+	bus := galaxybus.Member{
+		GalaxyID:    db.GalaxyID,
+		UserID:      db.UserID,
+		Role:        role,
+		InvitedBy:   db.InvitedBy,
+		Joined:      db.Joined,
+		DateCreated: db.DateCreated.In(time.Local),
+		DateUpdated: db.DateUpdated.In(time.Local),
+	}
+
+	return bus, nil
+}
+
+func toBusMembers(dbs []member) ([]galaxybus.Member, error) {
+	bus := make([]galaxybus.Member, len(dbs))
+
+	for i, db := range dbs {
+		var err error
+		bus[i], err = toBusMember(db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bus, nil
+}
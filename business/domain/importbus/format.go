@@ -0,0 +1,61 @@
+package importbus
+
+import "fmt"
+
+type formatSet struct {
+	GalaxyHarvester Format
+	SWGAide         Format
+}
+
+// Formats represents the set of export formats that can be imported.
+var Formats = formatSet{
+	GalaxyHarvester: newFormat("gh-xml"),
+	SWGAide:         newFormat("swgaide-csv"),
+}
+
+// Parse parses the string value and returns a format if one exists.
+func (formatSet) Parse(value string) (Format, error) {
+	format, exists := formats[value]
+	if !exists {
+		return Format{}, fmt.Errorf("invalid format %q", value)
+	}
+
+	return format, nil
+}
+
+// MustParse parses the string value and returns a format if one exists. If
+// an error occurs the function panics.
+func (formatSet) MustParse(value string) Format {
+	format, err := Formats.Parse(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return format
+}
+
+// =============================================================================
+
+// Set of known formats.
+var formats = make(map[string]Format)
+
+// Format represents the format of an export file.
+type Format struct {
+	name string
+}
+
+func newFormat(format string) Format {
+	f := Format{format}
+	formats[format] = f
+	return f
+}
+
+// String returns the name of the format.
+func (f Format) String() string {
+	return f.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (f Format) Equal(f2 Format) bool {
+	return f.name == f2.name
+}
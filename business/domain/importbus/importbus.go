@@ -0,0 +1,342 @@
+// Package importbus provides business support for importing resources from
+// the export files of Galaxy Harvester and SWGAide.
+package importbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/godwinrob/harvester/foundation/validate"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for imports.
+var (
+	ErrMalformed = errors.New("import file is malformed")
+	ErrNoRows    = errors.New("import file has no resources")
+)
+
+// ChunkSize is the number of rows loaded per bulk create. An import carries a
+// whole export file, so its chunks are larger than the bulk.MaxBatchSize a
+// bulk request of the API is limited to.
+const ChunkSize = 500
+
+// maxStat is the largest value a resource stat can have.
+const maxStat = 1000
+
+// Business manages the set of APIs for importing resources.
+type Business struct {
+	log             *logger.Logger
+	galaxyBus       *galaxybus.Business
+	resourceBus     *resourcebus.Business
+	resourceTypeBus *resourcetypebus.Business
+	planetBus       *planetbus.Business
+}
+
+// NewBusiness constructs an import business API for use.
+func NewBusiness(log *logger.Logger, galaxyBus *galaxybus.Business, resourceBus *resourcebus.Business, resourceTypeBus *resourcetypebus.Business, planetBus *planetbus.Business) *Business {
+	return &Business{
+		log:             log,
+		galaxyBus:       galaxyBus,
+		resourceBus:     resourceBus,
+		resourceTypeBus: resourceTypeBus,
+		planetBus:       planetBus,
+	}
+}
+
+// pendingRow is a row that passed its checks, waiting to be loaded.
+type pendingRow struct {
+	row int
+	nr  resourcebus.NewResource
+}
+
+// Import parses the export file and adds its resources to the galaxy. Rows
+// naming a resource the galaxy already has are skipped as duplicates and rows
+// that fail validation are rejected; neither stops the other rows from
+// loading. The returned report has the outcome of every row.
+//
+// Each chunk of rows is committed on its own. When loading a chunk fails, the
+// report is returned along with the error: the rows of the earlier chunks keep
+// their outcome and the rows left to load are marked not attempted, so the
+// caller knows which rows to import again.
+func (b *Business) Import(ctx context.Context, ni NewImport) (Report, error) {
+	ctx, span := otel.AddSpan(ctx, "business.importbus.import")
+	defer span.End()
+
+	gal, err := b.galaxyBus.QueryByID(ctx, ni.GalaxyID)
+	if err != nil {
+		return Report{}, fmt.Errorf("querybyid: galaxyID[%s]: %w", ni.GalaxyID, err)
+	}
+
+	if !gal.Enabled {
+		return Report{}, galaxybus.ErrDisabled
+	}
+
+	records, err := parse(ni.Format, ni.Data)
+	if err != nil {
+		return Report{}, err
+	}
+
+	rsv, err := b.newResolver(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("newresolver: %w", err)
+	}
+
+	rows := make([]Row, len(records))
+	seen := make(map[string]bool)
+	var pending []pendingRow
+
+	for i, rec := range records {
+		rows[i] = Row{
+			Line: rec.line,
+			Name: rec.name,
+		}
+
+		nr, msgs := toNewResource(rec, rsv, ni)
+		if len(msgs) > 0 {
+			rows[i].reject(msgs...)
+			continue
+		}
+
+		if seen[rec.name] {
+			rows[i].Status = Statuses.Duplicate
+			continue
+		}
+		seen[rec.name] = true
+
+		pending = append(pending, pendingRow{row: i, nr: nr})
+	}
+
+	for start := 0; start < len(pending); start += ChunkSize {
+		end := min(start+ChunkSize, len(pending))
+
+		if err := b.load(ctx, ni.GalaxyID, rows, pending[start:end]); err != nil {
+			for _, p := range pending[start:] {
+				if rows[p.row].Status.Equal(Status{}) {
+					rows[p.row].Status = Statuses.NotAttempted
+				}
+			}
+
+			return newReport(rows), err
+		}
+	}
+
+	return newReport(rows), nil
+}
+
+// load marks the rows of a chunk naming a resource the galaxy already has as
+// duplicates, checking the whole chunk in one query, and creates the rest.
+func (b *Business) load(ctx context.Context, galaxyID uuid.UUID, rows []Row, chunk []pendingRow) error {
+	names := make([]string, len(chunk))
+	for i, p := range chunk {
+		names[i] = p.nr.Name.String()
+	}
+
+	taken, err := b.resourceBus.QueryNames(ctx, galaxyID, names)
+	if err != nil {
+		return fmt.Errorf("querynames: line[%d]: %w", rows[chunk[0].row].Line, err)
+	}
+
+	exists := make(map[string]bool, len(taken))
+	for _, name := range taken {
+		exists[name] = true
+	}
+
+	var rest []pendingRow
+	for _, p := range chunk {
+		if exists[p.nr.Name.String()] {
+			rows[p.row].Status = Statuses.Duplicate
+			continue
+		}
+		rest = append(rest, p)
+	}
+
+	return b.create(ctx, rows, rest)
+}
+
+// create creates the resources of a chunk of rows in one bulk create. Rows
+// failing the resource validation are rejected and the rest of the chunk is
+// loaded again without them.
+func (b *Business) create(ctx context.Context, rows []Row, chunk []pendingRow) error {
+	for len(chunk) > 0 {
+		nrs := make([]resourcebus.NewResource, len(chunk))
+		for i, p := range chunk {
+			nrs[i] = p.nr
+		}
+
+		resources, err := b.resourceBus.BulkCreate(ctx, nrs)
+
+		var itemErrs resourcebus.ItemErrors
+		switch {
+		case err == nil:
+			for i, res := range resources {
+				rows[chunk[i].row].Status = Statuses.Accepted
+				rows[chunk[i].row].ResourceID = res.ID
+			}
+			return nil
+
+		case errors.As(err, &itemErrs):
+			failed := make(map[int]bool)
+			for _, ie := range itemErrs {
+				rows[chunk[ie.Index].row].reject(errorMessages(ie.Err)...)
+				failed[ie.Index] = true
+			}
+
+			var rest []pendingRow
+			for i, p := range chunk {
+				if !failed[i] {
+					rest = append(rest, p)
+				}
+			}
+			chunk = rest
+
+		case errors.Is(err, resourcebus.ErrUniqueName) && len(chunk) > 1:
+			// A name was taken after it was checked. Load the rows one at a
+			// time to find which.
+			for _, p := range chunk {
+				if err := b.create(ctx, rows, []pendingRow{p}); err != nil {
+					return err
+				}
+			}
+			return nil
+
+		case errors.Is(err, resourcebus.ErrUniqueName):
+			rows[chunk[0].row].Status = Statuses.Duplicate
+			return nil
+
+		default:
+			return fmt.Errorf("bulkcreate: line[%d]: %w", rows[chunk[0].row].Line, err)
+		}
+	}
+
+	return nil
+}
+
+// =============================================================================
+
+// toNewResource checks the values of a record and converts it into a new
+// resource, or returns the reasons it can't be.
+func toNewResource(rec record, rsv *resolver, ni NewImport) (resourcebus.NewResource, []string) {
+	var msgs []string
+
+	name, err := resourcebus.Names.Parse(rec.name)
+	if err != nil {
+		msgs = append(msgs, fmt.Sprintf("invalid name %q", rec.name))
+	}
+
+	var resourceType string
+	switch rec.typ {
+	case "":
+		msgs = append(msgs, "resource type is missing")
+	default:
+		resourceType, err = rsv.resourceType(rec.typ)
+		if err != nil {
+			msgs = append(msgs, err.Error())
+		}
+	}
+
+	stats := make(map[string]int16)
+	for _, code := range statCodes {
+		value := rec.stats[code]
+		if value == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 || n > maxStat {
+			msgs = append(msgs, fmt.Sprintf("%s must be a number between 0 and %d, got %q", code, maxStat, value))
+			continue
+		}
+		stats[code] = int16(n)
+	}
+
+	var planets []int16
+	for _, p := range rec.planets {
+		if id, err := strconv.ParseInt(p, 10, 16); err == nil {
+			planets = append(planets, int16(id))
+			continue
+		}
+
+		id, err := rsv.planet(p)
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			continue
+		}
+		planets = append(planets, id)
+	}
+
+	if len(msgs) > 0 {
+		return resourcebus.NewResource{}, msgs
+	}
+
+	nr := resourcebus.NewResource{
+		Name:         name,
+		GalaxyID:     ni.GalaxyID,
+		AddedUserID:  ni.UserID,
+		ResourceType: resourceType,
+		CR:           stats["cr"],
+		CD:           stats["cd"],
+		DR:           stats["dr"],
+		FL:           stats["fl"],
+		HR:           stats["hr"],
+		MA:           stats["ma"],
+		PE:           stats["pe"],
+		OQ:           stats["oq"],
+		SR:           stats["sr"],
+		UT:           stats["ut"],
+		ER:           stats["er"],
+		Planets:      planets,
+	}
+
+	return nr, nil
+}
+
+// errorMessages returns a message for each field a resource failed
+// validation on.
+func errorMessages(err error) []string {
+	fe := validate.GetFieldErrors(err)
+	if fe == nil {
+		return []string{err.Error()}
+	}
+
+	msgs := make([]string, len(fe))
+	for i, f := range fe {
+		msgs[i] = f.Err
+	}
+
+	return msgs
+}
+
+func (r *Row) reject(msgs ...string) {
+	r.Status = Statuses.Rejected
+	r.Errors = append(r.Errors, msgs...)
+}
+
+func newReport(rows []Row) Report {
+	rpt := Report{
+		Rows: rows,
+	}
+
+	for _, row := range rows {
+		switch row.Status {
+		case Statuses.Accepted:
+			rpt.Accepted++
+		case Statuses.Duplicate:
+			rpt.Duplicates++
+		case Statuses.Rejected:
+			rpt.Rejected++
+		case Statuses.NotAttempted:
+			rpt.NotAttempted++
+		}
+	}
+
+	return rpt
+}
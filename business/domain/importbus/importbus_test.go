@@ -0,0 +1,76 @@
+package importbus
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestToNewResource(t *testing.T) {
+	rsv := resolver{
+		types:   map[string]string{"steel": "steel_carbonite", "ambiguous": ""},
+		planets: map[string]int16{"tatooine": 1},
+	}
+
+	ni := NewImport{
+		GalaxyID: uuid.New(),
+		UserID:   uuid.New(),
+	}
+
+	tests := []struct {
+		name     string
+		rec      record
+		wantMsgs int
+	}{
+		{"valid", record{name: "Abcdef", typ: "Steel", stats: map[string]string{"cr": "100", "oq": "1000", "er": ""}, planets: []string{"Tatooine", "5"}}, 0},
+		{"invalid name", record{name: "a b!", typ: "Steel", stats: map[string]string{}}, 1},
+		{"missing type", record{name: "Abcdef", stats: map[string]string{}}, 1},
+		{"ambiguous type", record{name: "Abcdef", typ: "Ambiguous", stats: map[string]string{}}, 1},
+		{"bad stats", record{name: "Abcdef", typ: "Steel", stats: map[string]string{"cr": "high", "oq": "1001", "dr": "-1"}}, 3},
+		{"unknown planet", record{name: "Abcdef", typ: "Steel", stats: map[string]string{}, planets: []string{"Kashyyyk"}}, 1},
+		{"every reason", record{name: "", typ: "Unknown", stats: map[string]string{"ut": "x"}, planets: []string{"Nowhere"}}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nr, msgs := toNewResource(tt.rec, &rsv, ni)
+			if len(msgs) != tt.wantMsgs {
+				t.Fatalf("toNewResource() messages = %q, want %d", msgs, tt.wantMsgs)
+			}
+
+			if tt.wantMsgs > 0 {
+				return
+			}
+
+			if nr.Name.String() != tt.rec.name || nr.ResourceType != "steel_carbonite" || nr.GalaxyID != ni.GalaxyID || nr.AddedUserID != ni.UserID {
+				t.Errorf("toNewResource() = %+v", nr)
+			}
+
+			if nr.CR != 100 || nr.OQ != 1000 || nr.ER != 0 {
+				t.Errorf("stats cr[%d] oq[%d] er[%d], want 100, 1000 and 0", nr.CR, nr.OQ, nr.ER)
+			}
+
+			if want := []int16{1, 5}; !reflect.DeepEqual(nr.Planets, want) {
+				t.Errorf("planets = %v, want %v", nr.Planets, want)
+			}
+		})
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	rows := []Row{
+		{Status: Statuses.Accepted},
+		{Status: Statuses.Accepted},
+		{Status: Statuses.Duplicate},
+		{Status: Statuses.Rejected},
+		{Status: Statuses.NotAttempted},
+		{Status: Statuses.NotAttempted},
+	}
+
+	rpt := newReport(rows)
+
+	if rpt.Accepted != 2 || rpt.Duplicates != 1 || rpt.Rejected != 1 || rpt.NotAttempted != 2 {
+		t.Errorf("newReport() = %d accepted, %d duplicates, %d rejected, %d not attempted, want 2, 1, 1 and 2", rpt.Accepted, rpt.Duplicates, rpt.Rejected, rpt.NotAttempted)
+	}
+}
@@ -0,0 +1,36 @@
+package importbus
+
+import (
+	"io"
+
+	"github.com/google/uuid"
+)
+
+// NewImport contains the information needed to import an export file into a
+// galaxy.
+type NewImport struct {
+	GalaxyID uuid.UUID
+	UserID   uuid.UUID
+	Format   Format
+	Data     io.Reader
+}
+
+// Row represents the outcome of a single row of an export file. Line is the
+// line of the file the row starts on. ResourceID is set for accepted rows
+// and Errors for rejected ones.
+type Row struct {
+	Line       int
+	Name       string
+	Status     Status
+	ResourceID uuid.UUID
+	Errors     []string
+}
+
+// Report represents the outcome of an import, row by row.
+type Report struct {
+	Accepted     int
+	Duplicates   int
+	Rejected     int
+	NotAttempted int
+	Rows         []Row
+}
@@ -0,0 +1,244 @@
+package importbus
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// statCodes are the stats a resource can have, by the column and element
+// names the export formats use for them.
+var statCodes = []string{"cr", "cd", "dr", "fl", "hr", "ma", "pe", "oq", "sr", "ut", "er"}
+
+// record represents a row of an export file before its values are checked.
+type record struct {
+	line    int
+	name    string
+	typ     string
+	stats   map[string]string
+	planets []string
+}
+
+func newRecord(line int) record {
+	return record{
+		line:  line,
+		stats: make(map[string]string),
+	}
+}
+
+func (r *record) setStat(code string, value string) bool {
+	code = strings.ToLower(code)
+	for _, sc := range statCodes {
+		if code == sc {
+			r.stats[code] = strings.TrimSpace(value)
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *record) addPlanets(value string) {
+	for _, p := range strings.FieldsFunc(value, isListSeparator) {
+		if p = strings.TrimSpace(p); p != "" {
+			r.planets = append(r.planets, p)
+		}
+	}
+}
+
+func isListSeparator(r rune) bool {
+	return r == ',' || r == ';' || r == '|'
+}
+
+func parse(format Format, data io.Reader) ([]record, error) {
+	var records []record
+	var err error
+
+	switch format {
+	case Formats.GalaxyHarvester:
+		records, err = parseGalaxyHarvester(data)
+	case Formats.SWGAide:
+		records, err = parseSWGAide(data)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", ErrMalformed, format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+
+	if len(records) == 0 {
+		return nil, ErrNoRows
+	}
+
+	return records, nil
+}
+
+// =============================================================================
+
+// element represents an XML element of a Galaxy Harvester export.
+type element struct {
+	XMLName  xml.Name
+	Value    string    `xml:",chardata"`
+	Children []element `xml:",any"`
+}
+
+func (e element) name() string {
+	return strings.ToLower(e.XMLName.Local)
+}
+
+func (e element) value() string {
+	return strings.TrimSpace(e.Value)
+}
+
+// parseGalaxyHarvester reads the resource elements of a Galaxy Harvester XML
+// export, wherever they are nested. A resource carries its name, its type as
+// a type key or name, its stats either directly or within a stats element,
+// and the planets it is on either as planet elements or a list.
+func parseGalaxyHarvester(data io.Reader) ([]record, error) {
+	dec := xml.NewDecoder(data)
+
+	var records []record
+	for {
+		line, _ := dec.InputPos()
+
+		tok, err := dec.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, fmt.Errorf("xml: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || !strings.EqualFold(start.Name.Local, "resource") {
+			continue
+		}
+
+		var e element
+		if err := dec.DecodeElement(&e, &start); err != nil {
+			return nil, fmt.Errorf("xml: line %d: %w", line, err)
+		}
+
+		records = append(records, toRecord(line, e))
+	}
+}
+
+func toRecord(line int, e element) record {
+	rec := newRecord(line)
+
+	for _, c := range e.Children {
+		switch c.name() {
+		case "name":
+			rec.name = c.value()
+
+		case "type", "resource_type", "resourcetype", "type_id", "typeid", "type_name", "typename":
+			if rec.typ == "" {
+				rec.typ = c.value()
+			}
+
+		case "stats":
+			for _, s := range c.Children {
+				rec.setStat(s.name(), s.value())
+			}
+
+		case "planets":
+			if len(c.Children) == 0 {
+				rec.addPlanets(c.value())
+			}
+			for _, p := range c.Children {
+				rec.addPlanets(p.value())
+			}
+
+		case "planet":
+			rec.addPlanets(c.value())
+
+		default:
+			rec.setStat(c.name(), c.value())
+		}
+	}
+
+	return rec
+}
+
+// =============================================================================
+
+// parseSWGAide reads a SWGAide CSV export. The columns are found by the names
+// in the header row, in any order: name, type (or class), the stat codes and
+// planets. Lines starting with # are comments.
+func parseSWGAide(data io.Reader) ([]record, error) {
+	r := csv.NewReader(data)
+	r.Comment = '#'
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("csv: header: %w", err)
+	}
+
+	columns := make(map[string]int)
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		switch h {
+		case "resource", "resource name":
+			h = "name"
+		case "class", "resource class", "resource type":
+			h = "type"
+		case "planet":
+			h = "planets"
+		}
+
+		if _, exists := columns[h]; !exists {
+			columns[h] = i
+		}
+	}
+
+	for _, required := range []string{"name", "type"} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("csv: header has no %s column", required)
+		}
+	}
+
+	field := func(fields []string, column string) string {
+		i, exists := columns[column]
+		if !exists || i >= len(fields) {
+			return ""
+		}
+		return strings.TrimSpace(fields[i])
+	}
+
+	var records []record
+	for {
+		fields, err := r.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return records, nil
+			}
+			return nil, fmt.Errorf("csv: %w", err)
+		}
+
+		line, _ := r.FieldPos(0)
+
+		rec := newRecord(line)
+		rec.name = field(fields, "name")
+		rec.typ = field(fields, "type")
+		rec.addPlanets(field(fields, "planets"))
+		for _, sc := range statCodes {
+			rec.setStat(sc, field(fields, sc))
+		}
+
+		if rec.name == "" && rec.typ == "" {
+			continue
+		}
+
+		records = append(records, rec)
+	}
+}
@@ -0,0 +1,167 @@
+package importbus
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseGalaxyHarvester(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []record
+	}{
+		{
+			name: "flat",
+			data: `<resources>
+<resource><name>Abcdef</name><type>aluminum_agrinium</type><CR>100</CR><OQ> 950 </OQ><planets>Tatooine, Naboo</planets></resource>
+</resources>`,
+			want: []record{
+				{line: 2, name: "Abcdef", typ: "aluminum_agrinium", stats: map[string]string{"cr": "100", "oq": "950"}, planets: []string{"Tatooine", "Naboo"}},
+			},
+		},
+		{
+			name: "nested stats and planets",
+			data: `<export>
+  <galaxy>
+    <resource>
+      <name>Ghijk</name>
+      <resource_type>Agrinium Aluminum</resource_type>
+      <type>ignored</type>
+      <stats><cd>5</cd><unknown>7</unknown></stats>
+      <planets><planet>Corellia</planet><planet>Lok</planet></planets>
+    </resource>
+  </galaxy>
+</export>`,
+			want: []record{
+				{line: 3, name: "Ghijk", typ: "Agrinium Aluminum", stats: map[string]string{"cd": "5"}, planets: []string{"Corellia", "Lok"}},
+			},
+		},
+		{
+			name: "planet elements",
+			data: `<Resource><Name>Lmnop</Name><typename>Steel</typename><planet>Dantooine</planet><planet>Endor|Yavin 4</planet></Resource>`,
+			want: []record{
+				{line: 1, name: "Lmnop", typ: "Steel", stats: map[string]string{}, planets: []string{"Dantooine", "Endor", "Yavin 4"}},
+			},
+		},
+		{
+			name: "no resources",
+			data: `<resources></resources>`,
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseGalaxyHarvester(strings.NewReader(tt.data))
+			if err != nil {
+				t.Fatalf("parseGalaxyHarvester: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseGalaxyHarvester() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSWGAide(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []record
+		wantErr bool
+	}{
+		{
+			name: "columns in any order",
+			data: "\ufeffName,OQ,Class,CR,Planets\nAbcdef,950,Steel,100,\"Tatooine, Naboo\"\n",
+			want: []record{
+				{line: 2, name: "Abcdef", typ: "Steel", stats: csvStats(map[string]string{"oq": "950", "cr": "100"}), planets: []string{"Tatooine", "Naboo"}},
+			},
+		},
+		{
+			name: "comments, blank rows and short rows",
+			data: "# exported by SWGAide\nresource name,resource type,er,planet\n\n,,,\nGhijk,Copper\n",
+			want: []record{
+				{line: 5, name: "Ghijk", typ: "Copper", stats: csvStats(nil)},
+			},
+		},
+		{
+			name: "header only",
+			data: "name,type\n",
+			want: nil,
+		},
+		{
+			name: "empty",
+			data: "",
+			want: nil,
+		},
+		{
+			name:    "no name column",
+			data:    "type,cr\nSteel,100\n",
+			wantErr: true,
+		},
+		{
+			name:    "no type column",
+			data:    "name,cr\nAbcdef,100\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSWGAide(strings.NewReader(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSWGAide() = %+v, want an error", got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseSWGAide: %s", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSWGAide() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// csvStats returns the stats of a SWGAide record, which has every stat code
+// with the codes missing from the file empty.
+func csvStats(stats map[string]string) map[string]string {
+	all := make(map[string]string)
+	for _, code := range statCodes {
+		all[code] = stats[code]
+	}
+
+	return all
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		data    string
+		wantErr error
+	}{
+		{"rows", Formats.SWGAide, "name,type\nAbcdef,Steel\n", nil},
+		{"no rows", Formats.SWGAide, "name,type\n", ErrNoRows},
+		{"bad header", Formats.SWGAide, "cr\n100\n", ErrMalformed},
+		{"bad xml", Formats.GalaxyHarvester, "<resource><name>Abcdef</resource>", ErrMalformed},
+		{"unknown format", Format{}, "", ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parse(tt.format, strings.NewReader(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("parse() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
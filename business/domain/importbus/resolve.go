@@ -0,0 +1,143 @@
+package importbus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+	"github.com/godwinrob/harvester/business/sdk/migrate"
+)
+
+// lookupPageSize is the number of resource types and planets read per query
+// while building a resolver.
+const lookupPageSize = 500
+
+// resolver maps the resource type and planet names used by export files onto
+// the keys the system uses. An empty type key marks a name that matches more
+// than one resource type.
+type resolver struct {
+	types   map[string]string
+	planets map[string]int16
+}
+
+// newResolver indexes every resource type by its key and name, and every
+// planet by its name. The display names in migrate.ResourceTypes are added as
+// aliases of the one resource type whose name they start, so shortened names
+// like "Agrinium" resolve too.
+func (b *Business) newResolver(ctx context.Context) (*resolver, error) {
+	r := resolver{
+		types:   make(map[string]string),
+		planets: make(map[string]int16),
+	}
+
+	var rts []resourcetypebus.ResourceType
+	for page := 1; ; page++ {
+		list, err := b.resourceTypeBus.Query(ctx, resourcetypebus.QueryFilter{}, resourcetypebus.DefaultOrderBy, page, lookupPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("query resource types: page[%d]: %w", page, err)
+		}
+
+		rts = append(rts, list...)
+		if len(list) < lookupPageSize {
+			break
+		}
+	}
+
+	for _, rt := range rts {
+		r.addType(rt.ResourceType, rt.ResourceType)
+		r.addType(rt.ResourceTypeName, rt.ResourceType)
+	}
+
+	for _, alias := range migrate.ResourceTypes {
+		key := normalize(alias)
+		if _, exists := r.types[key]; exists {
+			continue
+		}
+
+		prefix := strings.ToLower(alias) + " "
+
+		var match string
+		var matches int
+		for _, rt := range rts {
+			if strings.HasPrefix(strings.ToLower(rt.ResourceTypeName), prefix) {
+				match = rt.ResourceType
+				matches++
+			}
+		}
+
+		if matches == 1 {
+			r.types[key] = match
+		}
+	}
+
+	for page := 1; ; page++ {
+		list, err := b.planetBus.Query(ctx, planetbus.QueryFilter{}, planetbus.DefaultOrderBy, page, lookupPageSize)
+		if err != nil {
+			return nil, fmt.Errorf("query planets: page[%d]: %w", page, err)
+		}
+
+		for _, p := range list {
+			r.planets[normalize(p.Name)] = p.ID
+		}
+
+		if len(list) < lookupPageSize {
+			break
+		}
+	}
+
+	return &r, nil
+}
+
+func (r *resolver) addType(name string, resourceType string) {
+	key := normalize(name)
+	if key == "" {
+		return
+	}
+
+	if existing, exists := r.types[key]; exists && existing != resourceType {
+		r.types[key] = ""
+		return
+	}
+
+	r.types[key] = resourceType
+}
+
+// resourceType returns the key of the resource type the name refers to.
+func (r *resolver) resourceType(name string) (string, error) {
+	resourceType, exists := r.types[normalize(name)]
+	switch {
+	case !exists:
+		return "", fmt.Errorf("unknown resource type %q", name)
+	case resourceType == "":
+		return "", fmt.Errorf("resource type %q is ambiguous", name)
+	}
+
+	return resourceType, nil
+}
+
+// planet returns the id of the planet the name refers to.
+func (r *resolver) planet(name string) (int16, error) {
+	id, exists := r.planets[normalize(name)]
+	if !exists {
+		return 0, fmt.Errorf("unknown planet %q", name)
+	}
+
+	return id, nil
+}
+
+// normalize reduces a name to its lower case letters and digits, so names
+// differing only in case, spacing and punctuation, like "Link-Steel Aluminum"
+// and "link steel aluminum", compare equal.
+func normalize(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
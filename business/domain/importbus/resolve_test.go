@@ -0,0 +1,89 @@
+package importbus
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Link-Steel Aluminum", "linksteelaluminum"},
+		{"link steel aluminum", "linksteelaluminum"},
+		{"aluminum_agrinium", "aluminumagrinium"},
+		{"Yavin 4", "yavin4"},
+		{" -_ ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := normalize(tt.name); got != tt.want {
+				t.Errorf("normalize(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolver(t *testing.T) {
+	r := resolver{
+		types:   make(map[string]string),
+		planets: map[string]int16{"tatooine": 1, "yavin4": 9},
+	}
+
+	r.addType("aluminum_agrinium", "aluminum_agrinium")
+	r.addType("Agrinium Aluminum", "aluminum_agrinium")
+	r.addType("steel_carbonite", "steel_carbonite")
+	r.addType("Carbonite Steel", "steel_carbonite")
+	r.addType("Shared Name", "steel_carbonite")
+	r.addType("shared-name", "steel_duralloy")
+	r.addType("", "steel_duralloy")
+
+	typeTests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{"aluminum_agrinium", "aluminum_agrinium", false},
+		{"Agrinium Aluminum", "aluminum_agrinium", false},
+		{"AGRINIUM-ALUMINUM", "aluminum_agrinium", false},
+		{"carbonite steel", "steel_carbonite", false},
+		{"Shared Name", "", true},
+		{"Unknown", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range typeTests {
+		t.Run("type/"+tt.name, func(t *testing.T) {
+			got, err := r.resourceType(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resourceType(%q) error = %v, want error %t", tt.name, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("resourceType(%q) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	planetTests := []struct {
+		name    string
+		want    int16
+		wantErr bool
+	}{
+		{"Tatooine", 1, false},
+		{"yavin 4", 9, false},
+		{"Kashyyyk", 0, true},
+	}
+
+	for _, tt := range planetTests {
+		t.Run("planet/"+tt.name, func(t *testing.T) {
+			got, err := r.planet(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("planet(%q) error = %v, want error %t", tt.name, err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("planet(%q) = %d, want %d", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package importbus
+
+import "fmt"
+
+type statusSet struct {
+	Accepted     Status
+	Duplicate    Status
+	Rejected     Status
+	NotAttempted Status
+}
+
+// Statuses represents the set of outcomes of an imported row. A duplicate
+// names a resource the galaxy already has, or one an earlier row of the same
+// file named. A row is not attempted when the import failed before its chunk
+// was loaded.
+var Statuses = statusSet{
+	Accepted:     newStatus("ACCEPTED"),
+	Duplicate:    newStatus("DUPLICATE"),
+	Rejected:     newStatus("REJECTED"),
+	NotAttempted: newStatus("NOT_ATTEMPTED"),
+}
+
+// Parse parses the string value and returns a status if one exists.
+func (statusSet) Parse(value string) (Status, error) {
+	status, exists := statuses[value]
+	if !exists {
+		return Status{}, fmt.Errorf("invalid status %q", value)
+	}
+
+	return status, nil
+}
+
+// =============================================================================
+
+// Set of known statuses.
+var statuses = make(map[string]Status)
+
+// Status represents the outcome of an imported row.
+type Status struct {
+	name string
+}
+
+func newStatus(status string) Status {
+	s := Status{status}
+	statuses[status] = s
+	return s
+}
+
+// String returns the name of the status.
+func (s Status) String() string {
+	return s.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (s Status) Equal(s2 Status) bool {
+	return s.name == s2.name
+}
@@ -0,0 +1,7 @@
+package planetbus
+
+// QueryFilter holds the available fields a query can be filtered on.
+type QueryFilter struct {
+	ID   *int16
+	Name *string
+}
@@ -0,0 +1,7 @@
+package planetbus
+
+// Planet represents a planet resources can spawn on.
+type Planet struct {
+	ID   int16
+	Name string
+}
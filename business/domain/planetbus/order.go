@@ -0,0 +1,12 @@
+package planetbus
+
+import "github.com/godwinrob/harvester/business/sdk/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByID, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID   = "planet_id"
+	OrderByName = "planet_name"
+)
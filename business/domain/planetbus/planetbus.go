@@ -0,0 +1,88 @@
+package planetbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound = errors.New("planet not found")
+)
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve data.
+type Storer interface {
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Planet, error)
+	QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Planet, order.Cursor, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, planetID int16) (Planet, error)
+}
+
+// Business manages the set of APIs for planet access.
+type Business struct {
+	log    *logger.Logger
+	storer Storer
+}
+
+// NewBusiness constructs a planet business API for use.
+func NewBusiness(log *logger.Logger, storer Storer) *Business {
+	return &Business{
+		log:    log,
+		storer: storer,
+	}
+}
+
+// Query retrieves a list of existing planets.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Planet, error) {
+	ctx, span := otel.AddSpan(ctx, "business.planetbus.query")
+	defer span.End()
+
+	planets, err := b.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return planets, nil
+}
+
+// QueryAfter retrieves the page of planets that follows the cursor in the
+// order, and the cursor for the page after it. The zero cursor starts at the
+// first planet.
+func (b *Business) QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Planet, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.planetbus.queryafter")
+	defer span.End()
+
+	planets, next, err := b.storer.QueryAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryafter: %w", err)
+	}
+
+	return planets, next, nil
+}
+
+// Count returns the total number of planets.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.planetbus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the planet by the specified id.
+func (b *Business) QueryByID(ctx context.Context, planetID int16) (Planet, error) {
+	ctx, span := otel.AddSpan(ctx, "business.planetbus.querybyid")
+	defer span.End()
+
+	planet, err := b.storer.QueryByID(ctx, planetID)
+	if err != nil {
+		return Planet{}, fmt.Errorf("query: planetID[%d]: %w", planetID, err)
+	}
+
+	return planet, nil
+}
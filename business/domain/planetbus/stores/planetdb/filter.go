@@ -0,0 +1,28 @@
+package planetdb
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+)
+
+func applyFilter(filter planetbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["planet_id"] = *filter.ID
+		wc = append(wc, "planet_id = :planet_id")
+	}
+
+	if filter.Name != nil {
+		data["planet_name"] = fmt.Sprintf("%%%s%%", *filter.Name)
+		wc = append(wc, "planet_name ILIKE :planet_name")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
@@ -0,0 +1,31 @@
+package planetdb
+
+import (
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+)
+
+type planet struct {
+	ID   int16  `db:"planet_id"`
+	Name string `db:"planet_name"`
+}
+
+type keyedPlanet struct {
+	planet
+	sqldb.Keyed
+}
+
+func toBusPlanet(db planet) planetbus.Planet {
+	return planetbus.Planet{
+		ID:   db.ID,
+		Name: db.Name,
+	}
+}
+
+func toBusPlanets(dbs []planet) []planetbus.Planet {
+	bus := make([]planetbus.Planet, len(dbs))
+	for i, db := range dbs {
+		bus[i] = toBusPlanet(db)
+	}
+	return bus
+}
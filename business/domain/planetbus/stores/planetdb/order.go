@@ -0,0 +1,32 @@
+package planetdb
+
+import (
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+)
+
+var orderByFields = map[string]string{
+	planetbus.OrderByID:   "planet_id",
+	planetbus.OrderByName: "planet_name",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
+
+func keyset(orderBy order.By) (sqldb.Keyset, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return sqldb.Keyset{}, fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return sqldb.Keyset{Expr: by, ID: "planet_id", Direction: orderBy.Direction}, nil
+}
@@ -0,0 +1,146 @@
+package planetdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for planet database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the api for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// Query retrieves a list of existing planets from the database.
+func (s *Store) Query(ctx context.Context, filter planetbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]planetbus.Planet, error) {
+	data := map[string]any{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		planet_id, planet_name
+	FROM
+		planets`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbPlanets []planet
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbPlanets); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusPlanets(dbPlanets), nil
+}
+
+// QueryAfter retrieves the page of planets that follows the cursor in the
+// order, along with the cursor that follows the last of them.
+func (s *Store) QueryAfter(ctx context.Context, filter planetbus.QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]planetbus.Planet, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		planet_id, planet_name
+	FROM
+		planets`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	keyset, err := keyset(orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedPlanet
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbPlanets := make([]planet, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbPlanets[i] = k.planet
+	}
+
+	return toBusPlanets(dbPlanets), dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
+// Count returns the total number of planets in the DB.
+func (s *Store) Count(ctx context.Context, filter planetbus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		planets`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified planet from the database.
+func (s *Store) QueryByID(ctx context.Context, planetID int16) (planetbus.Planet, error) {
+	data := struct {
+		ID int16 `db:"planet_id"`
+	}{
+		ID: planetID,
+	}
+
+	const q = `
+	SELECT
+		planet_id, planet_name
+	FROM
+		planets
+	WHERE
+		planet_id = :planet_id`
+
+	var dbPlanet planet
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbPlanet); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return planetbus.Planet{}, fmt.Errorf("db: %w", planetbus.ErrNotFound)
+		}
+		return planetbus.Planet{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toBusPlanet(dbPlanet), nil
+}
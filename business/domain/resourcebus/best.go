@@ -0,0 +1,76 @@
+package resourcebus
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/godwinrob/harvester/foundation/otel"
+)
+
+// Weights holds the experimentation weight, in percent, each resource stat
+// contributes to a weighted score. A 66% OQ / 33% SR formula sets OQ to 66
+// and SR to 33.
+type Weights struct {
+	CR int16
+	CD int16
+	DR int16
+	FL int16
+	HR int16
+	MA int16
+	PE int16
+	OQ int16
+	SR int16
+	UT int16
+	ER int16
+}
+
+// Total returns the sum of all the weights.
+func (w Weights) Total() int {
+	return int(w.CR) + int(w.CD) + int(w.DR) + int(w.FL) + int(w.HR) + int(w.MA) +
+		int(w.PE) + int(w.OQ) + int(w.SR) + int(w.UT) + int(w.ER)
+}
+
+// Validate checks every weight is a percentage and that together they add up
+// to no more than 100.
+func (w Weights) Validate() error {
+	weights := []int16{w.CR, w.CD, w.DR, w.FL, w.HR, w.MA, w.PE, w.OQ, w.SR, w.UT, w.ER}
+
+	if slices.ContainsFunc(weights, func(v int16) bool { return v < 0 || v > 100 }) {
+		return ErrWeightOutOfRange
+	}
+
+	if total := w.Total(); total < 1 || total > 100 {
+		return ErrWeightTotalOutOfRange
+	}
+
+	return nil
+}
+
+// ScoredResource represents a resource and its weighted score. The score is
+// on a scale of 0 to 1000. Each weighted stat is normalised against the
+// min/max caps of the resource's type, so a stat at the type's cap counts
+// fully no matter which type in a group the resource is. A weighted stat the
+// type does not carry counts as zero.
+type ScoredResource struct {
+	Resource Resource
+	Score    float64
+}
+
+// QueryBest retrieves up to limit resources matching the filter, ranked by
+// their weighted score for the weights, highest first.
+func (b *Business) QueryBest(ctx context.Context, filter QueryFilter, weights Weights, limit int) ([]ScoredResource, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.querybest")
+	defer span.End()
+
+	if err := weights.Validate(); err != nil {
+		return nil, fmt.Errorf("validate: %w", err)
+	}
+
+	resources, err := b.storer.QueryBest(ctx, filter, weights, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querybest: %w", err)
+	}
+
+	return resources, nil
+}
@@ -0,0 +1,71 @@
+package resourcebus
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/godwinrob/harvester/business/sdk/delegate"
+	"github.com/google/uuid"
+)
+
+// DomainName represents the name of this domain.
+const DomainName = "resource"
+
+// Set of delegate actions the resource domain calls.
+const (
+	ActionCreated   = "created"
+	ActionVerified  = "verified"
+	ActionDespawned = "despawned"
+)
+
+// ActionParms represents the parameters of every resource action: the
+// resources the action was applied to.
+type ActionParms struct {
+	ResourceIDs []uuid.UUID
+}
+
+// String returns a string representation of the action parameters.
+func (p *ActionParms) String() string {
+	return "ActionParms"
+}
+
+// Marshal returns the event parameters encoded as JSON.
+func (p *ActionParms) Marshal() ([]byte, error) {
+	return json.Marshal(p)
+}
+
+// ActionData constructs the data for the specified action.
+func ActionData(action string, resourceIDs ...uuid.UUID) delegate.Data {
+	params := ActionParms{
+		ResourceIDs: resourceIDs,
+	}
+
+	rawParams, err := params.Marshal()
+	if err != nil {
+		panic(err)
+	}
+
+	return delegate.Data{
+		Domain:    DomainName,
+		Action:    action,
+		RawParams: rawParams,
+	}
+}
+
+// notify counts the action in the metrics and tells the registered delegates
+// that it was committed on the resources. The change is already stored, so
+// failures are logged rather than returned to the caller, and the delegates
+// run even if the request that made the change has gone away.
+func (b *Business) notify(ctx context.Context, action string, resources ...Resource) {
+	countAction(action, resources...)
+
+	ids := make([]uuid.UUID, len(resources))
+	for i, res := range resources {
+		ids[i] = res.ID
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	if err := b.delegate.Call(ctx, ActionData(action, ids...)); err != nil {
+		b.log.Error(ctx, "resourcebus: delegate", "action", action, "ERROR", err)
+	}
+}
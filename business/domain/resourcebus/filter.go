@@ -8,15 +8,20 @@ import (
 
 // QueryFilter holds the available fields a query can be filtered on.
 // We are using pointer semantics because the With API mutates the value.
+// CR through ER hold the lowest value of each stat to match and CRMax
+// through ERMax the highest, both inclusive.
 type QueryFilter struct {
 	ID               *uuid.UUID
+	IDs              []uuid.UUID
 	GalaxyID         *uuid.UUID
 	ResourceName     *Name
 	ResourceType     *string
 	ResourceGroup    *string
+	Planet           *int16
 	StartCreatedDate *time.Time
 	EndCreatedDate   *time.Time
 	Verified         *bool
+	Available        *bool
 	CR               *int16
 	CD               *int16
 	DR               *int16
@@ -28,4 +33,15 @@ type QueryFilter struct {
 	SR               *int16
 	UT               *int16
 	ER               *int16
+	CRMax            *int16
+	CDMax            *int16
+	DRMax            *int16
+	FLMax            *int16
+	HRMax            *int16
+	MAMax            *int16
+	PEMax            *int16
+	OQMax            *int16
+	SRMax            *int16
+	UTMax            *int16
+	ERMax            *int16
 }
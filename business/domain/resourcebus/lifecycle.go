@@ -0,0 +1,88 @@
+package resourcebus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/google/uuid"
+)
+
+// Despawn marks a resource as no longer in spawn. The userID identifies the
+// acting user and is recorded against the resource and its status history.
+func (b *Business) Despawn(ctx context.Context, res Resource, userID uuid.UUID) (Resource, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.despawn")
+	defer span.End()
+
+	if !res.UnavailableAt.IsZero() {
+		return Resource{}, ErrAlreadyUnavailable
+	}
+
+	now := time.Now()
+
+	res.UnavailableAt = now
+	res.UnavailableUserID = userID
+	res.UpdatedAtDate = now
+
+	sc := StatusChange{
+		ID:         uuid.New(),
+		ResourceID: res.ID,
+		Status:     Statuses.Unavailable,
+		UserID:     userID,
+		ChangedAt:  now,
+	}
+
+	if err := b.storer.UpdateStatus(ctx, res, sc); err != nil {
+		return Resource{}, fmt.Errorf("updatestatus: %w", err)
+	}
+
+	b.notify(ctx, ActionDespawned, res)
+
+	return res, nil
+}
+
+// Reactivate puts a despawned resource back in spawn. The userID identifies
+// the acting user and is recorded in the status history.
+func (b *Business) Reactivate(ctx context.Context, res Resource, userID uuid.UUID) (Resource, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.reactivate")
+	defer span.End()
+
+	if res.UnavailableAt.IsZero() {
+		return Resource{}, ErrAlreadyAvailable
+	}
+
+	now := time.Now()
+
+	res.UnavailableAt = time.Time{}
+	res.UnavailableUserID = uuid.Nil
+	res.UpdatedAtDate = now
+
+	sc := StatusChange{
+		ID:         uuid.New(),
+		ResourceID: res.ID,
+		Status:     Statuses.Available,
+		UserID:     userID,
+		ChangedAt:  now,
+	}
+
+	if err := b.storer.UpdateStatus(ctx, res, sc); err != nil {
+		return Resource{}, fmt.Errorf("updatestatus: %w", err)
+	}
+
+	return res, nil
+}
+
+// QueryStatusHistory retrieves the status changes of a resource, oldest
+// first.
+func (b *Business) QueryStatusHistory(ctx context.Context, resourceID uuid.UUID) ([]StatusChange, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.querystatushistory")
+	defer span.End()
+
+	history, err := b.storer.QueryStatusHistory(ctx, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("querystatushistory: resourceID[%s]: %w", resourceID, err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,20 @@
+package resourcebus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var actionCount = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "harvester",
+	Subsystem: "resource",
+	Name:      "actions_total",
+	Help:      "Number of resources created, verified and despawned, by action and galaxy.",
+}, []string{"action", "galaxy_id"})
+
+// countAction adds the resources the action was committed on to the metrics.
+func countAction(action string, resources ...Resource) {
+	for _, res := range resources {
+		actionCount.WithLabelValues(action, res.GalaxyID.String()).Inc()
+	}
+}
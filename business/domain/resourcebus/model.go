@@ -30,6 +30,30 @@ type Resource struct {
 	SR                int16
 	UT                int16
 	ER                int16
+	Planets           []int16
+}
+
+// SameStats reports whether the resource has the same stats as the other.
+// Confirmations and disputes are cast on the stats, so they lapse once the
+// stats change.
+func (r Resource) SameStats(other Resource) bool {
+	return r.stats() == other.stats()
+}
+
+// unverifyChangedStats clears the verification of the resource when its stats
+// differ from before, the store removes the lapsed votes along with it.
+func (r *Resource) unverifyChangedStats(before Resource) {
+	if r.SameStats(before) {
+		return
+	}
+
+	r.Verified = false
+	r.VerifiedUserID = uuid.Nil
+}
+
+// stats returns the stats of the resource in a fixed order.
+func (r Resource) stats() [11]int16 {
+	return [11]int16{r.CR, r.CD, r.DR, r.FL, r.HR, r.MA, r.PE, r.OQ, r.SR, r.UT, r.ER}
 }
 
 // NewResource contains information needed to create a new resource.
@@ -49,26 +73,28 @@ type NewResource struct {
 	SR           int16
 	UT           int16
 	ER           int16
+	Planets      []int16
 }
 
 // UpdateResource contains information needed to update a resource.
+// Availability is changed through Despawn and Reactivate, and verification
+// through Confirm and Dispute, though changing a stat clears the votes and
+// unverifies the resource. A nil Planets leaves the planets the resource
+// is available on unchanged.
 type UpdateResource struct {
-	Name              *Name
-	UnavailableAt     *time.Time
-	UnavailableUserID *uuid.UUID
-	Verified          *bool
-	VerifiedUserID    *uuid.UUID
-	CR                *int16
-	CD                *int16
-	DR                *int16
-	FL                *int16
-	HR                *int16
-	MA                *int16
-	PE                *int16
-	OQ                *int16
-	SR                *int16
-	UT                *int16
-	ER                *int16
+	Name    *Name
+	CR      *int16
+	CD      *int16
+	DR      *int16
+	FL      *int16
+	HR      *int16
+	MA      *int16
+	PE      *int16
+	OQ      *int16
+	SR      *int16
+	UT      *int16
+	ER      *int16
+	Planets []int16
 }
 
 // UpdateResourceWithID contains an ID and update data for bulk update operations.
@@ -76,3 +102,36 @@ type UpdateResourceWithID struct {
 	ID   uuid.UUID
 	Data UpdateResource
 }
+
+// StatusChange records a resource moving in or out of spawn, who made the
+// change and when.
+type StatusChange struct {
+	ID         uuid.UUID
+	ResourceID uuid.UUID
+	Status     Status
+	UserID     uuid.UUID
+	ChangedAt  time.Time
+}
+
+// Verification records a user confirming or disputing the stats of a
+// resource. A user has at most one vote per resource.
+type Verification struct {
+	ResourceID  uuid.UUID
+	UserID      uuid.UUID
+	Vote        Vote
+	DateCreated time.Time
+}
+
+// VerificationTally counts the votes cast on a resource against the
+// verification threshold of its galaxy.
+type VerificationTally struct {
+	Confirmations int
+	Disputes      int
+	Threshold     int16
+}
+
+// Verified reports whether the confirmations exceed the disputes by at least
+// the threshold.
+func (vt VerificationTally) Verified() bool {
+	return vt.Confirmations-vt.Disputes >= int(vt.Threshold)
+}
@@ -0,0 +1,29 @@
+package resourcebus
+
+import "testing"
+
+func TestSameStats(t *testing.T) {
+	base := Resource{Name: Names.MustParse("Abcdef"), CR: 200, OQ: 900, Verified: true}
+
+	tests := []struct {
+		name   string
+		change func(*Resource)
+		want   bool
+	}{
+		{"unchanged", func(*Resource) {}, true},
+		{"other fields", func(r *Resource) { r.Name = Names.MustParse("Ghijkl"); r.Verified = false; r.Planets = []int16{1} }, true},
+		{"stat", func(r *Resource) { r.OQ = 901 }, false},
+		{"stat from zero", func(r *Resource) { r.ER = 1 }, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := base
+			tt.change(&other)
+
+			if got := base.SameStats(other); got != tt.want {
+				t.Errorf("SameStats() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
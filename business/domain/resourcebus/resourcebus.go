@@ -6,8 +6,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/godwinrob/harvester/business/domain/galaxybus"
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+	"github.com/godwinrob/harvester/business/sdk/delegate"
 	"github.com/godwinrob/harvester/business/sdk/order"
 	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/godwinrob/harvester/foundation/validate"
 
 	"github.com/google/uuid"
 )
@@ -17,6 +23,14 @@ var (
 	ErrNotFound              = errors.New("resource not found")
 	ErrUniqueName            = errors.New("resource name is not unique")
 	ErrAuthenticationFailure = errors.New("authentication failed")
+	ErrUnknownResourceType   = errors.New("resource type does not exist")
+	ErrNotEnterable          = errors.New("resource type can not be entered")
+	ErrAlreadyUnavailable    = errors.New("resource is already unavailable")
+	ErrAlreadyAvailable      = errors.New("resource is already available")
+	ErrStatusConflict        = errors.New("resource status was changed by another request")
+	ErrSelfVerification      = errors.New("resource can not be confirmed by the user who added it")
+	ErrWeightOutOfRange      = errors.New("weights must be between 0 and 100")
+	ErrWeightTotalOutOfRange = errors.New("weights must add up to between 1 and 100")
 )
 
 // Storer interface declares the behavior this package needs to perists and
@@ -26,30 +40,52 @@ type Storer interface {
 	Update(ctx context.Context, res Resource) error
 	Delete(ctx context.Context, res Resource) error
 	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Resource, error)
+	QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Resource, order.Cursor, error)
+	QueryEach(ctx context.Context, filter QueryFilter, orderBy order.By, fn func(Resource) error) error
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, resourceID uuid.UUID) (Resource, error)
-	QueryByName(ctx context.Context, resourceName string) (Resource, error)
+	QueryByName(ctx context.Context, galaxyID uuid.UUID, resourceName string) (Resource, error)
+	QueryNames(ctx context.Context, galaxyID uuid.UUID, names []string) ([]string, error)
+	QueryBest(ctx context.Context, filter QueryFilter, weights Weights, limit int) ([]ScoredResource, error)
+	UpdateStatus(ctx context.Context, res Resource, sc StatusChange) error
+	QueryStatusHistory(ctx context.Context, resourceID uuid.UUID) ([]StatusChange, error)
+	RecordVerification(ctx context.Context, v Verification, fn func(Resource, VerificationTally) Resource) (Resource, VerificationTally, error)
+	QueryVerifications(ctx context.Context, resourceID uuid.UUID) ([]Verification, error)
 	BulkCreate(ctx context.Context, resources []Resource) error
 	BulkUpdate(ctx context.Context, resources []Resource) error
 	BulkDelete(ctx context.Context, ids []uuid.UUID) error
+	ListenChanges(ctx context.Context, fn func(Change)) error
 }
 
 // Business manages the set of APIs for resource access.
 type Business struct {
-	log    *logger.Logger
-	storer Storer
+	log             *logger.Logger
+	delegate        *delegate.Delegate
+	galaxyBus       *galaxybus.Business
+	resourceTypeBus *resourcetypebus.Business
+	planetBus       *planetbus.Business
+	storer          Storer
+	stream          *stream
 }
 
 // NewBusiness constructs a resource business API for use.
-func NewBusiness(log *logger.Logger, storer Storer) *Business {
+func NewBusiness(log *logger.Logger, delegate *delegate.Delegate, galaxyBus *galaxybus.Business, resourceTypeBus *resourcetypebus.Business, planetBus *planetbus.Business, storer Storer) *Business {
 	return &Business{
-		log:    log,
-		storer: storer,
+		log:             log,
+		delegate:        delegate,
+		galaxyBus:       galaxyBus,
+		resourceTypeBus: resourceTypeBus,
+		planetBus:       planetBus,
+		storer:          storer,
+		stream:          newStream(),
 	}
 }
 
 // Create adds a new resource to the system.
 func (b *Business) Create(ctx context.Context, nu NewResource) (Resource, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.create")
+	defer span.End()
+
 	now := time.Now()
 
 	res := Resource{
@@ -71,35 +107,31 @@ func (b *Business) Create(ctx context.Context, nu NewResource) (Resource, error)
 		SR:            nu.SR,
 		UT:            nu.UT,
 		ER:            nu.ER,
+		Planets:       nu.Planets,
+	}
+
+	if err := b.checkResource(ctx, &res, newLookups()); err != nil {
+		return Resource{}, fmt.Errorf("validate: %w", err)
 	}
 
 	if err := b.storer.Create(ctx, res); err != nil {
 		return Resource{}, fmt.Errorf("create: %w", err)
 	}
 
+	b.notify(ctx, ActionCreated, res)
+
 	return res, nil
 }
 
 // Update modifies information about a resource.
 func (b *Business) Update(ctx context.Context, res Resource, uu UpdateResource) (Resource, error) {
-	if uu.Name != nil {
-		res.Name = *uu.Name
-	}
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.update")
+	defer span.End()
 
-	if uu.UnavailableAt != nil {
-		res.UnavailableAt = *uu.UnavailableAt
-	}
+	before := res
 
-	if uu.UnavailableUserID != nil {
-		res.UnavailableUserID = *uu.UnavailableUserID
-	}
-
-	if uu.Verified != nil {
-		res.Verified = *uu.Verified
-	}
-
-	if uu.VerifiedUserID != nil {
-		res.VerifiedUserID = *uu.VerifiedUserID
+	if uu.Name != nil {
+		res.Name = *uu.Name
 	}
 
 	if uu.CR != nil {
@@ -146,7 +178,16 @@ func (b *Business) Update(ctx context.Context, res Resource, uu UpdateResource)
 		res.ER = *uu.ER
 	}
 
-	res.UnavailableAt = time.Now()
+	if uu.Planets != nil {
+		res.Planets = uu.Planets
+	}
+
+	res.UpdatedAtDate = time.Now()
+	res.unverifyChangedStats(before)
+
+	if err := b.checkResource(ctx, &res, newLookups()); err != nil {
+		return Resource{}, fmt.Errorf("validate: %w", err)
+	}
 
 	if err := b.storer.Update(ctx, res); err != nil {
 		return Resource{}, fmt.Errorf("update: %w", err)
@@ -157,6 +198,9 @@ func (b *Business) Update(ctx context.Context, res Resource, uu UpdateResource)
 
 // Delete removes the specified resource.
 func (b *Business) Delete(ctx context.Context, res Resource) error {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.delete")
+	defer span.End()
+
 	if err := b.storer.Delete(ctx, res); err != nil {
 		return fmt.Errorf("delete: %w", err)
 	}
@@ -166,7 +210,8 @@ func (b *Business) Delete(ctx context.Context, res Resource) error {
 
 // Query retrieves a list of existing resources.
 func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Resource, error) {
-	// TODO: DON'T ALLOW MORE THAN N RECORDS REGARDLESS
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.query")
+	defer span.End()
 
 	resources, err := b.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
 	if err != nil {
@@ -176,13 +221,47 @@ func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.
 	return resources, nil
 }
 
+// QueryAfter retrieves the page of resources that follows the cursor in the
+// order, and the cursor for the page after it. The zero cursor starts at the
+// first resource.
+func (b *Business) QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Resource, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.queryafter")
+	defer span.End()
+
+	resources, next, err := b.storer.QueryAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryafter: %w", err)
+	}
+
+	return resources, next, nil
+}
+
+// QueryEach calls fn with every resource matching the filter, in order,
+// without paging. It stops at the first error fn returns.
+func (b *Business) QueryEach(ctx context.Context, filter QueryFilter, orderBy order.By, fn func(Resource) error) error {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.queryeach")
+	defer span.End()
+
+	if err := b.storer.QueryEach(ctx, filter, orderBy, fn); err != nil {
+		return fmt.Errorf("queryeach: %w", err)
+	}
+
+	return nil
+}
+
 // Count returns the total number of resources.
 func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.count")
+	defer span.End()
+
 	return b.storer.Count(ctx, filter)
 }
 
 // QueryByID finds the resource by the specified Ib.
 func (b *Business) QueryByID(ctx context.Context, resourceID uuid.UUID) (Resource, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.querybyid")
+	defer span.End()
+
 	resource, err := b.storer.QueryByID(ctx, resourceID)
 	if err != nil {
 		return Resource{}, fmt.Errorf("query: resourceID[%s]: %w", resourceID, err)
@@ -191,19 +270,46 @@ func (b *Business) QueryByID(ctx context.Context, resourceID uuid.UUID) (Resourc
 	return resource, nil
 }
 
-// QueryByName finds the resource by a specified resource email.
-func (b *Business) QueryByName(ctx context.Context, name string) (Resource, error) {
-	resource, err := b.storer.QueryByName(ctx, name)
+// QueryByName finds the resource by its name within the specified galaxy.
+// Resource names are only unique per galaxy.
+func (b *Business) QueryByName(ctx context.Context, galaxyID uuid.UUID, name string) (Resource, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.querybyname")
+	defer span.End()
+
+	resource, err := b.storer.QueryByName(ctx, galaxyID, name)
 	if err != nil {
-		return Resource{}, fmt.Errorf("query: name[%s]: %w", name, err)
+		return Resource{}, fmt.Errorf("query: galaxyID[%s] name[%s]: %w", galaxyID, name, err)
 	}
 
 	return resource, nil
 }
 
+// QueryNames returns the names, out of those specified, that resources of the
+// galaxy already have, for checking many names at once.
+func (b *Business) QueryNames(ctx context.Context, galaxyID uuid.UUID, names []string) ([]string, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.querynames")
+	defer span.End()
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	taken, err := b.storer.QueryNames(ctx, galaxyID, names)
+	if err != nil {
+		return nil, fmt.Errorf("query: galaxyID[%s]: %w", galaxyID, err)
+	}
+
+	return taken, nil
+}
+
 // BulkCreate adds multiple new resources to the system in a single transaction.
 func (b *Business) BulkCreate(ctx context.Context, newResources []NewResource) ([]Resource, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.bulkcreate")
+	defer span.End()
+
 	resources := make([]Resource, len(newResources))
+	lu := newLookups()
+	var itemErrs ItemErrors
 	now := time.Now()
 
 	for i, nr := range newResources {
@@ -226,41 +332,49 @@ func (b *Business) BulkCreate(ctx context.Context, newResources []NewResource) (
 			SR:            nr.SR,
 			UT:            nr.UT,
 			ER:            nr.ER,
+			Planets:       nr.Planets,
+		}
+
+		if err := b.checkResource(ctx, &resources[i], lu); err != nil {
+			if !validate.IsFieldErrors(err) {
+				return nil, fmt.Errorf("validate[%d]: %w", i, err)
+			}
+			itemErrs = append(itemErrs, ItemError{Index: i, Err: err})
 		}
 	}
 
+	if len(itemErrs) > 0 {
+		return nil, fmt.Errorf("validate: %w", itemErrs)
+	}
+
 	if err := b.storer.BulkCreate(ctx, resources); err != nil {
 		return nil, fmt.Errorf("bulkcreate: %w", err)
 	}
 
+	b.notify(ctx, ActionCreated, resources...)
+
 	return resources, nil
 }
 
 // BulkUpdate modifies multiple resources in a single transaction.
 func (b *Business) BulkUpdate(ctx context.Context, updates []UpdateResourceWithID) ([]Resource, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.bulkupdate")
+	defer span.End()
+
 	resources := make([]Resource, len(updates))
+	lu := newLookups()
+	var itemErrs ItemErrors
 
 	for i, upd := range updates {
 		res, err := b.storer.QueryByID(ctx, upd.ID)
 		if err != nil {
 			return nil, fmt.Errorf("querybyid[%d]: %w", i, err)
 		}
+		before := res
 
 		if upd.Data.Name != nil {
 			res.Name = *upd.Data.Name
 		}
-		if upd.Data.UnavailableAt != nil {
-			res.UnavailableAt = *upd.Data.UnavailableAt
-		}
-		if upd.Data.UnavailableUserID != nil {
-			res.UnavailableUserID = *upd.Data.UnavailableUserID
-		}
-		if upd.Data.Verified != nil {
-			res.Verified = *upd.Data.Verified
-		}
-		if upd.Data.VerifiedUserID != nil {
-			res.VerifiedUserID = *upd.Data.VerifiedUserID
-		}
 		if upd.Data.CR != nil {
 			res.CR = *upd.Data.CR
 		}
@@ -294,11 +408,26 @@ func (b *Business) BulkUpdate(ctx context.Context, updates []UpdateResourceWithI
 		if upd.Data.ER != nil {
 			res.ER = *upd.Data.ER
 		}
+		if upd.Data.Planets != nil {
+			res.Planets = upd.Data.Planets
+		}
 		res.UpdatedAtDate = time.Now()
+		res.unverifyChangedStats(before)
+
+		if err := b.checkResource(ctx, &res, lu); err != nil {
+			if !validate.IsFieldErrors(err) {
+				return nil, fmt.Errorf("validate[%d]: %w", i, err)
+			}
+			itemErrs = append(itemErrs, ItemError{Index: i, Err: err})
+		}
 
 		resources[i] = res
 	}
 
+	if len(itemErrs) > 0 {
+		return nil, fmt.Errorf("validate: %w", itemErrs)
+	}
+
 	if err := b.storer.BulkUpdate(ctx, resources); err != nil {
 		return nil, fmt.Errorf("bulkupdate: %w", err)
 	}
@@ -308,6 +437,9 @@ func (b *Business) BulkUpdate(ctx context.Context, updates []UpdateResourceWithI
 
 // BulkDelete removes multiple resources in a single transaction.
 func (b *Business) BulkDelete(ctx context.Context, ids []uuid.UUID) error {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.bulkdelete")
+	defer span.End()
+
 	if err := b.storer.BulkDelete(ctx, ids); err != nil {
 		return fmt.Errorf("bulkdelete: %w", err)
 	}
@@ -0,0 +1,181 @@
+package resourcebus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/godwinrob/harvester/business/domain/planetbus"
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+	"github.com/godwinrob/harvester/foundation/validate"
+)
+
+// statRange pairs a resource stat with the range its resource type allows.
+type statRange struct {
+	field string
+	value int16
+	min   int16
+	max   int16
+}
+
+// validateStats checks the resource stats against the ranges allowed by its
+// resource type. A stat the type does not carry must be zero.
+func validateStats(rt resourcetypebus.ResourceType, res Resource) validate.FieldErrors {
+	stats := []statRange{
+		{field: "cr", value: res.CR, min: rt.CRmin, max: rt.CRmax},
+		{field: "cd", value: res.CD, min: rt.CDmin, max: rt.CDmax},
+		{field: "dr", value: res.DR, min: rt.DRmin, max: rt.DRmax},
+		{field: "fl", value: res.FL, min: rt.FLmin, max: rt.FLmax},
+		{field: "hr", value: res.HR, min: rt.HRmin, max: rt.HRmax},
+		{field: "ma", value: res.MA, min: rt.MAmin, max: rt.MAmax},
+		{field: "pe", value: res.PE, min: rt.PEmin, max: rt.PEmax},
+		{field: "oq", value: res.OQ, min: rt.OQmin, max: rt.OQmax},
+		{field: "sr", value: res.SR, min: rt.SRmin, max: rt.SRmax},
+		{field: "ut", value: res.UT, min: rt.UTmin, max: rt.UTmax},
+		{field: "er", value: res.ER, min: rt.ERmin, max: rt.ERmax},
+	}
+
+	var fields validate.FieldErrors
+	for _, s := range stats {
+		switch {
+		case s.min == 0 && s.max == 0:
+			if s.value != 0 {
+				fields = append(fields, validate.FieldError{
+					Field: s.field,
+					Err:   fmt.Sprintf("%s is not applicable to resource type %s", s.field, rt.ResourceType),
+				})
+			}
+
+		case s.value < s.min || s.value > s.max:
+			fields = append(fields, validate.FieldError{
+				Field: s.field,
+				Err:   fmt.Sprintf("%s must be between %d and %d for resource type %s", s.field, s.min, s.max, rt.ResourceType),
+			})
+		}
+	}
+
+	return fields
+}
+
+// lookups caches the resource types and planets read while validating the
+// items of a bulk operation.
+type lookups struct {
+	types   map[string]resourcetypebus.ResourceType
+	planets map[int16]bool
+}
+
+func newLookups() *lookups {
+	return &lookups{
+		types:   make(map[string]resourcetypebus.ResourceType),
+		planets: make(map[int16]bool),
+	}
+}
+
+// checkResource looks up the resource type of the resource and validates the
+// resource's stats and planets against it. Resources of a planet-locked type
+// that name no planets are placed on the type's planet. Every failing field is
+// reported in the returned validate.FieldErrors.
+func (b *Business) checkResource(ctx context.Context, res *Resource, lu *lookups) error {
+	rt, exists := lu.types[res.ResourceType]
+	if !exists {
+		var err error
+		rt, err = b.resourceTypeBus.QueryByID(ctx, res.ResourceType)
+		if err != nil {
+			if errors.Is(err, resourcetypebus.ErrNotFound) {
+				return validate.NewFieldsError("resourceType", ErrUnknownResourceType)
+			}
+			return fmt.Errorf("querybyid: resourceType[%s]: %w", res.ResourceType, err)
+		}
+
+		lu.types[res.ResourceType] = rt
+	}
+
+	if !rt.Enterable {
+		return validate.NewFieldsError("resourceType", ErrNotEnterable)
+	}
+
+	if len(res.Planets) == 0 && rt.SpecificPlanet != 0 {
+		res.Planets = []int16{rt.SpecificPlanet}
+	}
+	res.Planets = uniquePlanets(res.Planets)
+
+	fields := validateStats(rt, *res)
+
+	for _, planetID := range res.Planets {
+		if rt.SpecificPlanet != 0 && planetID != rt.SpecificPlanet {
+			fields = append(fields, validate.FieldError{
+				Field: "planets",
+				Err:   fmt.Sprintf("resource type %s is only available on planet %d", rt.ResourceType, rt.SpecificPlanet),
+			})
+			continue
+		}
+
+		found, err := b.planetExists(ctx, planetID, lu)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			fields = append(fields, validate.FieldError{
+				Field: "planets",
+				Err:   fmt.Sprintf("planet %d does not exist", planetID),
+			})
+		}
+	}
+
+	if len(fields) > 0 {
+		return fields
+	}
+
+	return nil
+}
+
+func (b *Business) planetExists(ctx context.Context, planetID int16, lu *lookups) (bool, error) {
+	if found, exists := lu.planets[planetID]; exists {
+		return found, nil
+	}
+
+	if _, err := b.planetBus.QueryByID(ctx, planetID); err != nil {
+		if !errors.Is(err, planetbus.ErrNotFound) {
+			return false, fmt.Errorf("querybyid: planetID[%d]: %w", planetID, err)
+		}
+		lu.planets[planetID] = false
+		return false, nil
+	}
+
+	lu.planets[planetID] = true
+	return true, nil
+}
+
+// uniquePlanets returns the planet ids sorted and without duplicates.
+func uniquePlanets(planets []int16) []int16 {
+	if planets == nil {
+		return nil
+	}
+
+	ids := slices.Clone(planets)
+	slices.Sort(ids)
+
+	return slices.Compact(ids)
+}
+
+// ItemError reports the validation failure of a single item in a bulk
+// operation.
+type ItemError struct {
+	Index int
+	Err   error
+}
+
+// ItemErrors represents the validation failures of a bulk operation.
+type ItemErrors []ItemError
+
+// Error implements the error interface.
+func (ie ItemErrors) Error() string {
+	msgs := make([]string, len(ie))
+	for i, e := range ie {
+		msgs[i] = fmt.Sprintf("item[%d]: %s", e.Index, e.Err)
+	}
+	return strings.Join(msgs, "; ")
+}
@@ -0,0 +1,84 @@
+package resourcebus
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+)
+
+func TestValidateStats(t *testing.T) {
+	// The type carries cr, oq and sr only.
+	rt := resourcetypebus.ResourceType{
+		ResourceType: "aluminum_agrinium",
+		CRmin:        94,
+		CRmax:        307,
+		OQmin:        1,
+		OQmax:        1000,
+		SRmin:        500,
+		SRmax:        500,
+	}
+
+	tests := []struct {
+		name       string
+		res        Resource
+		wantFields []string
+	}{
+		{"within ranges", Resource{CR: 200, OQ: 900, SR: 500}, nil},
+		{"at the bounds", Resource{CR: 94, OQ: 1000, SR: 500}, nil},
+		{"below min", Resource{CR: 93, OQ: 900, SR: 500}, []string{"cr"}},
+		{"above max", Resource{CR: 308, OQ: 900, SR: 500}, []string{"cr"}},
+		{"missing stat the type carries", Resource{CR: 200, SR: 500}, []string{"oq"}},
+		{"stat the type does not carry", Resource{CR: 200, OQ: 900, SR: 500, ER: 1}, []string{"er"}},
+		{"every failing stat", Resource{CR: 1, OQ: 1001, SR: 499, CD: 5, UT: 5}, []string{"cr", "cd", "oq", "sr", "ut"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fe := validateStats(rt, tt.res)
+
+			var got []string
+			for _, f := range fe {
+				got = append(got, f.Field)
+			}
+
+			if !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("validateStats() failed %v, want %v: %v", got, tt.wantFields, fe)
+			}
+		})
+	}
+}
+
+func TestUniquePlanets(t *testing.T) {
+	tests := []struct {
+		name    string
+		planets []int16
+		want    []int16
+	}{
+		{"nil", nil, nil},
+		{"empty", []int16{}, []int16{}},
+		{"sorted", []int16{1, 2, 3}, []int16{1, 2, 3}},
+		{"unsorted with duplicates", []int16{3, 1, 3, 2, 1}, []int16{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := uniquePlanets(tt.planets); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("uniquePlanets(%v) = %v, want %v", tt.planets, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestItemErrors(t *testing.T) {
+	ie := ItemErrors{
+		{Index: 0, Err: errors.New("cr must be between 94 and 307")},
+		{Index: 3, Err: ErrUnknownResourceType},
+	}
+
+	want := "item[0]: cr must be between 94 and 307; item[3]: " + ErrUnknownResourceType.Error()
+	if got := ie.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
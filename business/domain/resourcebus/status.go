@@ -0,0 +1,50 @@
+package resourcebus
+
+import "fmt"
+
+type statusSet struct {
+	Available   Status
+	Unavailable Status
+}
+
+// Statuses represents the set of spawn statuses a resource can be in.
+var Statuses = statusSet{
+	Available:   newStatus("AVAILABLE"),
+	Unavailable: newStatus("UNAVAILABLE"),
+}
+
+// Parse parses the string value and returns a status if one exists.
+func (statusSet) Parse(value string) (Status, error) {
+	status, exists := statuses[value]
+	if !exists {
+		return Status{}, fmt.Errorf("invalid status %q", value)
+	}
+
+	return status, nil
+}
+
+// =============================================================================
+
+// Set of known statuses.
+var statuses = make(map[string]Status)
+
+// Status represents the spawn status of a resource.
+type Status struct {
+	name string
+}
+
+func newStatus(status string) Status {
+	s := Status{status}
+	statuses[status] = s
+	return s
+}
+
+// String returns the name of the status.
+func (s Status) String() string {
+	return s.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (s Status) Equal(s2 Status) bool {
+	return s.name == s2.name
+}
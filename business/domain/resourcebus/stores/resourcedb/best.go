@@ -0,0 +1,92 @@
+package resourcedb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+)
+
+type scoredResource struct {
+	resource
+	Score float64 `db:"score"`
+}
+
+// scoreExpr computes the weighted score of a resource from the :w_<stat>
+// weights and the :w_total of the weights. Each stat is normalised against
+// the min/max caps of the resource's type and clamped to [0, 1].
+var scoreExpr = func() string {
+	stats := []string{"cr", "cd", "dr", "fl", "hr", "ma", "pe", "oq", "sr", "ut", "er"}
+
+	terms := make([]string, len(stats))
+	for i, s := range stats {
+		terms[i] = fmt.Sprintf(`:w_%[1]s * CASE
+			WHEN rt.%[1]s_max = 0 THEN 0
+			WHEN rt.%[1]s_max = rt.%[1]s_min THEN 1
+			ELSE LEAST(GREATEST(CAST(r.%[1]s - rt.%[1]s_min AS DOUBLE PRECISION) / (rt.%[1]s_max - rt.%[1]s_min), 0), 1)
+		END`, s)
+	}
+
+	return "CAST(ROUND(CAST((" + strings.Join(terms, " +\n\t\t") + ") * 1000 / :w_total AS NUMERIC), 1) AS DOUBLE PRECISION)"
+}()
+
+// QueryBest retrieves the resources matching the filter ranked by their
+// weighted score for the weights.
+func (s *Store) QueryBest(ctx context.Context, filter resourcebus.QueryFilter, weights resourcebus.Weights, limit int) ([]resourcebus.ScoredResource, error) {
+	data := map[string]any{
+		"rows":    limit,
+		"w_total": weights.Total(),
+		"w_cr":    weights.CR,
+		"w_cd":    weights.CD,
+		"w_dr":    weights.DR,
+		"w_fl":    weights.FL,
+		"w_hr":    weights.HR,
+		"w_ma":    weights.MA,
+		"w_pe":    weights.PE,
+		"w_oq":    weights.OQ,
+		"w_sr":    weights.SR,
+		"w_ut":    weights.UT,
+		"w_er":    weights.ER,
+	}
+
+	q := `
+	SELECT
+		*
+	FROM (
+		SELECT
+			r.resource_id, r.resource_name, r.galaxy_id, r.added_at, r.updated_at, r.added_user_id, r.resource_type, r.unavailable_at, r.unavailable_user_id, r.verified, r.verified_user_id, r.cr, r.cd, r.dr, r.fl, r.hr, r.ma, r.pe, r.oq, r.sr, r.ut, r.er,
+			ARRAY(SELECT planet_id FROM resource_planets rp WHERE rp.resource_id = r.resource_id ORDER BY planet_id) AS planets,
+			` + scoreExpr + ` AS score
+		FROM
+			resources r
+		JOIN
+			resource_types rt ON rt.resource_type = r.resource_type
+	) AS resources`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+	buf.WriteString(" ORDER BY score DESC, added_at DESC FETCH FIRST :rows ROWS ONLY")
+
+	var dbRes []scoredResource
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbRes); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	bus := make([]resourcebus.ScoredResource, len(dbRes))
+	for i, db := range dbRes {
+		res, err := toBusResource(db.resource)
+		if err != nil {
+			return nil, err
+		}
+
+		bus[i] = resourcebus.ScoredResource{
+			Resource: res,
+			Score:    db.Score,
+		}
+	}
+
+	return bus, nil
+}
@@ -0,0 +1,65 @@
+package resourcedb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// changeChannel is the notification channel resource changes are published
+// on, so every instance of the service sees the changes made by the others.
+const changeChannel = "resource_changes"
+
+type change struct {
+	Action     string    `json:"action"`
+	ResourceID uuid.UUID `json:"resourceID"`
+	GalaxyID   uuid.UUID `json:"galaxyID"`
+}
+
+// notifyChange publishes the change to a resource as part of the transaction
+// that makes it.
+func (s *Store) notifyChange(ctx context.Context, tx *sqlx.Tx, action string, res resourcebus.Resource) error {
+	payload, err := json.Marshal(change{
+		Action:     action,
+		ResourceID: res.ID,
+		GalaxyID:   res.GalaxyID,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+
+	if err := sqldb.NotifyWithTx(ctx, s.log, tx, changeChannel, string(payload)); err != nil {
+		return fmt.Errorf("notify: %w", err)
+	}
+
+	return nil
+}
+
+// ListenChanges calls fn with every committed resource change until the
+// context is canceled or the connection fails.
+func (s *Store) ListenChanges(ctx context.Context, fn func(resourcebus.Change)) error {
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("listen changes requires *sqlx.DB")
+	}
+
+	return sqldb.Listen(ctx, db, changeChannel, func(payload string) {
+		var c change
+		if err := json.Unmarshal([]byte(payload), &c); err != nil {
+			s.log.Error(ctx, "resourcedb: listen changes", "payload", payload, "ERROR", err)
+			return
+		}
+
+		fn(resourcebus.Change{
+			Action:     c.Action,
+			ResourceID: c.ResourceID,
+			GalaxyID:   c.GalaxyID,
+		})
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb/dbarray"
 )
 
 func applyFilter(filter resourcebus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
@@ -16,6 +17,15 @@ func applyFilter(filter resourcebus.QueryFilter, data map[string]interface{}, bu
 		wc = append(wc, "resource_id = :resource_id")
 	}
 
+	if filter.IDs != nil {
+		ids := make(dbarray.String, len(filter.IDs))
+		for i, id := range filter.IDs {
+			ids[i] = id.String()
+		}
+		data["resource_ids"] = ids
+		wc = append(wc, "resource_id = ANY(CAST(:resource_ids AS UUID[]))")
+	}
+
 	if filter.GalaxyID != nil {
 		data["galaxy_id"] = *filter.GalaxyID
 		wc = append(wc, "galaxy_id = :galaxy_id")
@@ -28,12 +38,12 @@ func applyFilter(filter resourcebus.QueryFilter, data map[string]interface{}, bu
 
 	if filter.StartCreatedDate != nil {
 		data["start_date_created"] = filter.StartCreatedDate.UTC()
-		wc = append(wc, "updated_at >= :start_date_created")
+		wc = append(wc, "added_at >= :start_date_created")
 	}
 
 	if filter.EndCreatedDate != nil {
 		data["end_date_created"] = filter.EndCreatedDate.UTC()
-		wc = append(wc, "updated_at <= :end_date_created")
+		wc = append(wc, "added_at <= :end_date_created")
 	}
 
 	if filter.Verified != nil {
@@ -41,6 +51,14 @@ func applyFilter(filter resourcebus.QueryFilter, data map[string]interface{}, bu
 		wc = append(wc, "verified = :verified")
 	}
 
+	if filter.Available != nil {
+		if *filter.Available {
+			wc = append(wc, "unavailable_at IS NULL")
+		} else {
+			wc = append(wc, "unavailable_at IS NOT NULL")
+		}
+	}
+
 	if filter.ResourceType != nil {
 		data["resource_type"] = *filter.ResourceType
 		wc = append(wc, "resource_type = :resource_type")
@@ -51,6 +69,11 @@ func applyFilter(filter resourcebus.QueryFilter, data map[string]interface{}, bu
 		wc = append(wc, "resource_type IN (SELECT resource_type FROM resource_type_groups WHERE resource_group = :resource_group)")
 	}
 
+	if filter.Planet != nil {
+		data["planet_id"] = *filter.Planet
+		wc = append(wc, "resource_id IN (SELECT resource_id FROM resource_planets WHERE planet_id = :planet_id)")
+	}
+
 	if filter.CR != nil {
 		data["cr"] = *filter.CR
 		wc = append(wc, "cr >= :cr")
@@ -106,6 +129,61 @@ func applyFilter(filter resourcebus.QueryFilter, data map[string]interface{}, bu
 		wc = append(wc, "er >= :er")
 	}
 
+	if filter.CRMax != nil {
+		data["cr_max"] = *filter.CRMax
+		wc = append(wc, "cr <= :cr_max")
+	}
+
+	if filter.CDMax != nil {
+		data["cd_max"] = *filter.CDMax
+		wc = append(wc, "cd <= :cd_max")
+	}
+
+	if filter.DRMax != nil {
+		data["dr_max"] = *filter.DRMax
+		wc = append(wc, "dr <= :dr_max")
+	}
+
+	if filter.FLMax != nil {
+		data["fl_max"] = *filter.FLMax
+		wc = append(wc, "fl <= :fl_max")
+	}
+
+	if filter.HRMax != nil {
+		data["hr_max"] = *filter.HRMax
+		wc = append(wc, "hr <= :hr_max")
+	}
+
+	if filter.MAMax != nil {
+		data["ma_max"] = *filter.MAMax
+		wc = append(wc, "ma <= :ma_max")
+	}
+
+	if filter.PEMax != nil {
+		data["pe_max"] = *filter.PEMax
+		wc = append(wc, "pe <= :pe_max")
+	}
+
+	if filter.OQMax != nil {
+		data["oq_max"] = *filter.OQMax
+		wc = append(wc, "oq <= :oq_max")
+	}
+
+	if filter.SRMax != nil {
+		data["sr_max"] = *filter.SRMax
+		wc = append(wc, "sr <= :sr_max")
+	}
+
+	if filter.UTMax != nil {
+		data["ut_max"] = *filter.UTMax
+		wc = append(wc, "ut <= :ut_max")
+	}
+
+	if filter.ERMax != nil {
+		data["er_max"] = *filter.ERMax
+		wc = append(wc, "er <= :er_max")
+	}
+
 	if len(wc) > 0 {
 		buf.WriteString(" WHERE ")
 		buf.WriteString(strings.Join(wc, " AND "))
@@ -3,35 +3,44 @@ package resourcedb
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/business/sdk/sqldb/dbarray"
 	"github.com/google/uuid"
 )
 
 type resource struct {
-	ID                uuid.UUID      `db:"resource_id"`
-	ResourceName      string         `db:"resource_name"`
-	GalaxyID          uuid.UUID      `db:"galaxy_id"`
-	AddedAtDate       time.Time      `db:"added_at"`
-	UpdatedAtDate     time.Time      `db:"updated_at"`
-	AddedUserID       uuid.UUID      `db:"added_user_id"`
-	ResourceType      string         `db:"resource_type"`
-	UnavailableAt     sql.NullTime   `db:"unavailable_at"`
-	UnavailableUserID uuid.NullUUID  `db:"unavailable_user_id"`
-	Verified          bool           `db:"verified"`
-	VerifiedUserID    uuid.NullUUID  `db:"verified_user_id"`
-	CR                int16          `db:"cr"`
-	CD                int16          `db:"cd"`
-	DR                int16          `db:"dr"`
-	FL                int16          `db:"fl"`
-	HR                int16          `db:"hr"`
-	MA                int16          `db:"ma"`
-	PE                int16          `db:"pe"`
-	OQ                int16          `db:"oq"`
-	SR                int16          `db:"sr"`
-	UT                int16          `db:"ut"`
-	ER                int16          `db:"er"`
+	ID                uuid.UUID     `db:"resource_id"`
+	ResourceName      string        `db:"resource_name"`
+	GalaxyID          uuid.UUID     `db:"galaxy_id"`
+	AddedAtDate       time.Time     `db:"added_at"`
+	UpdatedAtDate     time.Time     `db:"updated_at"`
+	AddedUserID       uuid.UUID     `db:"added_user_id"`
+	ResourceType      string        `db:"resource_type"`
+	UnavailableAt     sql.NullTime  `db:"unavailable_at"`
+	UnavailableUserID uuid.NullUUID `db:"unavailable_user_id"`
+	Verified          bool          `db:"verified"`
+	VerifiedUserID    uuid.NullUUID `db:"verified_user_id"`
+	CR                int16         `db:"cr"`
+	CD                int16         `db:"cd"`
+	DR                int16         `db:"dr"`
+	FL                int16         `db:"fl"`
+	HR                int16         `db:"hr"`
+	MA                int16         `db:"ma"`
+	PE                int16         `db:"pe"`
+	OQ                int16         `db:"oq"`
+	SR                int16         `db:"sr"`
+	UT                int16         `db:"ut"`
+	ER                int16         `db:"er"`
+	Planets           dbarray.Int32 `db:"planets"`
+}
+
+type keyedResource struct {
+	resource
+	sqldb.Keyed
 }
 
 func toDBResource(bus resourcebus.Resource) resource {
@@ -73,6 +82,7 @@ func toDBResource(bus resourcebus.Resource) resource {
 		SR:                bus.SR,
 		UT:                bus.UT,
 		ER:                bus.ER,
+		Planets:           toDBPlanets(bus.Planets),
 	}
 }
 
@@ -105,7 +115,7 @@ func toBusResource(db resource) (resourcebus.Resource, error) {
 		AddedAtDate:       db.AddedAtDate,
 		UpdatedAtDate:     db.UpdatedAtDate,
 		AddedUserID:       db.AddedUserID,
-		ResourceType:      db.ResourceType,
+		ResourceType:      strings.TrimRight(db.ResourceType, " "), // resource_type is a padded bpchar column.
 		UnavailableAt:     unavailableAt,
 		UnavailableUserID: unavailableUserID,
 		Verified:          db.Verified,
@@ -121,6 +131,7 @@ func toBusResource(db resource) (resourcebus.Resource, error) {
 		SR:                db.SR,
 		UT:                db.UT,
 		ER:                db.ER,
+		Planets:           toBusPlanets(db.Planets),
 	}
 
 	return bus, nil
@@ -139,3 +150,110 @@ func toBusResources(dbs []resource) ([]resourcebus.Resource, error) {
 
 	return bus, nil
 }
+
+func toDBPlanets(planets []int16) dbarray.Int32 {
+	db := make(dbarray.Int32, len(planets))
+	for i, p := range planets {
+		db[i] = int32(p)
+	}
+	return db
+}
+
+func toBusPlanets(db dbarray.Int32) []int16 {
+	planets := make([]int16, len(db))
+	for i, p := range db {
+		planets[i] = int16(p)
+	}
+	return planets
+}
+
+// =============================================================================
+
+type statusChange struct {
+	ID         uuid.UUID `db:"history_id"`
+	ResourceID uuid.UUID `db:"resource_id"`
+	Status     string    `db:"status"`
+	UserID     uuid.UUID `db:"user_id"`
+	ChangedAt  time.Time `db:"changed_at"`
+}
+
+func toDBStatusChange(bus resourcebus.StatusChange) statusChange {
+	return statusChange{
+		ID:         bus.ID,
+		ResourceID: bus.ResourceID,
+		Status:     bus.Status.String(),
+		UserID:     bus.UserID,
+		ChangedAt:  bus.ChangedAt.UTC(),
+	}
+}
+
+func toBusStatusChanges(dbs []statusChange) ([]resourcebus.StatusChange, error) {
+	bus := make([]resourcebus.StatusChange, len(dbs))
+
+	for i, db := range dbs {
+		status, err := resourcebus.Statuses.Parse(db.Status)
+		if err != nil {
+			return nil, fmt.Errorf("parse status: %w", err)
+		}
+
+		bus[i] = resourcebus.StatusChange{
+			ID:         db.ID,
+			ResourceID: db.ResourceID,
+			Status:     status,
+			UserID:     db.UserID,
+			ChangedAt:  db.ChangedAt.In(time.Local),
+		}
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+
+type verification struct {
+	ResourceID  uuid.UUID `db:"resource_id"`
+	UserID      uuid.UUID `db:"user_id"`
+	Vote        string    `db:"vote"`
+	DateCreated time.Time `db:"date_created"`
+}
+
+func toDBVerification(bus resourcebus.Verification) verification {
+	return verification{
+		ResourceID:  bus.ResourceID,
+		UserID:      bus.UserID,
+		Vote:        bus.Vote.String(),
+		DateCreated: bus.DateCreated.UTC(),
+	}
+}
+
+func toBusVerifications(dbs []verification) ([]resourcebus.Verification, error) {
+	bus := make([]resourcebus.Verification, len(dbs))
+
+	for i, db := range dbs {
+		vote, err := resourcebus.Votes.Parse(db.Vote)
+		if err != nil {
+			return nil, fmt.Errorf("parse vote: %w", err)
+		}
+
+		bus[i] = resourcebus.Verification{
+			ResourceID:  db.ResourceID,
+			UserID:      db.UserID,
+			Vote:        vote,
+			DateCreated: db.DateCreated.In(time.Local),
+		}
+	}
+
+	return bus, nil
+}
+
+type tally struct {
+	Confirmations int `db:"confirmations"`
+	Disputes      int `db:"disputes"`
+}
+
+func toBusTally(db tally) resourcebus.VerificationTally {
+	return resourcebus.VerificationTally{
+		Confirmations: db.Confirmations,
+		Disputes:      db.Disputes,
+	}
+}
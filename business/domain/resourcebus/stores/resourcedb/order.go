@@ -5,6 +5,7 @@ import (
 
 	"github.com/godwinrob/harvester/business/domain/resourcebus"
 	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
 )
 
 var orderByFields = map[string]string{
@@ -12,7 +13,7 @@ var orderByFields = map[string]string{
 	resourcebus.OrderByName:          "resource_name",
 	resourcebus.OrderByResourceType:  "resource_type",
 	resourcebus.OrderByVerified:      "verified",
-	resourcebus.OrderByUnavailableAt: "unavailable_at",
+	resourcebus.OrderByUnavailableAt: "COALESCE(unavailable_at, CAST('infinity' AS TIMESTAMP))",
 	resourcebus.OrderByAddedAt:       "added_at",
 	resourcebus.OrderByEnabled:       "enabled",
 	resourcebus.OrderByCR:            "cr",
@@ -36,3 +37,12 @@ func orderByClause(orderBy order.By) (string, error) {
 
 	return " ORDER BY " + by + " " + orderBy.Direction, nil
 }
+
+func keyset(orderBy order.By) (sqldb.Keyset, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return sqldb.Keyset{}, fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return sqldb.Keyset{Expr: by, ID: "resource_id", Direction: orderBy.Direction}, nil
+}
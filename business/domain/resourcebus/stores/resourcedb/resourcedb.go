@@ -14,17 +14,38 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// Enqueuer queues the webhook deliveries of resource events as part of the
+// transaction that changes the resources.
+type Enqueuer interface {
+	CreatedWithTx(ctx context.Context, tx *sqlx.Tx, resources ...resourcebus.Resource) error
+	VerifiedWithTx(ctx context.Context, tx *sqlx.Tx, resources ...resourcebus.Resource) error
+	DespawnedWithTx(ctx context.Context, tx *sqlx.Tx, resources ...resourcebus.Resource) error
+}
+
+// Recorder records the audit entries of resource mutations as part of the
+// transaction that makes them. Before and after are the database models of
+// the resource.
+type Recorder interface {
+	CreateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, after any) error
+	UpdateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any, after any) error
+	DeleteWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any) error
+}
+
 // Store manages the set of APIs for resource database access.
 type Store struct {
-	log *logger.Logger
-	db  sqlx.ExtContext
+	log      *logger.Logger
+	db       sqlx.ExtContext
+	enqueuer Enqueuer
+	recorder Recorder
 }
 
 // NewStore constructs the api for data access.
-func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+func NewStore(log *logger.Logger, db *sqlx.DB, enqueuer Enqueuer, recorder Recorder) *Store {
 	return &Store{
-		log: log,
-		db:  db,
+		log:      log,
+		db:       db,
+		enqueuer: enqueuer,
+		recorder: recorder,
 	}
 }
 
@@ -36,14 +57,39 @@ func (s *Store) Create(ctx context.Context, res resourcebus.Resource) error {
 	VALUES
 		(:resource_id, :resource_name, :galaxy_id, :added_at, :updated_at, :added_user_id, :resource_type, :cr, :cd, :dr, :fl, :hr, :ma, :pe, :oq, :sr, :ut, :er)`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBResource(res)); err != nil {
-		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
-			return fmt.Errorf("namedexeccontext: %w", resourcebus.ErrUniqueName)
-		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("create requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbRes := toDBResource(res)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbRes); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return fmt.Errorf("namedexeccontext: %w", resourcebus.ErrUniqueName)
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.setPlanets(ctx, tx, res); err != nil {
+			return fmt.Errorf("setplanets: %w", err)
+		}
+
+		if err := s.notifyChange(ctx, tx, resourcebus.ChangeCreated, res); err != nil {
+			return fmt.Errorf("notifychange: %w", err)
+		}
+
+		if err := s.enqueuer.CreatedWithTx(ctx, tx, res); err != nil {
+			return fmt.Errorf("enqueue: %w", err)
+		}
+
+		if err := s.recorder.CreateWithTx(ctx, tx, res.ID.String(), dbRes); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Update replaces a resource document in the database.
@@ -53,10 +99,7 @@ func (s *Store) Update(ctx context.Context, res resourcebus.Resource) error {
 		resources
 	SET 
 		"resource_name" = :resource_name,
-		"unavailable_at" = :unavailable_at,
-		"unavailable_user_id" = :unavailable_user_id,
-		"verified" = :verified,
-		"verified_user_id" = :verified_user_id,
+		"updated_at" = :updated_at,
 		"cr" = :cr,
 		"cd" = :cd,
 		"dr" = :dr,
@@ -71,14 +114,44 @@ func (s *Store) Update(ctx context.Context, res resourcebus.Resource) error {
 	WHERE
 		resource_id = :resource_id`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBResource(res)); err != nil {
-		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
-			return resourcebus.ErrUniqueName
-		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("update requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		before, err := s.queryByID(ctx, tx, res.ID)
+		if err != nil {
+			return fmt.Errorf("querybyid: %w", err)
+		}
+
+		dbRes := toDBResource(res)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbRes); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return resourcebus.ErrUniqueName
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.setPlanets(ctx, tx, res); err != nil {
+			return fmt.Errorf("setplanets: %w", err)
+		}
+
+		if err := s.clearStaleVerifications(ctx, tx, before, res); err != nil {
+			return fmt.Errorf("clearstaleverifications: %w", err)
+		}
+
+		if err := s.notifyChange(ctx, tx, resourcebus.ChangeUpdated, res); err != nil {
+			return fmt.Errorf("notifychange: %w", err)
+		}
+
+		if err := s.recorder.UpdateWithTx(ctx, tx, res.ID.String(), before, dbRes); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Delete removes a resource from the database.
@@ -89,11 +162,24 @@ func (s *Store) Delete(ctx context.Context, res resourcebus.Resource) error {
 	WHERE
 		resource_id = :resource_id`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBResource(res)); err != nil {
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("delete requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbRes := toDBResource(res)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbRes); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.recorder.DeleteWithTx(ctx, tx, res.ID.String(), dbRes); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Query retrieves a list of existing resources from the database.
@@ -105,7 +191,8 @@ func (s *Store) Query(ctx context.Context, filter resourcebus.QueryFilter, order
 
 	const q = `
 	SELECT
-		resource_id, resource_name, galaxy_id, added_at, added_user_id, resource_type, unavailable_at, unavailable_user_id, verified,verified_user_id, cr, cd, dr, fl, "hr", ma, pe, oq, sr, ut, er
+		resource_id, resource_name, galaxy_id, added_at, updated_at, added_user_id, resource_type, unavailable_at, unavailable_user_id, verified,verified_user_id, cr, cd, dr, fl, "hr", ma, pe, oq, sr, ut, er,
+		ARRAY(SELECT planet_id FROM resource_planets rp WHERE rp.resource_id = resources.resource_id ORDER BY planet_id) AS planets
 	FROM
 		resources`
 
@@ -128,6 +215,85 @@ func (s *Store) Query(ctx context.Context, filter resourcebus.QueryFilter, order
 	return toBusResources(dbRes)
 }
 
+// QueryAfter retrieves the page of resources that follows the cursor in the
+// order, along with the cursor that follows the last of them.
+func (s *Store) QueryAfter(ctx context.Context, filter resourcebus.QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]resourcebus.Resource, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		resource_id, resource_name, galaxy_id, added_at, updated_at, added_user_id, resource_type, unavailable_at, unavailable_user_id, verified,verified_user_id, cr, cd, dr, fl, "hr", ma, pe, oq, sr, ut, er,
+		ARRAY(SELECT planet_id FROM resource_planets rp WHERE rp.resource_id = resources.resource_id ORDER BY planet_id) AS planets
+	FROM
+		resources`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	keyset, err := keyset(orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedResource
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbRes := make([]resource, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbRes[i] = k.resource
+	}
+
+	bus, err := toBusResources(dbRes)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	return bus, dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
+// QueryEach calls fn with every resource matching the filter, reading them
+// from the database cursor one at a time rather than loading a page.
+func (s *Store) QueryEach(ctx context.Context, filter resourcebus.QueryFilter, orderBy order.By, fn func(resourcebus.Resource) error) error {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		resource_id, resource_name, galaxy_id, added_at, updated_at, added_user_id, resource_type, unavailable_at, unavailable_user_id, verified,verified_user_id, cr, cd, dr, fl, "hr", ma, pe, oq, sr, ut, er,
+		ARRAY(SELECT planet_id FROM resource_planets rp WHERE rp.resource_id = resources.resource_id ORDER BY planet_id) AS planets
+	FROM
+		resources`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return err
+	}
+
+	buf.WriteString(orderByClause)
+
+	each := func(dbRes resource) error {
+		res, err := toBusResource(dbRes)
+		if err != nil {
+			return err
+		}
+		return fn(res)
+	}
+
+	if err := sqldb.NamedQueryEach(ctx, s.log, s.db, buf.String(), data, each); err != nil {
+		return fmt.Errorf("namedqueryeach: %w", err)
+	}
+
+	return nil
+}
+
 // Count returns the total number of resources in the DB.
 func (s *Store) Count(ctx context.Context, filter resourcebus.QueryFilter) (int, error) {
 	data := map[string]any{}
@@ -153,6 +319,17 @@ func (s *Store) Count(ctx context.Context, filter resourcebus.QueryFilter) (int,
 
 // QueryByID gets the specified resource from the database.
 func (s *Store) QueryByID(ctx context.Context, resourceID uuid.UUID) (resourcebus.Resource, error) {
+	dbRes, err := s.queryByID(ctx, s.db, resourceID)
+	if err != nil {
+		return resourcebus.Resource{}, err
+	}
+
+	return toBusResource(dbRes)
+}
+
+// queryByID gets the specified resource from the database, or from within a
+// transaction when db is one.
+func (s *Store) queryByID(ctx context.Context, db sqlx.ExtContext, resourceID uuid.UUID) (resource, error) {
 	data := struct {
 		ID string `db:"resource_id"`
 	}{
@@ -161,38 +338,43 @@ func (s *Store) QueryByID(ctx context.Context, resourceID uuid.UUID) (resourcebu
 
 	const q = `
 	SELECT
-        resource_id, resource_name, galaxy_id, added_at, added_user_id, resource_type, unavailable_at, unavailable_user_id, verified,verified_user_id, cr, cd, dr, fl, "hr", ma, pe, oq, sr, ut, er
+        resource_id, resource_name, galaxy_id, added_at, updated_at, added_user_id, resource_type, unavailable_at, unavailable_user_id, verified,verified_user_id, cr, cd, dr, fl, "hr", ma, pe, oq, sr, ut, er,
+		ARRAY(SELECT planet_id FROM resource_planets rp WHERE rp.resource_id = resources.resource_id ORDER BY planet_id) AS planets
 	FROM
 		resources
 	WHERE 
 		resource_id = :resource_id`
 
 	var dbRe resource
-	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbRe); err != nil {
+	if err := sqldb.NamedQueryStruct(ctx, s.log, db, q, data, &dbRe); err != nil {
 		if errors.Is(err, sqldb.ErrDBNotFound) {
-			return resourcebus.Resource{}, fmt.Errorf("db: %w", resourcebus.ErrNotFound)
+			return resource{}, fmt.Errorf("db: %w", resourcebus.ErrNotFound)
 		}
-		return resourcebus.Resource{}, fmt.Errorf("db: %w", err)
+		return resource{}, fmt.Errorf("db: %w", err)
 	}
 
-	return toBusResource(dbRe)
+	return dbRe, nil
 }
 
-// QueryByName gets the specified resource from the database by email.
-func (s *Store) QueryByName(ctx context.Context, name string) (resourcebus.Resource, error) {
+// QueryByName gets the specified resource from the database by its name
+// within a galaxy.
+func (s *Store) QueryByName(ctx context.Context, galaxyID uuid.UUID, name string) (resourcebus.Resource, error) {
 	data := struct {
-		Name string `db:"resource_name"`
+		GalaxyID string `db:"galaxy_id"`
+		Name     string `db:"resource_name"`
 	}{
-		Name: name,
+		GalaxyID: galaxyID.String(),
+		Name:     name,
 	}
 
 	const q = `
 	SELECT
-        resource_id, resource_name, galaxy_id, added_at, added_user_id, resource_type, unavailable_at, unavailable_user_id, verified,verified_user_id, cr, cd, dr, fl, "hr", ma, pe, oq, sr, ut, er
+        resource_id, resource_name, galaxy_id, added_at, updated_at, added_user_id, resource_type, unavailable_at, unavailable_user_id, verified,verified_user_id, cr, cd, dr, fl, "hr", ma, pe, oq, sr, ut, er,
+		ARRAY(SELECT planet_id FROM resource_planets rp WHERE rp.resource_id = resources.resource_id ORDER BY planet_id) AS planets
 	FROM
 		resources
 	WHERE
-		resource_name = :resource_name`
+		galaxy_id = :galaxy_id AND resource_name = :resource_name`
 
 	var dbRe resource
 	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbRe); err != nil {
@@ -205,6 +387,40 @@ func (s *Store) QueryByName(ctx context.Context, name string) (resourcebus.Resou
 	return toBusResource(dbRe)
 }
 
+// QueryNames returns the names, out of those specified, that resources of the
+// galaxy already have.
+func (s *Store) QueryNames(ctx context.Context, galaxyID uuid.UUID, names []string) ([]string, error) {
+	data := struct {
+		GalaxyID string   `db:"galaxy_id"`
+		Names    []string `db:"resource_names"`
+	}{
+		GalaxyID: galaxyID.String(),
+		Names:    names,
+	}
+
+	const q = `
+	SELECT
+		resource_name
+	FROM
+		resources
+	WHERE
+		galaxy_id = :galaxy_id AND resource_name IN (:resource_names)`
+
+	var dbNames []struct {
+		Name string `db:"resource_name"`
+	}
+	if err := sqldb.NamedQuerySliceUsingIn(ctx, s.log, s.db, q, data, &dbNames); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	taken := make([]string, len(dbNames))
+	for i, n := range dbNames {
+		taken[i] = n.Name
+	}
+
+	return taken, nil
+}
+
 // BulkCreate inserts multiple resources into the database in a single transaction.
 func (s *Store) BulkCreate(ctx context.Context, resources []resourcebus.Resource) error {
 	db, ok := s.db.(*sqlx.DB)
@@ -220,13 +436,32 @@ func (s *Store) BulkCreate(ctx context.Context, resources []resourcebus.Resource
 			(:resource_id, :resource_name, :galaxy_id, :added_at, :updated_at, :added_user_id, :resource_type, :cr, :cd, :dr, :fl, :hr, :ma, :pe, :oq, :sr, :ut, :er)`
 
 		for i, res := range resources {
-			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBResource(res)); err != nil {
+			dbRes := toDBResource(res)
+
+			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbRes); err != nil {
 				if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
 					return fmt.Errorf("item[%d]: %w", i, resourcebus.ErrUniqueName)
 				}
 				return fmt.Errorf("item[%d]: %w", i, err)
 			}
+
+			if err := s.setPlanets(ctx, tx, res); err != nil {
+				return fmt.Errorf("item[%d]: setplanets: %w", i, err)
+			}
+
+			if err := s.notifyChange(ctx, tx, resourcebus.ChangeCreated, res); err != nil {
+				return fmt.Errorf("item[%d]: notifychange: %w", i, err)
+			}
+
+			if err := s.recorder.CreateWithTx(ctx, tx, res.ID.String(), dbRes); err != nil {
+				return fmt.Errorf("item[%d]: audit: %w", i, err)
+			}
+		}
+
+		if err := s.enqueuer.CreatedWithTx(ctx, tx, resources...); err != nil {
+			return fmt.Errorf("enqueue: %w", err)
 		}
+
 		return nil
 	})
 }
@@ -244,10 +479,6 @@ func (s *Store) BulkUpdate(ctx context.Context, resources []resourcebus.Resource
 			resources
 		SET
 			"resource_name" = :resource_name,
-			"unavailable_at" = :unavailable_at,
-			"unavailable_user_id" = :unavailable_user_id,
-			"verified" = :verified,
-			"verified_user_id" = :verified_user_id,
 			"cr" = :cr,
 			"cd" = :cd,
 			"dr" = :dr,
@@ -264,12 +495,35 @@ func (s *Store) BulkUpdate(ctx context.Context, resources []resourcebus.Resource
 			resource_id = :resource_id`
 
 		for i, res := range resources {
-			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBResource(res)); err != nil {
+			before, err := s.queryByID(ctx, tx, res.ID)
+			if err != nil {
+				return fmt.Errorf("item[%d]: querybyid: %w", i, err)
+			}
+
+			dbRes := toDBResource(res)
+
+			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbRes); err != nil {
 				if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
 					return fmt.Errorf("item[%d]: %w", i, resourcebus.ErrUniqueName)
 				}
 				return fmt.Errorf("item[%d]: %w", i, err)
 			}
+
+			if err := s.setPlanets(ctx, tx, res); err != nil {
+				return fmt.Errorf("item[%d]: setplanets: %w", i, err)
+			}
+
+			if err := s.clearStaleVerifications(ctx, tx, before, res); err != nil {
+				return fmt.Errorf("item[%d]: clearstaleverifications: %w", i, err)
+			}
+
+			if err := s.notifyChange(ctx, tx, resourcebus.ChangeUpdated, res); err != nil {
+				return fmt.Errorf("item[%d]: notifychange: %w", i, err)
+			}
+
+			if err := s.recorder.UpdateWithTx(ctx, tx, res.ID.String(), before, dbRes); err != nil {
+				return fmt.Errorf("item[%d]: audit: %w", i, err)
+			}
 		}
 		return nil
 	})
@@ -292,11 +546,72 @@ func (s *Store) BulkDelete(ctx context.Context, ids []uuid.UUID) error {
 			data.IDs[i] = id.String()
 		}
 
+		const qSelect = `
+		SELECT
+			resource_id, resource_name, galaxy_id, added_at, updated_at, added_user_id, resource_type, unavailable_at, unavailable_user_id, verified, verified_user_id, cr, cd, dr, fl, "hr", ma, pe, oq, sr, ut, er,
+			ARRAY(SELECT planet_id FROM resource_planets rp WHERE rp.resource_id = resources.resource_id ORDER BY planet_id) AS planets
+		FROM
+			resources
+		WHERE
+			resource_id IN (:ids)`
+
+		var dbResources []resource
+		if err := sqldb.NamedQuerySliceUsingIn(ctx, s.log, tx, qSelect, data, &dbResources); err != nil {
+			return fmt.Errorf("namedqueryslice: %w", err)
+		}
+
 		const q = `DELETE FROM resources WHERE resource_id IN (:ids)`
 
 		if err := sqldb.NamedExecContextUsingInWithTx(ctx, s.log, tx, q, data); err != nil {
 			return fmt.Errorf("namedexeccontextusingintx: %w", err)
 		}
+
+		for _, dbRes := range dbResources {
+			if err := s.recorder.DeleteWithTx(ctx, tx, dbRes.ID.String(), dbRes); err != nil {
+				return fmt.Errorf("resourceID[%s]: audit: %w", dbRes.ID, err)
+			}
+		}
 		return nil
 	})
 }
+
+// setPlanets replaces the planets the resource is available on.
+func (s *Store) setPlanets(ctx context.Context, tx *sqlx.Tx, res resourcebus.Resource) error {
+	data := struct {
+		ID string `db:"resource_id"`
+	}{
+		ID: res.ID.String(),
+	}
+
+	const qDelete = `
+	DELETE FROM
+		resource_planets
+	WHERE
+		resource_id = :resource_id`
+
+	if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, qDelete, data); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	const qInsert = `
+	INSERT INTO resource_planets
+		(resource_id, planet_id)
+	VALUES
+		(:resource_id, :planet_id)`
+
+	for _, planetID := range res.Planets {
+		row := struct {
+			ID       string `db:"resource_id"`
+			PlanetID int16  `db:"planet_id"`
+		}{
+			ID:       res.ID.String(),
+			PlanetID: planetID,
+		}
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, qInsert, row); err != nil {
+			return fmt.Errorf("insert: planetID[%d]: %w", planetID, err)
+		}
+	}
+
+	return nil
+}
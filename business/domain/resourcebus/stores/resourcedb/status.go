@@ -0,0 +1,132 @@
+package resourcedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// UpdateStatus changes whether a resource is in spawn and records the change
+// in the status history in a single transaction. The resource is only changed
+// while it is still in the status it is changed from, so of two concurrent
+// changes the second fails with ErrStatusConflict.
+func (s *Store) UpdateStatus(ctx context.Context, res resourcebus.Resource, sc resourcebus.StatusChange) error {
+	const qDespawn = `
+	UPDATE
+		resources
+	SET
+		"unavailable_at" = :unavailable_at,
+		"unavailable_user_id" = :unavailable_user_id,
+		"updated_at" = :updated_at
+	WHERE
+		resource_id = :resource_id AND
+		unavailable_at IS NULL
+	RETURNING
+		resource_id`
+
+	const qReactivate = `
+	UPDATE
+		resources
+	SET
+		"unavailable_at" = :unavailable_at,
+		"unavailable_user_id" = :unavailable_user_id,
+		"updated_at" = :updated_at
+	WHERE
+		resource_id = :resource_id AND
+		unavailable_at IS NOT NULL
+	RETURNING
+		resource_id`
+
+	const qInsert = `
+	INSERT INTO resource_status_history
+		(history_id, resource_id, status, user_id, changed_at)
+	VALUES
+		(:history_id, :resource_id, :status, :user_id, :changed_at)`
+
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("update status requires *sqlx.DB")
+	}
+
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		before, err := s.queryByID(ctx, tx, res.ID)
+		if err != nil {
+			return fmt.Errorf("querybyid: %w", err)
+		}
+
+		despawn := sc.Status.Equal(resourcebus.Statuses.Unavailable)
+
+		qUpdate := qReactivate
+		if despawn {
+			qUpdate = qDespawn
+		}
+
+		dbRes := toDBResource(res)
+
+		var updated struct {
+			ID string `db:"resource_id"`
+		}
+		if err := sqldb.NamedQueryStruct(ctx, s.log, tx, qUpdate, dbRes, &updated); err != nil {
+			if errors.Is(err, sqldb.ErrDBNotFound) {
+				return fmt.Errorf("update: %w", resourcebus.ErrStatusConflict)
+			}
+			return fmt.Errorf("update: %w", err)
+		}
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, qInsert, toDBStatusChange(sc)); err != nil {
+			return fmt.Errorf("insert: %w", err)
+		}
+
+		action := resourcebus.ChangeUpdated
+		if despawn {
+			action = resourcebus.ChangeDespawned
+		}
+
+		if err := s.notifyChange(ctx, tx, action, res); err != nil {
+			return fmt.Errorf("notifychange: %w", err)
+		}
+
+		if despawn {
+			if err := s.enqueuer.DespawnedWithTx(ctx, tx, res); err != nil {
+				return fmt.Errorf("enqueue: %w", err)
+			}
+		}
+
+		if err := s.recorder.UpdateWithTx(ctx, tx, res.ID.String(), before, dbRes); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// QueryStatusHistory retrieves the status changes of a resource, oldest first.
+func (s *Store) QueryStatusHistory(ctx context.Context, resourceID uuid.UUID) ([]resourcebus.StatusChange, error) {
+	data := struct {
+		ID string `db:"resource_id"`
+	}{
+		ID: resourceID.String(),
+	}
+
+	const q = `
+	SELECT
+		history_id, resource_id, status, user_id, changed_at
+	FROM
+		resource_status_history
+	WHERE
+		resource_id = :resource_id
+	ORDER BY
+		changed_at`
+
+	var dbHistory []statusChange
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbHistory); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusStatusChanges(dbHistory)
+}
@@ -0,0 +1,206 @@
+package resourcedb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// RecordVerification stores the user's vote on a resource, replacing any
+// earlier vote by the same user, and tallies the votes. The resource returned
+// by fn is stored when it changed whether the resource is verified. It all
+// happens in a single transaction that locks the resource, so concurrent
+// votes on the same resource are tallied one after the other.
+func (s *Store) RecordVerification(ctx context.Context, v resourcebus.Verification, fn func(resourcebus.Resource, resourcebus.VerificationTally) resourcebus.Resource) (resourcebus.Resource, resourcebus.VerificationTally, error) {
+	const qLock = `
+	SELECT
+		resource_id
+	FROM
+		resources
+	WHERE
+		resource_id = :resource_id
+	FOR UPDATE`
+
+	const qUpsert = `
+	INSERT INTO resource_verifications
+		(resource_id, user_id, vote, date_created)
+	VALUES
+		(:resource_id, :user_id, :vote, :date_created)
+	ON CONFLICT (resource_id, user_id) DO UPDATE SET
+		vote = EXCLUDED.vote,
+		date_created = EXCLUDED.date_created`
+
+	const qTally = `
+	SELECT
+		count(1) FILTER (WHERE vote = :confirm) AS confirmations,
+		count(1) FILTER (WHERE vote = :dispute) AS disputes
+	FROM
+		resource_verifications
+	WHERE
+		resource_id = :resource_id`
+
+	const qVerified = `
+	UPDATE
+		resources
+	SET
+		"verified" = :verified,
+		"verified_user_id" = :verified_user_id,
+		"updated_at" = :updated_at
+	WHERE
+		resource_id = :resource_id`
+
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return resourcebus.Resource{}, resourcebus.VerificationTally{}, errors.New("record verification requires *sqlx.DB")
+	}
+
+	data := map[string]any{
+		"resource_id": v.ResourceID.String(),
+		"confirm":     resourcebus.Votes.Confirm.String(),
+		"dispute":     resourcebus.Votes.Dispute.String(),
+	}
+
+	var res resourcebus.Resource
+	var busTally resourcebus.VerificationTally
+
+	err := sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		var locked struct {
+			ID string `db:"resource_id"`
+		}
+		if err := sqldb.NamedQueryStruct(ctx, s.log, tx, qLock, data, &locked); err != nil {
+			if errors.Is(err, sqldb.ErrDBNotFound) {
+				return fmt.Errorf("lock: %w", resourcebus.ErrNotFound)
+			}
+			return fmt.Errorf("lock: %w", err)
+		}
+
+		before, err := s.queryByID(ctx, tx, v.ResourceID)
+		if err != nil {
+			return fmt.Errorf("querybyid: %w", err)
+		}
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, qUpsert, toDBVerification(v)); err != nil {
+			return fmt.Errorf("upsert: %w", err)
+		}
+
+		var dbTally tally
+		if err := sqldb.NamedQueryStruct(ctx, s.log, tx, qTally, data, &dbTally); err != nil {
+			return fmt.Errorf("tally: %w", err)
+		}
+		busTally = toBusTally(dbTally)
+
+		res, err = toBusResource(before)
+		if err != nil {
+			return fmt.Errorf("tobusresource: %w", err)
+		}
+
+		upd := fn(res, busTally)
+		if upd.Verified == res.Verified {
+			return nil
+		}
+		res = upd
+
+		dbRes := toDBResource(res)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, qVerified, dbRes); err != nil {
+			return fmt.Errorf("updateverified: %w", err)
+		}
+
+		if err := s.notifyChange(ctx, tx, resourcebus.ChangeUpdated, res); err != nil {
+			return fmt.Errorf("notifychange: %w", err)
+		}
+
+		if res.Verified {
+			if err := s.enqueuer.VerifiedWithTx(ctx, tx, res); err != nil {
+				return fmt.Errorf("enqueue: %w", err)
+			}
+		}
+
+		if err := s.recorder.UpdateWithTx(ctx, tx, res.ID.String(), before, dbRes); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return resourcebus.Resource{}, resourcebus.VerificationTally{}, err
+	}
+
+	return res, busTally, nil
+}
+
+// clearStaleVerifications removes the votes cast on a resource and unverifies
+// it when the update changed its stats. Votes are cast on the stats of a
+// resource, so they lapse with them.
+func (s *Store) clearStaleVerifications(ctx context.Context, tx *sqlx.Tx, before resource, res resourcebus.Resource) error {
+	busBefore, err := toBusResource(before)
+	if err != nil {
+		return fmt.Errorf("tobusresource: %w", err)
+	}
+
+	if res.SameStats(busBefore) {
+		return nil
+	}
+
+	data := struct {
+		ID string `db:"resource_id"`
+	}{
+		ID: res.ID.String(),
+	}
+
+	const qDelete = `
+	DELETE FROM
+		resource_verifications
+	WHERE
+		resource_id = :resource_id`
+
+	const qUnverify = `
+	UPDATE
+		resources
+	SET
+		"verified" = false,
+		"verified_user_id" = NULL
+	WHERE
+		resource_id = :resource_id`
+
+	if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, qDelete, data); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, qUnverify, data); err != nil {
+		return fmt.Errorf("unverify: %w", err)
+	}
+
+	return nil
+}
+
+// QueryVerifications retrieves the votes cast on a resource, oldest first.
+func (s *Store) QueryVerifications(ctx context.Context, resourceID uuid.UUID) ([]resourcebus.Verification, error) {
+	data := struct {
+		ID string `db:"resource_id"`
+	}{
+		ID: resourceID.String(),
+	}
+
+	const q = `
+	SELECT
+		resource_id, user_id, vote, date_created
+	FROM
+		resource_verifications
+	WHERE
+		resource_id = :resource_id
+	ORDER BY
+		date_created`
+
+	var dbVerifications []verification
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbVerifications); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusVerifications(dbVerifications)
+}
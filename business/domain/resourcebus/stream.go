@@ -0,0 +1,175 @@
+package resourcebus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Set of changes published when a resource is written.
+const (
+	ChangeCreated   = "created"
+	ChangeUpdated   = "updated"
+	ChangeDespawned = "despawned"
+)
+
+// Change represents a committed write to a resource as published by the store.
+type Change struct {
+	Action     string
+	ResourceID uuid.UUID
+	GalaxyID   uuid.UUID
+}
+
+// EventOverflow is the action of the last event a subscriber receives when it
+// falls eventBuffer events behind. The event has no resource and the
+// subscriber gets no further events, it must catch up by querying the
+// resources and subscribe again.
+const EventOverflow = "overflow"
+
+// Event represents a change delivered to a subscriber along with the resource
+// as it was when the event was handled.
+type Event struct {
+	Action   string
+	Resource Resource
+}
+
+// eventBuffer is the number of events a subscriber can fall behind before it
+// is sent EventOverflow and dropped.
+const eventBuffer = 16
+
+// stream fans the changes published by the store out to the subscribers of
+// each galaxy. A single listener runs for as long as anyone is subscribed.
+type stream struct {
+	mu     sync.Mutex
+	subs   map[uuid.UUID]map[chan Event]struct{}
+	cancel context.CancelFunc
+}
+
+func newStream() *stream {
+	return &stream{
+		subs: make(map[uuid.UUID]map[chan Event]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving the changes made to the resources of
+// the galaxy, including those made by other instances of the service. The
+// returned function ends the subscription and must be called once the caller
+// is done; the channel is not closed.
+func (b *Business) Subscribe(galaxyID uuid.UUID) (<-chan Event, func()) {
+	s := b.stream
+	// The extra slot holds the overflow event.
+	ch := make(chan Event, eventBuffer+1)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		go b.listen(ctx)
+	}
+
+	if s.subs[galaxyID] == nil {
+		s.subs[galaxyID] = make(map[chan Event]struct{})
+	}
+	s.subs[galaxyID][ch] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			delete(s.subs[galaxyID], ch)
+			if len(s.subs[galaxyID]) == 0 {
+				delete(s.subs, galaxyID)
+			}
+
+			if len(s.subs) == 0 && s.cancel != nil {
+				s.cancel()
+				s.cancel = nil
+			}
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// listen receives the changes from the store until the context is canceled,
+// reconnecting with backoff whenever the connection is lost.
+func (b *Business) listen(ctx context.Context) {
+	const (
+		minBackoff = time.Second
+		maxBackoff = 30 * time.Second
+	)
+
+	backoff := minBackoff
+
+	for {
+		started := time.Now()
+
+		err := b.storer.ListenChanges(ctx, func(c Change) {
+			b.publish(ctx, c)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(started) > maxBackoff {
+			backoff = minBackoff
+		}
+
+		b.log.Error(ctx, "resourcebus: listen changes", "retry", backoff, "ERROR", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, maxBackoff)
+	}
+}
+
+// publish loads the changed resource once and hands it to every subscriber of
+// its galaxy. Subscribers that are not keeping up are sent EventOverflow
+// instead and removed, so they never miss an event without knowing.
+func (b *Business) publish(ctx context.Context, c Change) {
+	s := b.stream
+
+	s.mu.Lock()
+	n := len(s.subs[c.GalaxyID])
+	s.mu.Unlock()
+
+	if n == 0 {
+		return
+	}
+
+	res, err := b.storer.QueryByID(ctx, c.ResourceID)
+	if err != nil {
+		b.log.Error(ctx, "resourcebus: publish", "resource_id", c.ResourceID, "ERROR", err)
+		return
+	}
+
+	evt := Event{
+		Action:   c.Action,
+		Resource: res,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for ch := range s.subs[c.GalaxyID] {
+		if len(ch) < eventBuffer {
+			ch <- evt
+			continue
+		}
+
+		b.log.Info(ctx, "resourcebus: publish: subscriber is behind, overflow sent", "resource_id", c.ResourceID, "action", c.Action)
+
+		ch <- Event{Action: EventOverflow}
+		delete(s.subs[c.GalaxyID], ch)
+	}
+}
@@ -0,0 +1,113 @@
+package resourcebus
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/google/uuid"
+)
+
+// Confirm records the user confirming the stats of a resource. The resource
+// is verified once its confirmations exceed its disputes by the verification
+// threshold of its galaxy. The user who added a resource can not confirm it.
+func (b *Business) Confirm(ctx context.Context, res Resource, userID uuid.UUID) (Resource, VerificationTally, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.confirm")
+	defer span.End()
+
+	if res.AddedUserID == userID {
+		return Resource{}, VerificationTally{}, ErrSelfVerification
+	}
+
+	return b.vote(ctx, res, userID, Votes.Confirm)
+}
+
+// Dispute records the user disputing the stats of a resource. A verified
+// resource loses its verification once the disputes bring it back under the
+// verification threshold of its galaxy.
+func (b *Business) Dispute(ctx context.Context, res Resource, userID uuid.UUID) (Resource, VerificationTally, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.dispute")
+	defer span.End()
+
+	return b.vote(ctx, res, userID, Votes.Dispute)
+}
+
+// QueryVerifications retrieves the votes cast on a resource and their tally.
+func (b *Business) QueryVerifications(ctx context.Context, res Resource) ([]Verification, VerificationTally, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcebus.queryverifications")
+	defer span.End()
+
+	gal, err := b.galaxyBus.QueryByID(ctx, res.GalaxyID)
+	if err != nil {
+		return nil, VerificationTally{}, fmt.Errorf("querybyid: galaxyID[%s]: %w", res.GalaxyID, err)
+	}
+
+	verifications, err := b.storer.QueryVerifications(ctx, res.ID)
+	if err != nil {
+		return nil, VerificationTally{}, fmt.Errorf("queryverifications: resourceID[%s]: %w", res.ID, err)
+	}
+
+	tally := VerificationTally{
+		Threshold: gal.VerificationThreshold,
+	}
+	for _, v := range verifications {
+		switch v.Vote {
+		case Votes.Confirm:
+			tally.Confirmations++
+		case Votes.Dispute:
+			tally.Disputes++
+		}
+	}
+
+	return verifications, tally, nil
+}
+
+// vote records the user's vote, replacing any earlier vote by the same user,
+// and flips the resource's verification when the tally crosses the threshold.
+// The vote and the flip are stored together while the resource is locked, so
+// concurrent votes are tallied one after the other.
+func (b *Business) vote(ctx context.Context, res Resource, userID uuid.UUID, vote Vote) (Resource, VerificationTally, error) {
+	gal, err := b.galaxyBus.QueryByID(ctx, res.GalaxyID)
+	if err != nil {
+		return Resource{}, VerificationTally{}, fmt.Errorf("querybyid: galaxyID[%s]: %w", res.GalaxyID, err)
+	}
+
+	now := time.Now()
+
+	v := Verification{
+		ResourceID:  res.ID,
+		UserID:      userID,
+		Vote:        vote,
+		DateCreated: now,
+	}
+
+	var verifiedNow bool
+
+	res, tally, err := b.storer.RecordVerification(ctx, v, func(res Resource, tally VerificationTally) Resource {
+		tally.Threshold = gal.VerificationThreshold
+
+		if verified := tally.Verified(); verified != res.Verified {
+			res.Verified = verified
+			res.VerifiedUserID = uuid.Nil
+			if verified {
+				res.VerifiedUserID = userID
+			}
+			res.UpdatedAtDate = now
+
+			verifiedNow = verified
+		}
+
+		return res
+	})
+	if err != nil {
+		return Resource{}, VerificationTally{}, fmt.Errorf("recordverification: %w", err)
+	}
+	tally.Threshold = gal.VerificationThreshold
+
+	if verifiedNow {
+		b.notify(ctx, ActionVerified, res)
+	}
+
+	return res, tally, nil
+}
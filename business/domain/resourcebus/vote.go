@@ -0,0 +1,50 @@
+package resourcebus
+
+import "fmt"
+
+type voteSet struct {
+	Confirm Vote
+	Dispute Vote
+}
+
+// Votes represents the set of votes a user can cast on a resource's stats.
+var Votes = voteSet{
+	Confirm: newVote("CONFIRM"),
+	Dispute: newVote("DISPUTE"),
+}
+
+// Parse parses the string value and returns a vote if one exists.
+func (voteSet) Parse(value string) (Vote, error) {
+	vote, exists := votes[value]
+	if !exists {
+		return Vote{}, fmt.Errorf("invalid vote %q", value)
+	}
+
+	return vote, nil
+}
+
+// =============================================================================
+
+// Set of known votes.
+var votes = make(map[string]Vote)
+
+// Vote represents a user confirming or disputing a resource's stats.
+type Vote struct {
+	name string
+}
+
+func newVote(vote string) Vote {
+	v := Vote{vote}
+	votes[vote] = v
+	return v
+}
+
+// String returns the name of the vote.
+func (v Vote) String() string {
+	return v.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (v Vote) Equal(v2 Vote) bool {
+	return v.name == v2.name
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/godwinrob/harvester/business/sdk/order"
 	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
 )
 
 // Set of error variables for CRUD operations.
@@ -18,6 +19,7 @@ var (
 // retrieve data.
 type Storer interface {
 	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]ResourceGroup, error)
+	QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]ResourceGroup, order.Cursor, error)
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, resourceGroup string) (ResourceGroup, error)
 }
@@ -38,6 +40,9 @@ func NewBusiness(log *logger.Logger, storer Storer) *Business {
 
 // Query retrieves a list of existing resource groups.
 func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]ResourceGroup, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcegroupbus.query")
+	defer span.End()
+
 	groups, err := b.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
 	if err != nil {
 		return nil, fmt.Errorf("query: %w", err)
@@ -46,13 +51,34 @@ func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.
 	return groups, nil
 }
 
+// QueryAfter retrieves the page of resource groups that follows the cursor
+// in the order, and the cursor for the page after it. The zero cursor starts
+// at the first resource group.
+func (b *Business) QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]ResourceGroup, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcegroupbus.queryafter")
+	defer span.End()
+
+	groups, next, err := b.storer.QueryAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryafter: %w", err)
+	}
+
+	return groups, next, nil
+}
+
 // Count returns the total number of resource groups.
 func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcegroupbus.count")
+	defer span.End()
+
 	return b.storer.Count(ctx, filter)
 }
 
 // QueryByID finds the resource group by the specified key.
 func (b *Business) QueryByID(ctx context.Context, resourceGroup string) (ResourceGroup, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcegroupbus.querybyid")
+	defer span.End()
+
 	group, err := b.storer.QueryByID(ctx, resourceGroup)
 	if err != nil {
 		return ResourceGroup{}, fmt.Errorf("query: resourceGroup[%s]: %w", resourceGroup, err)
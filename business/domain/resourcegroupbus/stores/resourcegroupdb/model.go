@@ -2,6 +2,7 @@ package resourcegroupdb
 
 import (
 	"github.com/godwinrob/harvester/business/domain/resourcegroupbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
 )
 
 type resourceGroup struct {
@@ -12,6 +13,11 @@ type resourceGroup struct {
 	ContainerType string `db:"container_type"`
 }
 
+type keyedResourceGroup struct {
+	resourceGroup
+	sqldb.Keyed
+}
+
 func toBusResourceGroup(db resourceGroup) resourcegroupbus.ResourceGroup {
 	return resourcegroupbus.ResourceGroup{
 		ResourceGroup: db.ResourceGroup,
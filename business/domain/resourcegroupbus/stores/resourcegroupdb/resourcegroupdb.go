@@ -59,6 +59,42 @@ func (s *Store) Query(ctx context.Context, filter resourcegroupbus.QueryFilter,
 	return toBusResourceGroups(dbRes), nil
 }
 
+// QueryAfter retrieves the page of resource groups that follows the cursor
+// in the order, along with the cursor that follows the last of them.
+func (s *Store) QueryAfter(ctx context.Context, filter resourcegroupbus.QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]resourcegroupbus.ResourceGroup, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		resource_group, group_name, group_level, group_order, container_type
+	FROM
+		resource_groups`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	keyset, err := keyset(orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedResourceGroup
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbRes := make([]resourceGroup, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbRes[i] = k.resourceGroup
+	}
+
+	return toBusResourceGroups(dbRes), dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
 // Count returns the total number of resource groups in the DB.
 func (s *Store) Count(ctx context.Context, filter resourcegroupbus.QueryFilter) (int, error) {
 	data := map[string]any{}
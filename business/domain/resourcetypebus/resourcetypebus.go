@@ -7,6 +7,7 @@ import (
 
 	"github.com/godwinrob/harvester/business/sdk/order"
 	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
 )
 
 // Set of error variables for CRUD operations.
@@ -22,6 +23,7 @@ type Storer interface {
 	Update(ctx context.Context, rt ResourceType) error
 	Delete(ctx context.Context, rt ResourceType) error
 	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]ResourceType, error)
+	QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]ResourceType, order.Cursor, error)
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, resourceType string) (ResourceType, error)
 	BulkCreate(ctx context.Context, resourceTypes []ResourceType) error
@@ -43,6 +45,9 @@ func NewBusiness(log *logger.Logger, storer Storer) *Business {
 
 // Create adds a new resource type to the system.
 func (b *Business) Create(ctx context.Context, nu NewResourceType) (ResourceType, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcetypebus.create")
+	defer span.End()
+
 	rt := ResourceType{
 		ResourceType:     nu.ResourceType,
 		ResourceTypeName: nu.ResourceTypeName,
@@ -86,6 +91,9 @@ func (b *Business) Create(ctx context.Context, nu NewResourceType) (ResourceType
 
 // Update modifies information about a resource type.
 func (b *Business) Update(ctx context.Context, rt ResourceType, uu UpdateResourceType) (ResourceType, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcetypebus.update")
+	defer span.End()
+
 	if uu.ResourceTypeName != nil {
 		rt.ResourceTypeName = *uu.ResourceTypeName
 	}
@@ -186,6 +194,9 @@ func (b *Business) Update(ctx context.Context, rt ResourceType, uu UpdateResourc
 
 // Delete removes the specified resource type.
 func (b *Business) Delete(ctx context.Context, rt ResourceType) error {
+	ctx, span := otel.AddSpan(ctx, "business.resourcetypebus.delete")
+	defer span.End()
+
 	if err := b.storer.Delete(ctx, rt); err != nil {
 		return fmt.Errorf("delete: %w", err)
 	}
@@ -195,6 +206,9 @@ func (b *Business) Delete(ctx context.Context, rt ResourceType) error {
 
 // Query retrieves a list of existing resource types.
 func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]ResourceType, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcetypebus.query")
+	defer span.End()
+
 	rts, err := b.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
 	if err != nil {
 		return nil, fmt.Errorf("query: %w", err)
@@ -203,13 +217,34 @@ func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.
 	return rts, nil
 }
 
+// QueryAfter retrieves the page of resource types that follows the cursor in the
+// order, and the cursor for the page after it. The zero cursor starts at the
+// first resource type.
+func (b *Business) QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]ResourceType, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcetypebus.queryafter")
+	defer span.End()
+
+	rts, next, err := b.storer.QueryAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryafter: %w", err)
+	}
+
+	return rts, next, nil
+}
+
 // Count returns the total number of resource types.
 func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcetypebus.count")
+	defer span.End()
+
 	return b.storer.Count(ctx, filter)
 }
 
 // QueryByID finds the resource type by the specified key.
 func (b *Business) QueryByID(ctx context.Context, resourceType string) (ResourceType, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcetypebus.querybyid")
+	defer span.End()
+
 	rt, err := b.storer.QueryByID(ctx, resourceType)
 	if err != nil {
 		return ResourceType{}, fmt.Errorf("query: resourceType[%s]: %w", resourceType, err)
@@ -220,6 +255,9 @@ func (b *Business) QueryByID(ctx context.Context, resourceType string) (Resource
 
 // BulkCreate adds multiple new resource types to the system in a single transaction.
 func (b *Business) BulkCreate(ctx context.Context, newTypes []NewResourceType) ([]ResourceType, error) {
+	ctx, span := otel.AddSpan(ctx, "business.resourcetypebus.bulkcreate")
+	defer span.End()
+
 	rts := make([]ResourceType, len(newTypes))
 
 	for i, nu := range newTypes {
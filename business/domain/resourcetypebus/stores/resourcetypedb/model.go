@@ -2,6 +2,7 @@ package resourcetypedb
 
 import (
 	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
 )
 
 type resourceType struct {
@@ -38,6 +39,11 @@ type resourceType struct {
 	SpecificPlanet   int16  `db:"specific_planet"`
 }
 
+type keyedResourceType struct {
+	resourceType
+	sqldb.Keyed
+}
+
 func toDBResourceType(bus resourcetypebus.ResourceType) resourceType {
 	return resourceType{
 		ResourceType:     bus.ResourceType,
@@ -5,6 +5,7 @@ import (
 
 	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
 	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
 )
 
 var orderByFields = map[string]string{
@@ -23,3 +24,12 @@ func orderByClause(orderBy order.By) (string, error) {
 
 	return " ORDER BY " + by + " " + orderBy.Direction, nil
 }
+
+func keyset(orderBy order.By) (sqldb.Keyset, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return sqldb.Keyset{}, fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return sqldb.Keyset{Expr: by, ID: "resource_type", Direction: orderBy.Direction}, nil
+}
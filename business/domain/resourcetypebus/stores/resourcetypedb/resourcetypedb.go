@@ -13,17 +13,28 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// Recorder records the audit entries of resource type mutations as part of the
+// transaction that makes them. Before and after are the database models of
+// the resource type.
+type Recorder interface {
+	CreateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, after any) error
+	UpdateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any, after any) error
+	DeleteWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any) error
+}
+
 // Store manages the set of APIs for resource type database access.
 type Store struct {
-	log *logger.Logger
-	db  sqlx.ExtContext
+	log      *logger.Logger
+	db       sqlx.ExtContext
+	recorder Recorder
 }
 
 // NewStore constructs the api for data access.
-func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+func NewStore(log *logger.Logger, db *sqlx.DB, recorder Recorder) *Store {
 	return &Store{
-		log: log,
-		db:  db,
+		log:      log,
+		db:       db,
+		recorder: recorder,
 	}
 }
 
@@ -45,14 +56,27 @@ func (s *Store) Create(ctx context.Context, rt resourcetypebus.ResourceType) err
 		 :sr_min, :sr_max, :ut_min, :ut_max, :er_min, :er_max,
 		 :container_type, :inventory_type, :specific_planet)`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBResourceType(rt)); err != nil {
-		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
-			return fmt.Errorf("namedexeccontext: %w", resourcetypebus.ErrUniqueType)
-		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("create requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbRT := toDBResourceType(rt)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbRT); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return fmt.Errorf("namedexeccontext: %w", resourcetypebus.ErrUniqueType)
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.recorder.CreateWithTx(ctx, tx, rt.ResourceType, dbRT); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Update replaces a resource type document in the database.
@@ -83,11 +107,29 @@ func (s *Store) Update(ctx context.Context, rt resourcetypebus.ResourceType) err
 	WHERE
 		resource_type = :resource_type`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBResourceType(rt)); err != nil {
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("update requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		before, err := s.queryByID(ctx, tx, rt.ResourceType)
+		if err != nil {
+			return fmt.Errorf("querybyid: %w", err)
+		}
+
+		dbRT := toDBResourceType(rt)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbRT); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.recorder.UpdateWithTx(ctx, tx, rt.ResourceType, before, dbRT); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Delete removes a resource type from the database.
@@ -98,11 +140,24 @@ func (s *Store) Delete(ctx context.Context, rt resourcetypebus.ResourceType) err
 	WHERE
 		resource_type = :resource_type`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBResourceType(rt)); err != nil {
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("delete requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbRT := toDBResourceType(rt)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbRT); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.recorder.DeleteWithTx(ctx, tx, rt.ResourceType, dbRT); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Query retrieves a list of existing resource types from the database.
@@ -142,6 +197,47 @@ func (s *Store) Query(ctx context.Context, filter resourcetypebus.QueryFilter, o
 	return toBusResourceTypes(dbRes), nil
 }
 
+// QueryAfter retrieves the page of resource types that follows the cursor in the
+// order, along with the cursor that follows the last of them.
+func (s *Store) QueryAfter(ctx context.Context, filter resourcetypebus.QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]resourcetypebus.ResourceType, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		resource_type, resource_type_name, resource_category, resource_group,
+		enterable, max_types,
+		cr_min, cr_max, cd_min, cd_max, dr_min, dr_max, fl_min, fl_max,
+		hr_min, hr_max, ma_min, ma_max, pe_min, pe_max, oq_min, oq_max,
+		sr_min, sr_max, ut_min, ut_max, er_min, er_max,
+		container_type, inventory_type, specific_planet
+	FROM
+		resource_types`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	keyset, err := keyset(orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedResourceType
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbRes := make([]resourceType, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbRes[i] = k.resourceType
+	}
+
+	return toBusResourceTypes(dbRes), dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
 // Count returns the total number of resource types in the DB.
 func (s *Store) Count(ctx context.Context, filter resourcetypebus.QueryFilter) (int, error) {
 	data := map[string]any{}
@@ -167,6 +263,17 @@ func (s *Store) Count(ctx context.Context, filter resourcetypebus.QueryFilter) (
 
 // QueryByID gets the specified resource type from the database.
 func (s *Store) QueryByID(ctx context.Context, resourceTypeKey string) (resourcetypebus.ResourceType, error) {
+	dbRT, err := s.queryByID(ctx, s.db, resourceTypeKey)
+	if err != nil {
+		return resourcetypebus.ResourceType{}, err
+	}
+
+	return toBusResourceType(dbRT), nil
+}
+
+// queryByID gets the specified resource type from the database, or from
+// within a transaction when db is one.
+func (s *Store) queryByID(ctx context.Context, db sqlx.ExtContext, resourceTypeKey string) (resourceType, error) {
 	data := struct {
 		ResourceType string `db:"resource_type"`
 	}{
@@ -187,14 +294,14 @@ func (s *Store) QueryByID(ctx context.Context, resourceTypeKey string) (resource
 		resource_type = :resource_type`
 
 	var dbRT resourceType
-	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbRT); err != nil {
+	if err := sqldb.NamedQueryStruct(ctx, s.log, db, q, data, &dbRT); err != nil {
 		if errors.Is(err, sqldb.ErrDBNotFound) {
-			return resourcetypebus.ResourceType{}, fmt.Errorf("db: %w", resourcetypebus.ErrNotFound)
+			return resourceType{}, fmt.Errorf("db: %w", resourcetypebus.ErrNotFound)
 		}
-		return resourcetypebus.ResourceType{}, fmt.Errorf("db: %w", err)
+		return resourceType{}, fmt.Errorf("db: %w", err)
 	}
 
-	return toBusResourceType(dbRT), nil
+	return dbRT, nil
 }
 
 // BulkCreate inserts multiple resource types into the database in a single transaction.
@@ -222,12 +329,18 @@ func (s *Store) BulkCreate(ctx context.Context, resourceTypes []resourcetypebus.
 			 :container_type, :inventory_type, :specific_planet)`
 
 		for i, rt := range resourceTypes {
-			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBResourceType(rt)); err != nil {
+			dbRT := toDBResourceType(rt)
+
+			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbRT); err != nil {
 				if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
 					return fmt.Errorf("item[%d]: %w", i, resourcetypebus.ErrUniqueType)
 				}
 				return fmt.Errorf("item[%d]: %w", i, err)
 			}
+
+			if err := s.recorder.CreateWithTx(ctx, tx, rt.ResourceType, dbRT); err != nil {
+				return fmt.Errorf("item[%d]: audit: %w", i, err)
+			}
 		}
 		return nil
 	})
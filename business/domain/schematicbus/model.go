@@ -0,0 +1,54 @@
+package schematicbus
+
+import (
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/google/uuid"
+)
+
+// Schematic represents a crafting schematic and the resource slots it needs.
+type Schematic struct {
+	ID          uuid.UUID
+	Name        string
+	Slots       []Slot
+	DateCreated time.Time
+	DateUpdated time.Time
+}
+
+// Slot represents a single resource slot of a schematic. A slot accepts either
+// a specific resource type or any resource type within a resource group.
+type Slot struct {
+	Name          string
+	ResourceType  string
+	ResourceGroup string
+	Weights       resourcebus.Weights
+}
+
+// NewSchematic contains information needed to create a new schematic.
+type NewSchematic struct {
+	Name  string
+	Slots []Slot
+}
+
+// UpdateSchematic contains information needed to update a schematic. A nil
+// Slots leaves the slots of the schematic unchanged.
+type UpdateSchematic struct {
+	Name  *string
+	Slots []Slot
+}
+
+// =============================================================================
+
+// ScoreFilter holds the fields the candidate resources of a score can be
+// filtered on.
+type ScoreFilter struct {
+	GalaxyID  uuid.UUID
+	Available *bool
+}
+
+// SlotScore represents the best scoring candidate resources for a slot.
+type SlotScore struct {
+	Slot       Slot
+	Candidates []resourcebus.ScoredResource
+}
@@ -0,0 +1,13 @@
+package schematicbus
+
+import "github.com/godwinrob/harvester/business/sdk/order"
+
+// DefaultOrderBy represents the default way we sort.
+var DefaultOrderBy = order.NewBy(OrderByID, order.ASC)
+
+// Set of fields that the results can be ordered by.
+const (
+	OrderByID          = "schematic_id"
+	OrderByName        = "schematic_name"
+	OrderByDateCreated = "date_created"
+)
@@ -0,0 +1,169 @@
+package schematicbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/domain/resourcegroupbus"
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
+
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound             = errors.New("schematic not found")
+	ErrUniqueName           = errors.New("schematic name is not unique")
+	ErrUnknownResourceType  = errors.New("resource type does not exist")
+	ErrUnknownResourceGroup = errors.New("resource group does not exist")
+	ErrSlotSource           = errors.New("slot must name either a resource type or a resource group")
+)
+
+// Storer interface declares the behavior this package needs to perists and
+// retrieve data.
+type Storer interface {
+	Create(ctx context.Context, sch Schematic) error
+	Update(ctx context.Context, sch Schematic) error
+	Delete(ctx context.Context, sch Schematic) error
+	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Schematic, error)
+	QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Schematic, order.Cursor, error)
+	Count(ctx context.Context, filter QueryFilter) (int, error)
+	QueryByID(ctx context.Context, schematicID uuid.UUID) (Schematic, error)
+}
+
+// Business manages the set of APIs for schematic access.
+type Business struct {
+	log              *logger.Logger
+	resourceBus      *resourcebus.Business
+	resourceTypeBus  *resourcetypebus.Business
+	resourceGroupBus *resourcegroupbus.Business
+	storer           Storer
+}
+
+// NewBusiness constructs a schematic business API for use.
+func NewBusiness(log *logger.Logger, resourceBus *resourcebus.Business, resourceTypeBus *resourcetypebus.Business, resourceGroupBus *resourcegroupbus.Business, storer Storer) *Business {
+	return &Business{
+		log:              log,
+		resourceBus:      resourceBus,
+		resourceTypeBus:  resourceTypeBus,
+		resourceGroupBus: resourceGroupBus,
+		storer:           storer,
+	}
+}
+
+// Create adds a new schematic to the system.
+func (b *Business) Create(ctx context.Context, ns NewSchematic) (Schematic, error) {
+	ctx, span := otel.AddSpan(ctx, "business.schematicbus.create")
+	defer span.End()
+
+	if err := b.checkSlots(ctx, ns.Slots); err != nil {
+		return Schematic{}, fmt.Errorf("validate: %w", err)
+	}
+
+	now := time.Now()
+
+	sch := Schematic{
+		ID:          uuid.New(),
+		Name:        ns.Name,
+		Slots:       ns.Slots,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := b.storer.Create(ctx, sch); err != nil {
+		return Schematic{}, fmt.Errorf("create: %w", err)
+	}
+
+	return sch, nil
+}
+
+// Update modifies information about a schematic.
+func (b *Business) Update(ctx context.Context, sch Schematic, us UpdateSchematic) (Schematic, error) {
+	ctx, span := otel.AddSpan(ctx, "business.schematicbus.update")
+	defer span.End()
+
+	if us.Name != nil {
+		sch.Name = *us.Name
+	}
+
+	if us.Slots != nil {
+		if err := b.checkSlots(ctx, us.Slots); err != nil {
+			return Schematic{}, fmt.Errorf("validate: %w", err)
+		}
+		sch.Slots = us.Slots
+	}
+	sch.DateUpdated = time.Now()
+
+	if err := b.storer.Update(ctx, sch); err != nil {
+		return Schematic{}, fmt.Errorf("update: %w", err)
+	}
+
+	return sch, nil
+}
+
+// Delete removes the specified schematic.
+func (b *Business) Delete(ctx context.Context, sch Schematic) error {
+	ctx, span := otel.AddSpan(ctx, "business.schematicbus.delete")
+	defer span.End()
+
+	if err := b.storer.Delete(ctx, sch); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing schematics.
+func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]Schematic, error) {
+	ctx, span := otel.AddSpan(ctx, "business.schematicbus.query")
+	defer span.End()
+
+	schematics, err := b.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
+	if err != nil {
+		return nil, fmt.Errorf("query: %w", err)
+	}
+
+	return schematics, nil
+}
+
+// QueryAfter retrieves the page of schematics that follows the cursor in the
+// order, and the cursor for the page after it. The zero cursor starts at the
+// first schematic.
+func (b *Business) QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]Schematic, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.schematicbus.queryafter")
+	defer span.End()
+
+	schematics, next, err := b.storer.QueryAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryafter: %w", err)
+	}
+
+	return schematics, next, nil
+}
+
+// Count returns the total number of schematics.
+func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.schematicbus.count")
+	defer span.End()
+
+	return b.storer.Count(ctx, filter)
+}
+
+// QueryByID finds the schematic by the specified ID.
+func (b *Business) QueryByID(ctx context.Context, schematicID uuid.UUID) (Schematic, error) {
+	ctx, span := otel.AddSpan(ctx, "business.schematicbus.querybyid")
+	defer span.End()
+
+	sch, err := b.storer.QueryByID(ctx, schematicID)
+	if err != nil {
+		return Schematic{}, fmt.Errorf("query: schematicID[%s]: %w", schematicID, err)
+	}
+
+	return sch, nil
+}
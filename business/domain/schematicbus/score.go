@@ -0,0 +1,89 @@
+package schematicbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/domain/resourcegroupbus"
+	"github.com/godwinrob/harvester/business/domain/resourcetypebus"
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/godwinrob/harvester/foundation/validate"
+)
+
+// Score rates the resources of a galaxy against every slot of the schematic
+// and returns up to limit of the best candidates per slot, highest score
+// first. See resourcebus.ScoredResource for how a score is computed.
+func (b *Business) Score(ctx context.Context, sch Schematic, filter ScoreFilter, limit int) ([]SlotScore, error) {
+	ctx, span := otel.AddSpan(ctx, "business.schematicbus.score")
+	defer span.End()
+
+	scores := make([]SlotScore, len(sch.Slots))
+	for i, slot := range sch.Slots {
+		resFilter := resourcebus.QueryFilter{
+			GalaxyID:  &filter.GalaxyID,
+			Available: filter.Available,
+		}
+
+		switch {
+		case slot.ResourceType != "":
+			resFilter.ResourceType = &slot.ResourceType
+		default:
+			resFilter.ResourceGroup = &slot.ResourceGroup
+		}
+
+		candidates, err := b.resourceBus.QueryBest(ctx, resFilter, slot.Weights, limit)
+		if err != nil {
+			return nil, fmt.Errorf("querybest: slot[%d]: %w", i, err)
+		}
+
+		scores[i] = SlotScore{
+			Slot:       slot,
+			Candidates: candidates,
+		}
+	}
+
+	return scores, nil
+}
+
+// checkSlots validates the slots of a schematic. Every failing field is
+// reported in the returned validate.FieldErrors.
+func (b *Business) checkSlots(ctx context.Context, slots []Slot) error {
+	var fields validate.FieldErrors
+
+	for i, slot := range slots {
+		field := fmt.Sprintf("slots[%d]", i)
+
+		switch {
+		case (slot.ResourceType == "") == (slot.ResourceGroup == ""):
+			fields = append(fields, validate.FieldError{Field: field, Err: ErrSlotSource.Error()})
+
+		case slot.ResourceType != "":
+			if _, err := b.resourceTypeBus.QueryByID(ctx, slot.ResourceType); err != nil {
+				if !errors.Is(err, resourcetypebus.ErrNotFound) {
+					return fmt.Errorf("querybyid: resourceType[%s]: %w", slot.ResourceType, err)
+				}
+				fields = append(fields, validate.FieldError{Field: field + ".resourceType", Err: ErrUnknownResourceType.Error()})
+			}
+
+		default:
+			if _, err := b.resourceGroupBus.QueryByID(ctx, slot.ResourceGroup); err != nil {
+				if !errors.Is(err, resourcegroupbus.ErrNotFound) {
+					return fmt.Errorf("querybyid: resourceGroup[%s]: %w", slot.ResourceGroup, err)
+				}
+				fields = append(fields, validate.FieldError{Field: field + ".resourceGroup", Err: ErrUnknownResourceGroup.Error()})
+			}
+		}
+
+		if err := slot.Weights.Validate(); err != nil {
+			fields = append(fields, validate.FieldError{Field: field + ".weights", Err: err.Error()})
+		}
+	}
+
+	if len(fields) > 0 {
+		return fields
+	}
+
+	return nil
+}
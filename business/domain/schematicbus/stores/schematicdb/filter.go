@@ -0,0 +1,33 @@
+package schematicdb
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+)
+
+func applyFilter(filter schematicbus.QueryFilter, data map[string]interface{}, buf *bytes.Buffer) {
+	var wc []string
+
+	if filter.ID != nil {
+		data["schematic_id"] = *filter.ID
+		wc = append(wc, "schematic_id = :schematic_id")
+	}
+
+	if filter.Name != nil {
+		data["schematic_name"] = fmt.Sprintf("%%%s%%", *filter.Name)
+		wc = append(wc, "schematic_name ILIKE :schematic_name")
+	}
+
+	if filter.ResourceType != nil {
+		data["resource_type"] = *filter.ResourceType
+		wc = append(wc, "schematic_id IN (SELECT schematic_id FROM schematic_slots WHERE resource_type = :resource_type)")
+	}
+
+	if len(wc) > 0 {
+		buf.WriteString(" WHERE ")
+		buf.WriteString(strings.Join(wc, " AND "))
+	}
+}
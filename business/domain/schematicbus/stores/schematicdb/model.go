@@ -0,0 +1,124 @@
+package schematicdb
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/google/uuid"
+)
+
+type schematic struct {
+	ID          uuid.UUID `db:"schematic_id"`
+	Name        string    `db:"schematic_name"`
+	DateCreated time.Time `db:"date_created"`
+	DateUpdated time.Time `db:"date_updated"`
+}
+
+type keyedSchematic struct {
+	schematic
+	sqldb.Keyed
+}
+
+type slot struct {
+	SchematicID   uuid.UUID      `db:"schematic_id"`
+	Index         int16          `db:"slot_index"`
+	Name          string         `db:"slot_name"`
+	ResourceType  sql.NullString `db:"resource_type"`
+	ResourceGroup sql.NullString `db:"resource_group"`
+	CR            int16          `db:"cr"`
+	CD            int16          `db:"cd"`
+	DR            int16          `db:"dr"`
+	FL            int16          `db:"fl"`
+	HR            int16          `db:"hr"`
+	MA            int16          `db:"ma"`
+	PE            int16          `db:"pe"`
+	OQ            int16          `db:"oq"`
+	SR            int16          `db:"sr"`
+	UT            int16          `db:"ut"`
+	ER            int16          `db:"er"`
+}
+
+func toDBSchematic(bus schematicbus.Schematic) schematic {
+	return schematic{
+		ID:          bus.ID,
+		Name:        bus.Name,
+		DateCreated: bus.DateCreated.UTC(),
+		DateUpdated: bus.DateUpdated.UTC(),
+	}
+}
+
+func toDBSlot(schematicID uuid.UUID, index int, bus schematicbus.Slot) slot {
+	return slot{
+		SchematicID:   schematicID,
+		Index:         int16(index),
+		Name:          bus.Name,
+		ResourceType:  sql.NullString{String: bus.ResourceType, Valid: bus.ResourceType != ""},
+		ResourceGroup: sql.NullString{String: bus.ResourceGroup, Valid: bus.ResourceGroup != ""},
+		CR:            bus.Weights.CR,
+		CD:            bus.Weights.CD,
+		DR:            bus.Weights.DR,
+		FL:            bus.Weights.FL,
+		HR:            bus.Weights.HR,
+		MA:            bus.Weights.MA,
+		PE:            bus.Weights.PE,
+		OQ:            bus.Weights.OQ,
+		SR:            bus.Weights.SR,
+		UT:            bus.Weights.UT,
+		ER:            bus.Weights.ER,
+	}
+}
+
+func toBusSchematic(db schematic, dbSlots []slot) schematicbus.Schematic {
+	slots := make([]schematicbus.Slot, len(dbSlots))
+	for i, s := range dbSlots {
+		slots[i] = toBusSlot(s)
+	}
+
+	return schematicbus.Schematic{
+		ID:          db.ID,
+		Name:        db.Name,
+		Slots:       slots,
+		DateCreated: db.DateCreated.In(time.Local),
+		DateUpdated: db.DateUpdated.In(time.Local),
+	}
+}
+
+func toBusSlot(db slot) schematicbus.Slot {
+	return schematicbus.Slot{
+		Name:          db.Name,
+		ResourceType:  db.ResourceType.String,
+		ResourceGroup: db.ResourceGroup.String,
+		Weights: resourcebus.Weights{
+			CR: db.CR,
+			CD: db.CD,
+			DR: db.DR,
+			FL: db.FL,
+			HR: db.HR,
+			MA: db.MA,
+			PE: db.PE,
+			OQ: db.OQ,
+			SR: db.SR,
+			UT: db.UT,
+			ER: db.ER,
+		},
+	}
+}
+
+// toBusSchematics pairs each schematic with its slots. The slots are expected
+// to be ordered by slot index.
+func toBusSchematics(dbs []schematic, dbSlots []slot) []schematicbus.Schematic {
+	bySchematic := make(map[uuid.UUID][]slot, len(dbs))
+	for _, s := range dbSlots {
+		bySchematic[s.SchematicID] = append(bySchematic[s.SchematicID], s)
+	}
+
+	bus := make([]schematicbus.Schematic, len(dbs))
+	for i, db := range dbs {
+		bus[i] = toBusSchematic(db, bySchematic[db.ID])
+	}
+
+	return bus
+}
@@ -0,0 +1,33 @@
+package schematicdb
+
+import (
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+)
+
+var orderByFields = map[string]string{
+	schematicbus.OrderByID:          "schematic_id",
+	schematicbus.OrderByName:        "schematic_name",
+	schematicbus.OrderByDateCreated: "date_created",
+}
+
+func orderByClause(orderBy order.By) (string, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return "", fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return " ORDER BY " + by + " " + orderBy.Direction, nil
+}
+
+func keyset(orderBy order.By) (sqldb.Keyset, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return sqldb.Keyset{}, fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return sqldb.Keyset{Expr: by, ID: "schematic_id", Direction: orderBy.Direction}, nil
+}
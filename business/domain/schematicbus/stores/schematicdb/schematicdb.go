@@ -0,0 +1,315 @@
+package schematicdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/schematicbus"
+	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/foundation/logger"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for schematic database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the api for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// Create inserts a new schematic and its slots into the database.
+func (s *Store) Create(ctx context.Context, sch schematicbus.Schematic) error {
+	const q = `
+	INSERT INTO schematics
+		(schematic_id, schematic_name, date_created, date_updated)
+	VALUES
+		(:schematic_id, :schematic_name, :date_created, :date_updated)`
+
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("create requires *sqlx.DB")
+	}
+
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBSchematic(sch)); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return fmt.Errorf("namedexeccontext: %w", schematicbus.ErrUniqueName)
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.setSlots(ctx, tx, sch); err != nil {
+			return fmt.Errorf("setslots: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Update replaces a schematic and its slots in the database.
+func (s *Store) Update(ctx context.Context, sch schematicbus.Schematic) error {
+	const q = `
+	UPDATE
+		schematics
+	SET
+		"schematic_name" = :schematic_name,
+		"date_updated" = :date_updated
+	WHERE
+		schematic_id = :schematic_id`
+
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("update requires *sqlx.DB")
+	}
+
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBSchematic(sch)); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return schematicbus.ErrUniqueName
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.setSlots(ctx, tx, sch); err != nil {
+			return fmt.Errorf("setslots: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Delete removes a schematic and its slots from the database.
+func (s *Store) Delete(ctx context.Context, sch schematicbus.Schematic) error {
+	const q = `
+	DELETE FROM
+		schematics
+	WHERE
+		schematic_id = :schematic_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBSchematic(sch)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Query retrieves a list of existing schematics from the database.
+func (s *Store) Query(ctx context.Context, filter schematicbus.QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]schematicbus.Schematic, error) {
+	data := map[string]any{
+		"offset":        (pageNumber - 1) * rowsPerPage,
+		"rows_per_page": rowsPerPage,
+	}
+
+	const q = `
+	SELECT
+		schematic_id, schematic_name, date_created, date_updated
+	FROM
+		schematics`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	orderByClause, err := orderByClause(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	buf.WriteString(orderByClause)
+	buf.WriteString(" OFFSET :offset ROWS FETCH NEXT :rows_per_page ROWS ONLY")
+
+	var dbSchematics []schematic
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, buf.String(), data, &dbSchematics); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbSchematics) == 0 {
+		return []schematicbus.Schematic{}, nil
+	}
+
+	ids := make([]uuid.UUID, len(dbSchematics))
+	for i, sch := range dbSchematics {
+		ids[i] = sch.ID
+	}
+
+	dbSlots, err := s.querySlots(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("queryslots: %w", err)
+	}
+
+	return toBusSchematics(dbSchematics, dbSlots), nil
+}
+
+// QueryAfter retrieves the page of schematics that follows the cursor in the
+// order, along with the cursor that follows the last of them.
+func (s *Store) QueryAfter(ctx context.Context, filter schematicbus.QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]schematicbus.Schematic, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		schematic_id, schematic_name, date_created, date_updated
+	FROM
+		schematics`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	keyset, err := keyset(orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedSchematic
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbSchematics := make([]schematic, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbSchematics[i] = k.schematic
+	}
+
+	ids := make([]uuid.UUID, len(dbSchematics))
+	for i, sch := range dbSchematics {
+		ids[i] = sch.ID
+	}
+
+	dbSlots, err := s.querySlots(ctx, ids)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryslots: %w", err)
+	}
+
+	return toBusSchematics(dbSchematics, dbSlots), dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
+// Count returns the total number of schematics in the DB.
+func (s *Store) Count(ctx context.Context, filter schematicbus.QueryFilter) (int, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		count(1)
+	FROM
+		schematics`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	var count struct {
+		Count int `db:"count"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, buf.String(), data, &count); err != nil {
+		return 0, fmt.Errorf("db: %w", err)
+	}
+
+	return count.Count, nil
+}
+
+// QueryByID gets the specified schematic from the database.
+func (s *Store) QueryByID(ctx context.Context, schematicID uuid.UUID) (schematicbus.Schematic, error) {
+	data := struct {
+		ID string `db:"schematic_id"`
+	}{
+		ID: schematicID.String(),
+	}
+
+	const q = `
+	SELECT
+		schematic_id, schematic_name, date_created, date_updated
+	FROM
+		schematics
+	WHERE
+		schematic_id = :schematic_id`
+
+	var dbSchematic schematic
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbSchematic); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return schematicbus.Schematic{}, fmt.Errorf("db: %w", schematicbus.ErrNotFound)
+		}
+		return schematicbus.Schematic{}, fmt.Errorf("db: %w", err)
+	}
+
+	dbSlots, err := s.querySlots(ctx, []uuid.UUID{schematicID})
+	if err != nil {
+		return schematicbus.Schematic{}, fmt.Errorf("queryslots: %w", err)
+	}
+
+	return toBusSchematic(dbSchematic, dbSlots), nil
+}
+
+// =============================================================================
+
+func (s *Store) querySlots(ctx context.Context, schematicIDs []uuid.UUID) ([]slot, error) {
+	data := struct {
+		IDs []string `db:"ids"`
+	}{
+		IDs: make([]string, len(schematicIDs)),
+	}
+	for i, id := range schematicIDs {
+		data.IDs[i] = id.String()
+	}
+
+	const q = `
+	SELECT
+		schematic_id, slot_index, slot_name, resource_type, resource_group, cr, cd, dr, fl, hr, ma, pe, oq, sr, ut, er
+	FROM
+		schematic_slots
+	WHERE
+		schematic_id IN (:ids)
+	ORDER BY
+		schematic_id, slot_index`
+
+	var dbSlots []slot
+	if err := sqldb.NamedQuerySliceUsingIn(ctx, s.log, s.db, q, data, &dbSlots); err != nil {
+		return nil, fmt.Errorf("namedquerysliceusingin: %w", err)
+	}
+
+	return dbSlots, nil
+}
+
+func (s *Store) setSlots(ctx context.Context, tx *sqlx.Tx, sch schematicbus.Schematic) error {
+	data := struct {
+		ID string `db:"schematic_id"`
+	}{
+		ID: sch.ID.String(),
+	}
+
+	const qDelete = `
+	DELETE FROM
+		schematic_slots
+	WHERE
+		schematic_id = :schematic_id`
+
+	if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, qDelete, data); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	const qInsert = `
+	INSERT INTO schematic_slots
+		(schematic_id, slot_index, slot_name, resource_type, resource_group, cr, cd, dr, fl, hr, ma, pe, oq, sr, ut, er)
+	VALUES
+		(:schematic_id, :slot_index, :slot_name, :resource_type, :resource_group, :cr, :cd, :dr, :fl, :hr, :ma, :pe, :oq, :sr, :ut, :er)`
+
+	for i, sl := range sch.Slots {
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, qInsert, toDBSlot(sch.ID, i, sl)); err != nil {
+			return fmt.Errorf("insert: slot[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
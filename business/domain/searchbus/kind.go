@@ -0,0 +1,52 @@
+package searchbus
+
+import "fmt"
+
+type kindSet struct {
+	Resource      Kind
+	ResourceType  Kind
+	ResourceGroup Kind
+}
+
+// Kinds represents the set of things a search can find.
+var Kinds = kindSet{
+	Resource:      newKind("RESOURCE"),
+	ResourceType:  newKind("RESOURCE_TYPE"),
+	ResourceGroup: newKind("RESOURCE_GROUP"),
+}
+
+// Parse parses the string value and returns a kind if one exists.
+func (kindSet) Parse(value string) (Kind, error) {
+	kind, exists := kinds[value]
+	if !exists {
+		return Kind{}, fmt.Errorf("invalid kind %q", value)
+	}
+
+	return kind, nil
+}
+
+// =============================================================================
+
+// Set of known kinds.
+var kinds = make(map[string]Kind)
+
+// Kind represents the kind of thing a search hit names.
+type Kind struct {
+	name string
+}
+
+func newKind(kind string) Kind {
+	k := Kind{kind}
+	kinds[kind] = k
+	return k
+}
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	return k.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (k Kind) Equal(k2 Kind) bool {
+	return k.name == k2.name
+}
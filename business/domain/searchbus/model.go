@@ -0,0 +1,24 @@
+package searchbus
+
+import "github.com/google/uuid"
+
+// Query represents what to search for. Text is matched against resource,
+// resource type and resource group names, allowing for typos. GalaxyID limits
+// the resources searched to one galaxy.
+type Query struct {
+	Text     string
+	GalaxyID *uuid.UUID
+}
+
+// Hit represents one thing a search found. ID is the resource id, or the key
+// of the resource type or group. GalaxyID and ResourceType are only set for
+// resources. Score runs from 0 to 1, where 1 means the name contains Text
+// as typed.
+type Hit struct {
+	Kind         Kind
+	ID           string
+	Name         string
+	GalaxyID     uuid.UUID
+	ResourceType string
+	Score        float64
+}
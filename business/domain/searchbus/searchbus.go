@@ -0,0 +1,45 @@
+// Package searchbus provides business access to fuzzy name search across
+// resources, resource types and resource groups.
+package searchbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
+)
+
+// Storer interface declares the behavior this package needs to search the
+// stored data.
+type Storer interface {
+	Search(ctx context.Context, query Query, limit int) ([]Hit, error)
+}
+
+// Business manages the set of APIs for search.
+type Business struct {
+	log    *logger.Logger
+	storer Storer
+}
+
+// NewBusiness constructs a search business API for use.
+func NewBusiness(log *logger.Logger, storer Storer) *Business {
+	return &Business{
+		log:    log,
+		storer: storer,
+	}
+}
+
+// Search returns up to limit resources, resource types and resource groups
+// whose names match the query, best match first.
+func (b *Business) Search(ctx context.Context, query Query, limit int) ([]Hit, error) {
+	ctx, span := otel.AddSpan(ctx, "business.searchbus.search")
+	defer span.End()
+
+	hits, err := b.storer.Search(ctx, query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("search: %w", err)
+	}
+
+	return hits, nil
+}
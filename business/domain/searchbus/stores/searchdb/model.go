@@ -0,0 +1,47 @@
+package searchdb
+
+import (
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/searchbus"
+	"github.com/google/uuid"
+)
+
+type hit struct {
+	Kind         string        `db:"kind"`
+	ID           string        `db:"id"`
+	Name         string        `db:"name"`
+	GalaxyID     uuid.NullUUID `db:"galaxy_id"`
+	ResourceType string        `db:"resource_type"`
+	Score        float64       `db:"score"`
+}
+
+func toBusHit(db hit) (searchbus.Hit, error) {
+	kind, err := searchbus.Kinds.Parse(db.Kind)
+	if err != nil {
+		return searchbus.Hit{}, fmt.Errorf("parse kind: %w", err)
+	}
+
+	return searchbus.Hit{
+		Kind:         kind,
+		ID:           db.ID,
+		Name:         db.Name,
+		GalaxyID:     db.GalaxyID.UUID,
+		ResourceType: db.ResourceType,
+		Score:        db.Score,
+	}, nil
+}
+
+func toBusHits(dbs []hit) ([]searchbus.Hit, error) {
+	bus := make([]searchbus.Hit, len(dbs))
+
+	for i, db := range dbs {
+		var err error
+		bus[i], err = toBusHit(db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bus, nil
+}
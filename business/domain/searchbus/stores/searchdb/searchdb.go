@@ -0,0 +1,82 @@
+// Package searchdb contains search related CRUD functionality.
+package searchdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/searchbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for search database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the api for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// Search matches the query text against resource, resource type and resource
+// group names with pg_trgm word similarity, which the trigram indexes on the
+// name columns serve. A name matches when some run of words in it is close
+// enough to the text, so partial names and typos are found.
+func (s *Store) Search(ctx context.Context, query searchbus.Query, limit int) ([]searchbus.Hit, error) {
+	data := map[string]any{
+		"text":  query.Text,
+		"limit": limit,
+	}
+
+	galaxyFilter := ""
+	if query.GalaxyID != nil {
+		data["galaxy_id"] = *query.GalaxyID
+		galaxyFilter = " AND galaxy_id = :galaxy_id"
+	}
+
+	q := `
+	SELECT
+		kind, id, name, galaxy_id, resource_type, score
+	FROM (
+		SELECT
+			'RESOURCE' AS kind, CAST(resource_id AS TEXT) AS id, resource_name AS name, galaxy_id,
+			CAST(resource_type AS TEXT) AS resource_type, word_similarity(:text, resource_name) AS score
+		FROM
+			resources
+		WHERE
+			:text <% resource_name` + galaxyFilter + `
+		UNION ALL
+		SELECT
+			'RESOURCE_TYPE', resource_type, resource_type_name, NULL,
+			'', word_similarity(:text, resource_type_name)
+		FROM
+			resource_types
+		WHERE
+			:text <% resource_type_name
+		UNION ALL
+		SELECT
+			'RESOURCE_GROUP', resource_group, group_name, NULL,
+			'', word_similarity(:text, group_name)
+		FROM
+			resource_groups
+		WHERE
+			:text <% group_name
+	) AS hits
+	ORDER BY
+		score DESC, name
+	FETCH NEXT :limit ROWS ONLY`
+
+	var dbHits []hit
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbHits); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusHits(dbHits)
+}
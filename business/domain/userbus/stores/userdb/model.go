@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/godwinrob/harvester/business/domain/userbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
 	"github.com/godwinrob/harvester/business/sdk/sqldb/dbarray"
 	"github.com/google/uuid"
 )
@@ -23,6 +24,11 @@ type user struct {
 	DateUpdated  time.Time      `db:"date_updated"`
 }
 
+type keyedUser struct {
+	user
+	sqldb.Keyed
+}
+
 func toDBUser(bus userbus.User) user {
 	roles := make([]string, len(bus.Roles))
 	for i, role := range bus.Roles {
@@ -5,6 +5,7 @@ import (
 
 	"github.com/godwinrob/harvester/business/domain/userbus"
 	"github.com/godwinrob/harvester/business/sdk/order"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
 )
 
 var orderByFields = map[string]string{
@@ -12,7 +13,7 @@ var orderByFields = map[string]string{
 	userbus.OrderByName:        "name",
 	userbus.OrderByEmail:       "email",
 	userbus.OrderByRoles:       "roles",
-	userbus.OrderByGuild:       "guild",
+	userbus.OrderByGuild:       "COALESCE(guild, '')",
 	userbus.OrderByDateCreated: "date_created",
 	userbus.OrderByEnabled:     "enabled",
 }
@@ -25,3 +26,12 @@ func orderByClause(orderBy order.By) (string, error) {
 
 	return " ORDER BY " + by + " " + orderBy.Direction, nil
 }
+
+func keyset(orderBy order.By) (sqldb.Keyset, error) {
+	by, exists := orderByFields[orderBy.Field]
+	if !exists {
+		return sqldb.Keyset{}, fmt.Errorf("field %q does not exist", orderBy.Field)
+	}
+
+	return sqldb.Keyset{Expr: by, ID: "user_id", Direction: orderBy.Direction}, nil
+}
@@ -16,17 +16,28 @@ import (
 	"github.com/jmoiron/sqlx"
 )
 
+// Recorder records the audit entries of user mutations as part of the
+// transaction that makes them. Before and after are the database models of
+// the user.
+type Recorder interface {
+	CreateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, after any) error
+	UpdateWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any, after any) error
+	DeleteWithTx(ctx context.Context, tx *sqlx.Tx, entityID string, before any) error
+}
+
 // Store manages the set of APIs for user database access.
 type Store struct {
-	log *logger.Logger
-	db  sqlx.ExtContext
+	log      *logger.Logger
+	db       sqlx.ExtContext
+	recorder Recorder
 }
 
 // NewStore constructs the api for data access.
-func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+func NewStore(log *logger.Logger, db *sqlx.DB, recorder Recorder) *Store {
 	return &Store{
-		log: log,
-		db:  db,
+		log:      log,
+		db:       db,
+		recorder: recorder,
 	}
 }
 
@@ -38,14 +49,27 @@ func (s *Store) Create(ctx context.Context, usr userbus.User) error {
 	VALUES
 		(:user_id, :name, :email, :password_hash, :roles, :guild, :enabled, :date_created, :date_updated)`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBUser(usr)); err != nil {
-		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
-			return fmt.Errorf("namedexeccontext: %w", userbus.ErrUniqueEmail)
-		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("create requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbUsr := toDBUser(usr)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbUsr); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return fmt.Errorf("namedexeccontext: %w", userbus.ErrUniqueEmail)
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.recorder.CreateWithTx(ctx, tx, usr.ID.String(), dbUsr); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Update replaces a user document in the database.
@@ -64,14 +88,32 @@ func (s *Store) Update(ctx context.Context, usr userbus.User) error {
 	WHERE
 		user_id = :user_id`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBUser(usr)); err != nil {
-		if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
-			return userbus.ErrUniqueEmail
-		}
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("update requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		before, err := s.queryByID(ctx, tx, usr.ID)
+		if err != nil {
+			return fmt.Errorf("querybyid: %w", err)
+		}
+
+		dbUsr := toDBUser(usr)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbUsr); err != nil {
+			if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
+				return userbus.ErrUniqueEmail
+			}
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.recorder.UpdateWithTx(ctx, tx, usr.ID.String(), before, dbUsr); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Delete removes a user from the database.
@@ -82,11 +124,24 @@ func (s *Store) Delete(ctx context.Context, usr userbus.User) error {
 	WHERE
 		user_id = :user_id`
 
-	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBUser(usr)); err != nil {
-		return fmt.Errorf("namedexeccontext: %w", err)
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return errors.New("delete requires *sqlx.DB")
 	}
 
-	return nil
+	return sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+		dbUsr := toDBUser(usr)
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbUsr); err != nil {
+			return fmt.Errorf("namedexeccontext: %w", err)
+		}
+
+		if err := s.recorder.DeleteWithTx(ctx, tx, usr.ID.String(), dbUsr); err != nil {
+			return fmt.Errorf("audit: %w", err)
+		}
+
+		return nil
+	})
 }
 
 // Query retrieves a list of existing users from the database.
@@ -121,6 +176,47 @@ func (s *Store) Query(ctx context.Context, filter userbus.QueryFilter, orderBy o
 	return toBusUsers(dbUsrs)
 }
 
+// QueryAfter retrieves the page of users that follows the cursor in the
+// order, along with the cursor that follows the last of them.
+func (s *Store) QueryAfter(ctx context.Context, filter userbus.QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]userbus.User, order.Cursor, error) {
+	data := map[string]any{}
+
+	const q = `
+	SELECT
+		user_id, name, email, password_hash, roles, guild, enabled, date_created, date_updated
+	FROM
+		users`
+
+	buf := bytes.NewBufferString(q)
+	applyFilter(filter, data, buf)
+
+	keyset, err := keyset(orderBy)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	var dbKeyed []keyedUser
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, keyset.Query(buf.String(), cursor, rowsPerPage, data), data, &dbKeyed); err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	if len(dbKeyed) == 0 {
+		return nil, order.Cursor{}, nil
+	}
+
+	dbUsrs := make([]user, len(dbKeyed))
+	for i, k := range dbKeyed {
+		dbUsrs[i] = k.user
+	}
+
+	bus, err := toBusUsers(dbUsrs)
+	if err != nil {
+		return nil, order.Cursor{}, err
+	}
+
+	return bus, dbKeyed[len(dbKeyed)-1].Cursor(orderBy), nil
+}
+
 // Count returns the total number of users in the DB.
 func (s *Store) Count(ctx context.Context, filter userbus.QueryFilter) (int, error) {
 	data := map[string]any{}
@@ -146,6 +242,17 @@ func (s *Store) Count(ctx context.Context, filter userbus.QueryFilter) (int, err
 
 // QueryByID gets the specified user from the database.
 func (s *Store) QueryByID(ctx context.Context, userID uuid.UUID) (userbus.User, error) {
+	dbUsr, err := s.queryByID(ctx, s.db, userID)
+	if err != nil {
+		return userbus.User{}, err
+	}
+
+	return toBusUser(dbUsr)
+}
+
+// queryByID gets the specified user from the database, or from within a
+// transaction when db is one.
+func (s *Store) queryByID(ctx context.Context, db sqlx.ExtContext, userID uuid.UUID) (user, error) {
 	data := struct {
 		ID string `db:"user_id"`
 	}{
@@ -161,14 +268,14 @@ func (s *Store) QueryByID(ctx context.Context, userID uuid.UUID) (userbus.User,
 		user_id = :user_id`
 
 	var dbUsr user
-	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbUsr); err != nil {
+	if err := sqldb.NamedQueryStruct(ctx, s.log, db, q, data, &dbUsr); err != nil {
 		if errors.Is(err, sqldb.ErrDBNotFound) {
-			return userbus.User{}, fmt.Errorf("db: %w", userbus.ErrNotFound)
+			return user{}, fmt.Errorf("db: %w", userbus.ErrNotFound)
 		}
-		return userbus.User{}, fmt.Errorf("db: %w", err)
+		return user{}, fmt.Errorf("db: %w", err)
 	}
 
-	return toBusUser(dbUsr)
+	return dbUsr, nil
 }
 
 // QueryByEmail gets the specified user from the database by email.
@@ -213,12 +320,18 @@ func (s *Store) BulkCreate(ctx context.Context, users []userbus.User) error {
 			(:user_id, :name, :email, :password_hash, :roles, :guild, :enabled, :date_created, :date_updated)`
 
 		for i, usr := range users {
-			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBUser(usr)); err != nil {
+			dbUsr := toDBUser(usr)
+
+			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbUsr); err != nil {
 				if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
 					return fmt.Errorf("item[%d]: %w", i, userbus.ErrUniqueEmail)
 				}
 				return fmt.Errorf("item[%d]: %w", i, err)
 			}
+
+			if err := s.recorder.CreateWithTx(ctx, tx, usr.ID.String(), dbUsr); err != nil {
+				return fmt.Errorf("item[%d]: audit: %w", i, err)
+			}
 		}
 		return nil
 	})
@@ -247,12 +360,23 @@ func (s *Store) BulkUpdate(ctx context.Context, users []userbus.User) error {
 			user_id = :user_id`
 
 		for i, usr := range users {
-			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, toDBUser(usr)); err != nil {
+			before, err := s.queryByID(ctx, tx, usr.ID)
+			if err != nil {
+				return fmt.Errorf("item[%d]: querybyid: %w", i, err)
+			}
+
+			dbUsr := toDBUser(usr)
+
+			if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, q, dbUsr); err != nil {
 				if errors.Is(err, sqldb.ErrDBDuplicatedEntry) {
 					return fmt.Errorf("item[%d]: %w", i, userbus.ErrUniqueEmail)
 				}
 				return fmt.Errorf("item[%d]: %w", i, err)
 			}
+
+			if err := s.recorder.UpdateWithTx(ctx, tx, usr.ID.String(), before, dbUsr); err != nil {
+				return fmt.Errorf("item[%d]: audit: %w", i, err)
+			}
 		}
 		return nil
 	})
@@ -275,11 +399,30 @@ func (s *Store) BulkDelete(ctx context.Context, ids []uuid.UUID) error {
 			data.IDs[i] = id.String()
 		}
 
+		const qSelect = `
+		SELECT
+			user_id, name, email, password_hash, roles, guild, enabled, date_created, date_updated
+		FROM
+			users
+		WHERE
+			user_id IN (:ids)`
+
+		var dbUsrs []user
+		if err := sqldb.NamedQuerySliceUsingIn(ctx, s.log, tx, qSelect, data, &dbUsrs); err != nil {
+			return fmt.Errorf("namedqueryslice: %w", err)
+		}
+
 		const q = `DELETE FROM users WHERE user_id IN (:ids)`
 
 		if err := sqldb.NamedExecContextUsingInWithTx(ctx, s.log, tx, q, data); err != nil {
 			return fmt.Errorf("namedexeccontextusingintx: %w", err)
 		}
+
+		for _, dbUsr := range dbUsrs {
+			if err := s.recorder.DeleteWithTx(ctx, tx, dbUsr.ID.String(), dbUsr); err != nil {
+				return fmt.Errorf("userID[%s]: audit: %w", dbUsr.ID, err)
+			}
+		}
 		return nil
 	})
 }
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
 	"github.com/google/uuid"
 	"net/mail"
 	"time"
@@ -19,6 +20,7 @@ var (
 	ErrNotFound              = errors.New("user not found")
 	ErrUniqueEmail           = errors.New("email is not unique")
 	ErrAuthenticationFailure = errors.New("authentication failed")
+	ErrUserDisabled          = errors.New("user disabled")
 )
 
 // Storer interface declares the behavior this package needs to perists and
@@ -28,6 +30,7 @@ type Storer interface {
 	Update(ctx context.Context, usr User) error
 	Delete(ctx context.Context, usr User) error
 	Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]User, error)
+	QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]User, order.Cursor, error)
 	Count(ctx context.Context, filter QueryFilter) (int, error)
 	QueryByID(ctx context.Context, userID uuid.UUID) (User, error)
 	QueryByEmail(ctx context.Context, email mail.Address) (User, error)
@@ -52,6 +55,9 @@ func NewBusiness(log *logger.Logger, storer Storer) *Business {
 
 // Create adds a new user to the system.
 func (b *Business) Create(ctx context.Context, nu NewUser) (User, error) {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.create")
+	defer span.End()
+
 	hash, err := bcrypt.GenerateFromPassword([]byte(nu.Password), bcrypt.DefaultCost)
 	if err != nil {
 		return User{}, fmt.Errorf("generatefrompassword: %w", err)
@@ -80,6 +86,9 @@ func (b *Business) Create(ctx context.Context, nu NewUser) (User, error) {
 
 // Update modifies information about a user.
 func (b *Business) Update(ctx context.Context, usr User, uu UpdateUser) (User, error) {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.update")
+	defer span.End()
+
 	if uu.Name != nil {
 		usr.Name = *uu.Name
 	}
@@ -118,6 +127,9 @@ func (b *Business) Update(ctx context.Context, usr User, uu UpdateUser) (User, e
 
 // Delete removes the specified user.
 func (b *Business) Delete(ctx context.Context, usr User) error {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.delete")
+	defer span.End()
+
 	if err := b.storer.Delete(ctx, usr); err != nil {
 		return fmt.Errorf("delete: %w", err)
 	}
@@ -127,7 +139,8 @@ func (b *Business) Delete(ctx context.Context, usr User) error {
 
 // Query retrieves a list of existing users.
 func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.By, pageNumber int, rowsPerPage int) ([]User, error) {
-	// TODO: DON'T ALLOW MORE THAN N RECORDS REGARDLESS
+	ctx, span := otel.AddSpan(ctx, "business.userbus.query")
+	defer span.End()
 
 	users, err := b.storer.Query(ctx, filter, orderBy, pageNumber, rowsPerPage)
 	if err != nil {
@@ -137,13 +150,34 @@ func (b *Business) Query(ctx context.Context, filter QueryFilter, orderBy order.
 	return users, nil
 }
 
+// QueryAfter retrieves the page of users that follows the cursor in the
+// order, and the cursor for the page after it. The zero cursor starts at the
+// first user.
+func (b *Business) QueryAfter(ctx context.Context, filter QueryFilter, orderBy order.By, cursor order.Cursor, rowsPerPage int) ([]User, order.Cursor, error) {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.queryafter")
+	defer span.End()
+
+	users, next, err := b.storer.QueryAfter(ctx, filter, orderBy, cursor, rowsPerPage)
+	if err != nil {
+		return nil, order.Cursor{}, fmt.Errorf("queryafter: %w", err)
+	}
+
+	return users, next, nil
+}
+
 // Count returns the total number of users.
 func (b *Business) Count(ctx context.Context, filter QueryFilter) (int, error) {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.count")
+	defer span.End()
+
 	return b.storer.Count(ctx, filter)
 }
 
 // QueryByID finds the user by the specified Ib.
 func (b *Business) QueryByID(ctx context.Context, userID uuid.UUID) (User, error) {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.querybyid")
+	defer span.End()
+
 	user, err := b.storer.QueryByID(ctx, userID)
 	if err != nil {
 		return User{}, fmt.Errorf("query: userID[%s]: %w", userID, err)
@@ -154,6 +188,9 @@ func (b *Business) QueryByID(ctx context.Context, userID uuid.UUID) (User, error
 
 // QueryByEmail finds the user by a specified user email.
 func (b *Business) QueryByEmail(ctx context.Context, email mail.Address) (User, error) {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.querybyemail")
+	defer span.End()
+
 	user, err := b.storer.QueryByEmail(ctx, email)
 	if err != nil {
 		return User{}, fmt.Errorf("query: email[%s]: %w", email, err)
@@ -162,8 +199,33 @@ func (b *Business) QueryByEmail(ctx context.Context, email mail.Address) (User,
 	return user, nil
 }
 
+// Authenticate finds a user by their email and verifies their password. On
+// success it returns a User representing this user.
+func (b *Business) Authenticate(ctx context.Context, email mail.Address, password string) (User, error) {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.authenticate")
+	defer span.End()
+
+	usr, err := b.QueryByEmail(ctx, email)
+	if err != nil {
+		return User{}, fmt.Errorf("query: email[%s]: %w", email, err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(usr.PasswordHash, []byte(password)); err != nil {
+		return User{}, fmt.Errorf("comparehashandpassword: %w", ErrAuthenticationFailure)
+	}
+
+	if !usr.Enabled {
+		return User{}, fmt.Errorf("authenticate: userID[%s]: %w", usr.ID, ErrUserDisabled)
+	}
+
+	return usr, nil
+}
+
 // BulkCreate adds multiple new users to the system in a single transaction.
 func (b *Business) BulkCreate(ctx context.Context, newUsers []NewUser) ([]User, error) {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.bulkcreate")
+	defer span.End()
+
 	users := make([]User, len(newUsers))
 	now := time.Now()
 
@@ -195,6 +257,9 @@ func (b *Business) BulkCreate(ctx context.Context, newUsers []NewUser) ([]User,
 
 // BulkUpdate modifies multiple users in a single transaction.
 func (b *Business) BulkUpdate(ctx context.Context, updates []UpdateUserWithID) ([]User, error) {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.bulkupdate")
+	defer span.End()
+
 	users := make([]User, len(updates))
 
 	for i, upd := range updates {
@@ -239,6 +304,9 @@ func (b *Business) BulkUpdate(ctx context.Context, updates []UpdateUserWithID) (
 
 // BulkDelete removes multiple users in a single transaction.
 func (b *Business) BulkDelete(ctx context.Context, ids []uuid.UUID) error {
+	ctx, span := otel.AddSpan(ctx, "business.userbus.bulkdelete")
+	defer span.End()
+
 	if err := b.storer.BulkDelete(ctx, ids); err != nil {
 		return fmt.Errorf("bulkdelete: %w", err)
 	}
@@ -0,0 +1,234 @@
+package webhookbus
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/google/uuid"
+)
+
+// Set of headers sent with every delivery.
+const (
+	HeaderEvent     = "X-Harvester-Event"
+	HeaderDelivery  = "X-Harvester-Delivery"
+	HeaderTimestamp = "X-Harvester-Timestamp"
+	HeaderSignature = "X-Harvester-Signature"
+)
+
+// Bounds of the delay between attempts of a delivery.
+const (
+	minBackoff = 30 * time.Second
+	maxBackoff = time.Hour
+)
+
+// DispatcherConfig represents the settings of the outbox dispatcher.
+type DispatcherConfig struct {
+	Interval    time.Duration
+	BatchSize   int
+	MaxAttempts int
+	Timeout     time.Duration
+}
+
+// Dispatcher sends the deliveries queued in the outbox. Several dispatchers
+// can share the outbox: each claims its batch for the length of the request
+// timeout before sending it.
+type Dispatcher struct {
+	log    *logger.Logger
+	storer Storer
+	client *http.Client
+	cfg    DispatcherConfig
+}
+
+// NewDispatcher constructs a dispatcher for the outbox.
+func NewDispatcher(log *logger.Logger, storer Storer, cfg DispatcherConfig) *Dispatcher {
+	return &Dispatcher{
+		log:    log,
+		storer: storer,
+		client: &http.Client{Timeout: cfg.Timeout},
+		cfg:    cfg,
+	}
+}
+
+// Run sends due deliveries every interval until the context is canceled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the outbox before waiting for the next tick.
+		for {
+			n, err := d.Dispatch(ctx)
+			if err != nil {
+				d.log.Error(ctx, "webhookbus: dispatch", "ERROR", err)
+			}
+			if err != nil || n < d.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Dispatch claims and sends one batch of due deliveries. It returns the
+// number of deliveries attempted.
+func (d *Dispatcher) Dispatch(ctx context.Context) (int, error) {
+	if ctx.Err() != nil {
+		return 0, nil
+	}
+
+	// The lease covers the attempts of the whole batch, so a delivery is
+	// only claimed again if this dispatcher dies while sending it.
+	lease := d.cfg.Timeout * time.Duration(d.cfg.BatchSize+1)
+
+	deliveries, err := d.storer.ClaimDeliveries(ctx, time.Now(), lease, d.cfg.BatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claimdeliveries: %w", err)
+	}
+
+	webhooks := make(map[uuid.UUID]Webhook)
+
+	for _, dl := range deliveries {
+		wh, exists := webhooks[dl.WebhookID]
+		if !exists {
+			wh, err = d.storer.QueryByID(ctx, dl.WebhookID)
+			if err != nil {
+				// The deliveries of a deleted webhook are removed with it.
+				if errors.Is(err, ErrNotFound) {
+					continue
+				}
+				return 0, fmt.Errorf("querybyid: webhookID[%s]: %w", dl.WebhookID, err)
+			}
+			webhooks[wh.ID] = wh
+		}
+
+		d.attempt(ctx, wh, dl)
+	}
+
+	return len(deliveries), nil
+}
+
+// attempt sends the delivery once and records the outcome. Failed attempts
+// are retried with an exponential backoff, honouring Retry-After, until the
+// delivery runs out of attempts or the receiver rejects it outright.
+func (d *Dispatcher) attempt(ctx context.Context, wh Webhook, dl Delivery) {
+	now := time.Now()
+	dl.Attempts++
+
+	retryAfter, permanent, err := d.send(ctx, wh, dl, now)
+
+	switch {
+	case err == nil:
+		dl.Status = Statuses.Delivered
+		dl.LastError = ""
+		dl.DateDelivered = now
+
+	case permanent || dl.Attempts >= d.cfg.MaxAttempts:
+		dl.Status = Statuses.Failed
+		dl.LastError = err.Error()
+
+	default:
+		dl.LastError = err.Error()
+		dl.NextAttempt = now.Add(max(backoff(dl.Attempts), retryAfter))
+	}
+
+	if err != nil {
+		d.log.Info(ctx, "webhookbus: delivery failed", "deliveryID", dl.ID, "webhookID", wh.ID, "attempts", dl.Attempts, "status", dl.Status, "ERROR", err)
+	}
+
+	if err := d.storer.UpdateDelivery(ctx, dl); err != nil {
+		d.log.Error(ctx, "webhookbus: updatedelivery", "deliveryID", dl.ID, "ERROR", err)
+	}
+}
+
+// send posts the payload to the webhook. A non-nil error is returned for
+// every unsuccessful attempt; permanent reports whether retrying is pointless.
+func (d *Dispatcher) send(ctx context.Context, wh Webhook, dl Delivery, now time.Time) (retryAfter time.Duration, permanent bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, wh.URL, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return 0, true, fmt.Errorf("new request: %w", err)
+	}
+
+	timestamp := now.Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Harvester-Webhook")
+	req.Header.Set(HeaderEvent, dl.Event.String())
+	req.Header.Set(HeaderDelivery, dl.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(wh.Secret, timestamp, dl.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, false, fmt.Errorf("post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection can be reused.
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, false, nil
+	}
+
+	err = fmt.Errorf("post: status %s", resp.Status)
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusRequestTimeout:
+		return parseRetryAfter(resp.Header.Get("Retry-After")), false, err
+	}
+
+	return 0, resp.StatusCode < 500, err
+}
+
+// Sign returns the signature of a delivery: the hex encoded HMAC-SHA256 of
+// "<timestamp>.<body>" keyed by the webhook secret, prefixed with "sha256=".
+// Receivers recompute it to check a delivery came from this service.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the delay after the given number of failed attempts.
+func backoff(attempts int) time.Duration {
+	delay := minBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxBackoff)
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds. Discord sends
+// fractional seconds. The delay is capped at maxBackoff, so a receiver can't
+// park a delivery indefinitely.
+func parseRetryAfter(value string) time.Duration {
+	secs, err := strconv.ParseFloat(value, 64)
+	if err != nil || secs < 0 {
+		return 0
+	}
+
+	if secs >= maxBackoff.Seconds() {
+		return maxBackoff
+	}
+
+	return time.Duration(secs * float64(time.Second))
+}
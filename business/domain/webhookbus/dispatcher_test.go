@@ -0,0 +1,359 @@
+package webhookbus
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/google/uuid"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{"body", "secret", 1700000000, `{"a":1}`, "sha256=49f24e537407743fa4a0242bb63b94b9a47ee99cbbe071ccd8a22550ae411686"},
+		{"other secret", "other", 1700000000, `{"a":1}`, "sha256=2cb38bd50b3aa61b12df512da616c9577f2a99edb9467110d361a655e1ad3bd5"},
+		{"empty body", "secret", 0, "", "sha256=3445798a051818ef95def46c2eb62b43d377ce6e3c29b4d0aec3da0e59577f79"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("Sign() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, minBackoff},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{7, 32 * time.Minute},
+		{8, maxBackoff},
+		{100, maxBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.attempts), func(t *testing.T) {
+			if got := backoff(tt.attempts); got != tt.want {
+				t.Errorf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"soon", 0},
+		{"-5", 0},
+		{"Wed, 21 Oct 2015 07:28:00 GMT", 0},
+		{"0", 0},
+		{"5", 5 * time.Second},
+		{"1.5", 1500 * time.Millisecond},
+		{"3600", maxBackoff},
+		{"86400", maxBackoff},
+		{"1e300", maxBackoff},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseRetryAfter(tt.value); got != tt.want {
+				t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		retryAfter  string
+		attempts    int
+		maxAttempts int
+		wantStatus  Status
+		wantDelay   time.Duration
+	}{
+		{"delivered", http.StatusNoContent, "", 0, 3, Statuses.Delivered, 0},
+		{"server error retried", http.StatusBadGateway, "", 0, 3, Statuses.Pending, 30 * time.Second},
+		{"server error backs off", http.StatusBadGateway, "", 2, 5, Statuses.Pending, 2 * time.Minute},
+		{"rate limited", http.StatusTooManyRequests, "120", 0, 3, Statuses.Pending, 2 * time.Minute},
+		{"rate limited briefly", http.StatusTooManyRequests, "1", 0, 3, Statuses.Pending, 30 * time.Second},
+		{"rate limited too long", http.StatusTooManyRequests, "86400", 0, 3, Statuses.Pending, maxBackoff},
+		{"rejected", http.StatusNotFound, "", 0, 3, Statuses.Failed, 0},
+		{"out of attempts", http.StatusBadGateway, "", 2, 3, Statuses.Failed, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := []byte(`{"content":"New resource in Test"}`)
+
+			var got *http.Request
+			var gotBody []byte
+
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				gotBody, _ = io.ReadAll(r.Body)
+
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			wh := Webhook{
+				ID:     uuid.New(),
+				URL:    srv.URL,
+				Secret: "secret",
+				Events: []Event{Events.Created},
+			}
+
+			dl := Delivery{
+				ID:          uuid.New(),
+				WebhookID:   wh.ID,
+				Event:       Events.Created,
+				Payload:     payload,
+				Status:      Statuses.Pending,
+				Attempts:    tt.attempts,
+				NextAttempt: time.Now(),
+			}
+
+			storer := newMemoryStorer(wh, dl)
+
+			d := NewDispatcher(testLog(), storer, DispatcherConfig{
+				Interval:    time.Second,
+				BatchSize:   10,
+				MaxAttempts: tt.maxAttempts,
+				Timeout:     5 * time.Second,
+			})
+
+			start := time.Now()
+
+			n, err := d.Dispatch(context.Background())
+			if err != nil {
+				t.Fatalf("Dispatch: %s", err)
+			}
+			if n != 1 {
+				t.Fatalf("Dispatch attempted %d deliveries, want 1", n)
+			}
+
+			if got == nil {
+				t.Fatal("receiver got no request")
+			}
+
+			if string(gotBody) != string(payload) {
+				t.Errorf("body = %s, want %s", gotBody, payload)
+			}
+
+			if h := got.Header.Get(HeaderEvent); h != Events.Created.String() {
+				t.Errorf("%s = %q, want %q", HeaderEvent, h, Events.Created)
+			}
+
+			if h := got.Header.Get(HeaderDelivery); h != dl.ID.String() {
+				t.Errorf("%s = %q, want %q", HeaderDelivery, h, dl.ID)
+			}
+
+			timestamp, err := strconv.ParseInt(got.Header.Get(HeaderTimestamp), 10, 64)
+			if err != nil {
+				t.Fatalf("%s: %s", HeaderTimestamp, err)
+			}
+
+			if h := got.Header.Get(HeaderSignature); h != Sign(wh.Secret, timestamp, gotBody) {
+				t.Errorf("%s = %q does not sign the body", HeaderSignature, h)
+			}
+
+			upd := storer.delivery(dl.ID)
+
+			if upd.Attempts != tt.attempts+1 {
+				t.Errorf("attempts = %d, want %d", upd.Attempts, tt.attempts+1)
+			}
+
+			if !upd.Status.Equal(tt.wantStatus) {
+				t.Errorf("status = %s, want %s", upd.Status, tt.wantStatus)
+			}
+
+			switch {
+			case tt.wantStatus.Equal(Statuses.Delivered):
+				if upd.DateDelivered.IsZero() || upd.LastError != "" {
+					t.Errorf("delivered at %s with error %q, want a time and no error", upd.DateDelivered, upd.LastError)
+				}
+
+			default:
+				if upd.LastError == "" {
+					t.Error("last error is empty")
+				}
+			}
+
+			if tt.wantStatus.Equal(Statuses.Pending) {
+				delay := upd.NextAttempt.Sub(start)
+				if delay < tt.wantDelay || delay > tt.wantDelay+5*time.Second {
+					t.Errorf("next attempt in %s, want %s", delay, tt.wantDelay)
+				}
+			}
+		})
+	}
+}
+
+func TestDispatchDeletedWebhook(t *testing.T) {
+	dl := Delivery{
+		ID:          uuid.New(),
+		WebhookID:   uuid.New(),
+		Event:       Events.Created,
+		Status:      Statuses.Pending,
+		NextAttempt: time.Now(),
+	}
+
+	storer := newMemoryStorer(Webhook{}, dl)
+	delete(storer.webhooks, dl.WebhookID)
+
+	d := NewDispatcher(testLog(), storer, DispatcherConfig{BatchSize: 10, MaxAttempts: 3, Timeout: time.Second})
+
+	if _, err := d.Dispatch(context.Background()); err != nil {
+		t.Fatalf("Dispatch: %s", err)
+	}
+
+	if upd := storer.delivery(dl.ID); upd.Attempts != 0 {
+		t.Errorf("attempts = %d, want the delivery skipped", upd.Attempts)
+	}
+}
+
+// =============================================================================
+
+func testLog() *logger.Logger {
+	return logger.New(io.Discard, logger.LevelError, "TEST", func(context.Context) string { return "" })
+}
+
+// memoryStorer keeps webhooks and deliveries in memory for the dispatcher.
+type memoryStorer struct {
+	mu         sync.Mutex
+	webhooks   map[uuid.UUID]Webhook
+	deliveries map[uuid.UUID]Delivery
+}
+
+func newMemoryStorer(wh Webhook, deliveries ...Delivery) *memoryStorer {
+	s := memoryStorer{
+		webhooks:   map[uuid.UUID]Webhook{wh.ID: wh},
+		deliveries: make(map[uuid.UUID]Delivery),
+	}
+
+	for _, dl := range deliveries {
+		s.deliveries[dl.ID] = dl
+	}
+
+	return &s
+}
+
+func (s *memoryStorer) delivery(id uuid.UUID) Delivery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.deliveries[id]
+}
+
+func (s *memoryStorer) Create(ctx context.Context, wh Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.webhooks[wh.ID] = wh
+	return nil
+}
+
+func (s *memoryStorer) Delete(ctx context.Context, wh Webhook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.webhooks, wh.ID)
+	return nil
+}
+
+func (s *memoryStorer) QueryByID(ctx context.Context, webhookID uuid.UUID) (Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	wh, exists := s.webhooks[webhookID]
+	if !exists {
+		return Webhook{}, ErrNotFound
+	}
+
+	return wh, nil
+}
+
+func (s *memoryStorer) QueryByGalaxy(ctx context.Context, galaxyID uuid.UUID) ([]Webhook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var whs []Webhook
+	for _, wh := range s.webhooks {
+		if wh.GalaxyID == galaxyID {
+			whs = append(whs, wh)
+		}
+	}
+
+	return whs, nil
+}
+
+func (s *memoryStorer) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var claimed []Delivery
+	for id, dl := range s.deliveries {
+		if len(claimed) == limit {
+			break
+		}
+
+		if !dl.Status.Equal(Statuses.Pending) || dl.NextAttempt.After(now) {
+			continue
+		}
+
+		dl.NextAttempt = now.Add(lease)
+		s.deliveries[id] = dl
+		claimed = append(claimed, dl)
+	}
+
+	return claimed, nil
+}
+
+func (s *memoryStorer) UpdateDelivery(ctx context.Context, d Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.deliveries[d.ID] = d
+	return nil
+}
+
+func (s *memoryStorer) QueryDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deliveries []Delivery
+	for _, dl := range s.deliveries {
+		if dl.WebhookID == webhookID && len(deliveries) < limit {
+			deliveries = append(deliveries, dl)
+		}
+	}
+
+	return deliveries, nil
+}
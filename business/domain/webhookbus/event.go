@@ -0,0 +1,79 @@
+package webhookbus
+
+import "fmt"
+
+type eventSet struct {
+	Created   Event
+	Verified  Event
+	Despawned Event
+}
+
+// Events represents the set of resource events a webhook can subscribe to.
+var Events = eventSet{
+	Created:   newEvent("resource.created"),
+	Verified:  newEvent("resource.verified"),
+	Despawned: newEvent("resource.despawned"),
+}
+
+// Parse parses the string value and returns an event if one exists.
+func (eventSet) Parse(value string) (Event, error) {
+	event, exists := events[value]
+	if !exists {
+		return Event{}, fmt.Errorf("invalid event %q", value)
+	}
+
+	return event, nil
+}
+
+// MustParse parses the string value and returns an event if one exists. If
+// an error occurs the function panics.
+func (eventSet) MustParse(value string) Event {
+	event, err := Events.Parse(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return event
+}
+
+// =============================================================================
+
+// Set of known events.
+var events = make(map[string]Event)
+
+// Event represents a resource event delivered to webhooks.
+type Event struct {
+	name string
+}
+
+func newEvent(event string) Event {
+	e := Event{event}
+	events[event] = e
+	return e
+}
+
+// String returns the name of the event.
+func (e Event) String() string {
+	return e.name
+}
+
+// UnmarshalText implement the unmarshal interface for JSON conversions.
+func (e *Event) UnmarshalText(data []byte) error {
+	event, err := Events.Parse(string(data))
+	if err != nil {
+		return err
+	}
+
+	e.name = event.name
+	return nil
+}
+
+// MarshalText implement the marshal interface for JSON conversions.
+func (e Event) MarshalText() ([]byte, error) {
+	return []byte(e.name), nil
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (e Event) Equal(e2 Event) bool {
+	return e.name == e2.name
+}
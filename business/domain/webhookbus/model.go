@@ -0,0 +1,50 @@
+package webhookbus
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Webhook represents a subscription of an external URL to the resource
+// events of a galaxy. Every delivery is signed with Secret.
+type Webhook struct {
+	ID          uuid.UUID
+	GalaxyID    uuid.UUID
+	URL         string
+	Secret      string
+	Events      []Event
+	CreatedBy   uuid.UUID
+	DateCreated time.Time
+	DateUpdated time.Time
+}
+
+// Subscribed reports whether the webhook receives the event.
+func (w Webhook) Subscribed(event Event) bool {
+	return slices.ContainsFunc(w.Events, event.Equal)
+}
+
+// NewWebhook contains information needed to create a new webhook.
+type NewWebhook struct {
+	GalaxyID  uuid.UUID
+	URL       string
+	Events    []Event
+	CreatedBy uuid.UUID
+}
+
+// Delivery represents one payload queued in the outbox for a webhook. A
+// delivery is attempted until it is delivered or MaxAttempts is reached;
+// NextAttempt holds the earliest time of the next attempt.
+type Delivery struct {
+	ID            uuid.UUID
+	WebhookID     uuid.UUID
+	Event         Event
+	Payload       []byte
+	Status        Status
+	Attempts      int
+	NextAttempt   time.Time
+	LastError     string
+	DateCreated   time.Time
+	DateDelivered time.Time
+}
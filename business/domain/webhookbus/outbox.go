@@ -0,0 +1,53 @@
+package webhookbus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/google/uuid"
+)
+
+// NewDeliveries builds the deliveries of an event on resources of one galaxy
+// for the webhooks of the galaxy subscribed to it, splitting the resources
+// into messages of at most maxEmbeds. The store queues them in the outbox as
+// part of the transaction that changes the resources, so they survive a
+// restart of the service and are sent by the Dispatcher.
+func NewDeliveries(event Event, galaxyName string, webhooks []Webhook, resources []resourcebus.Resource) ([]Delivery, error) {
+	var subscribed []Webhook
+	for _, wh := range webhooks {
+		if wh.Subscribed(event) {
+			subscribed = append(subscribed, wh)
+		}
+	}
+
+	if len(subscribed) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+
+	var deliveries []Delivery
+	for start := 0; start < len(resources); start += maxEmbeds {
+		end := min(start+maxEmbeds, len(resources))
+
+		payload, err := newPayload(event, galaxyName, resources[start:end])
+		if err != nil {
+			return nil, fmt.Errorf("newpayload: %w", err)
+		}
+
+		for _, wh := range subscribed {
+			deliveries = append(deliveries, Delivery{
+				ID:          uuid.New(),
+				WebhookID:   wh.ID,
+				Event:       event,
+				Payload:     payload,
+				Status:      Statuses.Pending,
+				NextAttempt: now,
+				DateCreated: now,
+			})
+		}
+	}
+
+	return deliveries, nil
+}
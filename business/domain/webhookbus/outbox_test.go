@@ -0,0 +1,82 @@
+package webhookbus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/google/uuid"
+)
+
+func TestNewDeliveries(t *testing.T) {
+	created := Webhook{ID: uuid.New(), Events: []Event{Events.Created}}
+	both := Webhook{ID: uuid.New(), Events: []Event{Events.Created, Events.Despawned}}
+	verified := Webhook{ID: uuid.New(), Events: []Event{Events.Verified}}
+
+	tests := []struct {
+		name      string
+		event     Event
+		webhooks  []Webhook
+		resources int
+		want      map[uuid.UUID][]int
+	}{
+		{"no webhooks", Events.Created, nil, 1, nil},
+		{"none subscribed", Events.Despawned, []Webhook{created, verified}, 3, nil},
+		{"one resource", Events.Created, []Webhook{created, verified}, 1, map[uuid.UUID][]int{created.ID: {1}}},
+		{"every subscriber", Events.Created, []Webhook{created, both, verified}, 2, map[uuid.UUID][]int{created.ID: {2}, both.ID: {2}}},
+		{"split at max embeds", Events.Despawned, []Webhook{both}, maxEmbeds + 1, map[uuid.UUID][]int{both.ID: {maxEmbeds, 1}}},
+		{"exactly max embeds", Events.Verified, []Webhook{verified}, maxEmbeds, map[uuid.UUID][]int{verified.ID: {maxEmbeds}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources := make([]resourcebus.Resource, tt.resources)
+			for i := range resources {
+				resources[i] = resourcebus.Resource{
+					ID:           uuid.New(),
+					Name:         resourcebus.Names.MustParse("Resource"),
+					ResourceType: "iron_polonium",
+					OQ:           500,
+				}
+			}
+
+			deliveries, err := NewDeliveries(tt.event, "Test", tt.webhooks, resources)
+			if err != nil {
+				t.Fatalf("NewDeliveries: %s", err)
+			}
+
+			got := make(map[uuid.UUID][]int)
+			for _, dl := range deliveries {
+				if !dl.Event.Equal(tt.event) {
+					t.Errorf("event = %s, want %s", dl.Event, tt.event)
+				}
+
+				if !dl.Status.Equal(Statuses.Pending) || dl.Attempts != 0 || dl.NextAttempt.IsZero() {
+					t.Errorf("delivery is %s after %d attempts, next at %s, want pending and due", dl.Status, dl.Attempts, dl.NextAttempt)
+				}
+
+				var p payload
+				if err := json.Unmarshal(dl.Payload, &p); err != nil {
+					t.Fatalf("unmarshal payload: %s", err)
+				}
+
+				got[dl.WebhookID] = append(got[dl.WebhookID], len(p.Embeds))
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("deliveries for %d webhooks, want %d", len(got), len(tt.want))
+			}
+
+			for id, embeds := range tt.want {
+				if len(got[id]) != len(embeds) {
+					t.Fatalf("webhook %s got messages of %v embeds, want %v", id, got[id], embeds)
+				}
+				for i := range embeds {
+					if got[id][i] != embeds[i] {
+						t.Errorf("webhook %s got messages of %v embeds, want %v", id, got[id], embeds)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package webhookbus
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+)
+
+// maxEmbeds is the number of embeds Discord accepts in a single message.
+// Events covering more resources are split across several deliveries.
+const maxEmbeds = 10
+
+// Embed colours for each event.
+var eventColors = map[Event]int{
+	Events.Created:   0x2ecc71,
+	Events.Verified:  0x3498db,
+	Events.Despawned: 0x95a5a6,
+}
+
+var eventVerbs = map[Event]string{
+	Events.Created:   "New",
+	Events.Verified:  "Verified",
+	Events.Despawned: "Despawned",
+}
+
+// The payload types follow Discord's execute webhook body, so a Discord
+// webhook URL can be subscribed directly. Other receivers get the same JSON.
+type payload struct {
+	Username string  `json:"username"`
+	Content  string  `json:"content"`
+	Embeds   []embed `json:"embeds"`
+}
+
+type embed struct {
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Color       int     `json:"color"`
+	Fields      []field `json:"fields,omitempty"`
+	Footer      footer  `json:"footer"`
+	Timestamp   string  `json:"timestamp"`
+}
+
+type field struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+type footer struct {
+	Text string `json:"text"`
+}
+
+// newPayload builds the message for an event on resources of a galaxy.
+func newPayload(event Event, galaxyName string, resources []resourcebus.Resource) ([]byte, error) {
+	noun := "resource"
+	if len(resources) > 1 {
+		noun = "resources"
+	}
+
+	p := payload{
+		Username: "Harvester",
+		Content:  fmt.Sprintf("%s %s in %s", eventVerbs[event], noun, galaxyName),
+		Embeds:   make([]embed, len(resources)),
+	}
+
+	for i, res := range resources {
+		p.Embeds[i] = embed{
+			Title:       res.Name.String(),
+			Description: res.ResourceType,
+			Color:       eventColors[event],
+			Fields:      statFields(res),
+			Footer:      footer{Text: fmt.Sprintf("%s | %s", event, res.ID)},
+			Timestamp:   res.UpdatedAtDate.UTC().Format(time.RFC3339),
+		}
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+
+	return data, nil
+}
+
+// statFields lists the stats the resource has, in the order players read
+// them in game.
+func statFields(res resourcebus.Resource) []field {
+	stats := []struct {
+		name  string
+		value int16
+	}{
+		{"CR", res.CR}, {"CD", res.CD}, {"DR", res.DR}, {"FL", res.FL},
+		{"HR", res.HR}, {"MA", res.MA}, {"PE", res.PE}, {"OQ", res.OQ},
+		{"SR", res.SR}, {"UT", res.UT}, {"ER", res.ER},
+	}
+
+	var fields []field
+	for _, s := range stats {
+		if s.value == 0 {
+			continue
+		}
+
+		fields = append(fields, field{Name: s.name, Value: strconv.Itoa(int(s.value)), Inline: true})
+	}
+
+	return fields
+}
@@ -0,0 +1,53 @@
+package webhookbus
+
+import "fmt"
+
+type statusSet struct {
+	Pending   Status
+	Delivered Status
+	Failed    Status
+}
+
+// Statuses represents the set of states a delivery moves through. A pending
+// delivery is retried until it is delivered or has failed permanently.
+var Statuses = statusSet{
+	Pending:   newStatus("PENDING"),
+	Delivered: newStatus("DELIVERED"),
+	Failed:    newStatus("FAILED"),
+}
+
+// Parse parses the string value and returns a status if one exists.
+func (statusSet) Parse(value string) (Status, error) {
+	status, exists := statuses[value]
+	if !exists {
+		return Status{}, fmt.Errorf("invalid status %q", value)
+	}
+
+	return status, nil
+}
+
+// =============================================================================
+
+// Set of known statuses.
+var statuses = make(map[string]Status)
+
+// Status represents the state of a delivery.
+type Status struct {
+	name string
+}
+
+func newStatus(status string) Status {
+	s := Status{status}
+	statuses[status] = s
+	return s
+}
+
+// String returns the name of the status.
+func (s Status) String() string {
+	return s.name
+}
+
+// Equal provides support for the go-cmp package and testing.
+func (s Status) Equal(s2 Status) bool {
+	return s.name == s2.name
+}
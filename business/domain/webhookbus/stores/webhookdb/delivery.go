@@ -0,0 +1,101 @@
+package webhookdb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/webhookbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/google/uuid"
+)
+
+const deliveryColumns = `
+		delivery_id, webhook_id, event, CAST(payload AS TEXT) AS payload, status, attempts,
+		next_attempt, last_error, date_created, date_delivered`
+
+// ClaimDeliveries takes up to limit pending deliveries that are due and
+// moves their next attempt past the lease, so no other dispatcher claims them
+// while they are being sent. Rows locked by another dispatcher are skipped.
+func (s *Store) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]webhookbus.Delivery, error) {
+	data := map[string]any{
+		"pending":     webhookbus.Statuses.Pending.String(),
+		"now":         now.UTC(),
+		"lease_until": now.Add(lease).UTC(),
+		"limit":       limit,
+	}
+
+	const q = `
+	UPDATE
+		webhook_deliveries
+	SET
+		next_attempt = :lease_until
+	WHERE
+		delivery_id IN (
+			SELECT
+				delivery_id
+			FROM
+				webhook_deliveries
+			WHERE
+				status = :pending AND next_attempt <= :now
+			ORDER BY
+				next_attempt
+			LIMIT :limit
+			FOR UPDATE SKIP LOCKED
+		)
+	RETURNING` + deliveryColumns
+
+	var dbDeliveries []delivery
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbDeliveries); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusDeliveries(dbDeliveries)
+}
+
+// UpdateDelivery records the outcome of a delivery attempt.
+func (s *Store) UpdateDelivery(ctx context.Context, d webhookbus.Delivery) error {
+	const q = `
+	UPDATE
+		webhook_deliveries
+	SET
+		"status" = :status,
+		"attempts" = :attempts,
+		"next_attempt" = :next_attempt,
+		"last_error" = :last_error,
+		"date_delivered" = :date_delivered
+	WHERE
+		delivery_id = :delivery_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBDelivery(d)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryDeliveries retrieves the most recent deliveries of a webhook, newest
+// first.
+func (s *Store) QueryDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]webhookbus.Delivery, error) {
+	data := map[string]any{
+		"webhook_id": webhookID.String(),
+		"limit":      limit,
+	}
+
+	const q = `
+	SELECT` + deliveryColumns + `
+	FROM
+		webhook_deliveries
+	WHERE
+		webhook_id = :webhook_id
+	ORDER BY
+		date_created DESC
+	LIMIT :limit`
+
+	var dbDeliveries []delivery
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbDeliveries); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusDeliveries(dbDeliveries)
+}
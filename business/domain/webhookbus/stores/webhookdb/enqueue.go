@@ -0,0 +1,130 @@
+package webhookdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/resourcebus"
+	"github.com/godwinrob/harvester/business/domain/webhookbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Enqueuer queues the deliveries of resource events for the resource store,
+// so the store needn't depend on this package.
+type Enqueuer struct {
+	log *logger.Logger
+}
+
+// NewEnqueuer constructs an enqueuer of resource events.
+func NewEnqueuer(log *logger.Logger) *Enqueuer {
+	return &Enqueuer{
+		log: log,
+	}
+}
+
+// CreatedWithTx queues the deliveries of the created event on the resources.
+func (e *Enqueuer) CreatedWithTx(ctx context.Context, tx *sqlx.Tx, resources ...resourcebus.Resource) error {
+	return EnqueueWithTx(ctx, e.log, tx, webhookbus.Events.Created, resources...)
+}
+
+// VerifiedWithTx queues the deliveries of the verified event on the resources.
+func (e *Enqueuer) VerifiedWithTx(ctx context.Context, tx *sqlx.Tx, resources ...resourcebus.Resource) error {
+	return EnqueueWithTx(ctx, e.log, tx, webhookbus.Events.Verified, resources...)
+}
+
+// DespawnedWithTx queues the deliveries of the despawned event on the
+// resources.
+func (e *Enqueuer) DespawnedWithTx(ctx context.Context, tx *sqlx.Tx, resources ...resourcebus.Resource) error {
+	return EnqueueWithTx(ctx, e.log, tx, webhookbus.Events.Despawned, resources...)
+}
+
+// EnqueueWithTx queues the deliveries of an event on the resources in the
+// outbox as part of the transaction that changes them, one for every webhook
+// of their galaxies subscribed to the event. The deliveries commit or roll
+// back with the change, so a committed change is never left unannounced.
+func EnqueueWithTx(ctx context.Context, log *logger.Logger, tx *sqlx.Tx, event webhookbus.Event, resources ...resourcebus.Resource) error {
+	var galaxyIDs []uuid.UUID
+	galaxies := make(map[uuid.UUID][]resourcebus.Resource)
+	for _, res := range resources {
+		if _, exists := galaxies[res.GalaxyID]; !exists {
+			galaxyIDs = append(galaxyIDs, res.GalaxyID)
+		}
+		galaxies[res.GalaxyID] = append(galaxies[res.GalaxyID], res)
+	}
+
+	for _, galaxyID := range galaxyIDs {
+		if err := enqueueGalaxy(ctx, log, tx, event, galaxyID, galaxies[galaxyID]); err != nil {
+			return fmt.Errorf("galaxyID[%s]: %w", galaxyID, err)
+		}
+	}
+
+	return nil
+}
+
+// enqueueGalaxy queues the deliveries of an event on resources of one galaxy.
+func enqueueGalaxy(ctx context.Context, log *logger.Logger, tx *sqlx.Tx, event webhookbus.Event, galaxyID uuid.UUID, resources []resourcebus.Resource) error {
+	data := map[string]any{
+		"galaxy_id": galaxyID.String(),
+		"event":     event.String(),
+	}
+
+	const qWebhooks = `
+	SELECT
+		webhook_id, galaxy_id, url, secret, events, created_by, date_created, date_updated
+	FROM
+		webhooks
+	WHERE
+		galaxy_id = :galaxy_id AND
+		CAST(:event AS TEXT) = ANY(events)`
+
+	var dbWebhooks []webhook
+	if err := sqldb.NamedQuerySlice(ctx, log, tx, qWebhooks, data, &dbWebhooks); err != nil {
+		return fmt.Errorf("querywebhooks: %w", err)
+	}
+
+	if len(dbWebhooks) == 0 {
+		return nil
+	}
+
+	whs, err := toBusWebhooks(dbWebhooks)
+	if err != nil {
+		return err
+	}
+
+	const qGalaxy = `
+	SELECT
+		galaxy_name
+	FROM
+		galaxies
+	WHERE
+		galaxy_id = :galaxy_id`
+
+	var gal struct {
+		Name string `db:"galaxy_name"`
+	}
+	if err := sqldb.NamedQueryStruct(ctx, log, tx, qGalaxy, data, &gal); err != nil {
+		return fmt.Errorf("querygalaxy: %w", err)
+	}
+
+	deliveries, err := webhookbus.NewDeliveries(event, gal.Name, whs, resources)
+	if err != nil {
+		return fmt.Errorf("newdeliveries: %w", err)
+	}
+
+	const qInsert = `
+	INSERT INTO webhook_deliveries
+		(delivery_id, webhook_id, event, payload, status, attempts, next_attempt, last_error, date_created, date_delivered)
+	VALUES
+		(:delivery_id, :webhook_id, :event, CAST(:payload AS JSONB), :status, :attempts, :next_attempt, :last_error, :date_created, :date_delivered)`
+
+	for i, d := range deliveries {
+		if err := sqldb.NamedExecContextWithTx(ctx, log, tx, qInsert, toDBDelivery(d)); err != nil {
+			return fmt.Errorf("insert[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package webhookdb
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/godwinrob/harvester/business/domain/webhookbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb/dbarray"
+	"github.com/google/uuid"
+)
+
+type webhook struct {
+	ID          uuid.UUID      `db:"webhook_id"`
+	GalaxyID    uuid.UUID      `db:"galaxy_id"`
+	URL         string         `db:"url"`
+	Secret      string         `db:"secret"`
+	Events      dbarray.String `db:"events"`
+	CreatedBy   uuid.UUID      `db:"created_by"`
+	DateCreated time.Time      `db:"date_created"`
+	DateUpdated time.Time      `db:"date_updated"`
+}
+
+func toDBWebhook(bus webhookbus.Webhook) webhook {
+	events := make(dbarray.String, len(bus.Events))
+	for i, e := range bus.Events {
+		events[i] = e.String()
+	}
+
+	return webhook{
+		ID:          bus.ID,
+		GalaxyID:    bus.GalaxyID,
+		URL:         bus.URL,
+		Secret:      bus.Secret,
+		Events:      events,
+		CreatedBy:   bus.CreatedBy,
+		DateCreated: bus.DateCreated.UTC(),
+		DateUpdated: bus.DateUpdated.UTC(),
+	}
+}
+
+func toBusWebhook(db webhook) (webhookbus.Webhook, error) {
+	events := make([]webhookbus.Event, len(db.Events))
+	for i, e := range db.Events {
+		var err error
+		events[i], err = webhookbus.Events.Parse(e)
+		if err != nil {
+			return webhookbus.Webhook{}, fmt.Errorf("parse event: %w", err)
+		}
+	}
+
+	bus := webhookbus.Webhook{
+		ID:          db.ID,
+		GalaxyID:    db.GalaxyID,
+		URL:         db.URL,
+		Secret:      db.Secret,
+		Events:      events,
+		CreatedBy:   db.CreatedBy,
+		DateCreated: db.DateCreated.In(time.Local),
+		DateUpdated: db.DateUpdated.In(time.Local),
+	}
+
+	return bus, nil
+}
+
+func toBusWebhooks(dbs []webhook) ([]webhookbus.Webhook, error) {
+	bus := make([]webhookbus.Webhook, len(dbs))
+
+	for i, db := range dbs {
+		var err error
+		bus[i], err = toBusWebhook(db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bus, nil
+}
+
+// =============================================================================
+
+type delivery struct {
+	ID            uuid.UUID    `db:"delivery_id"`
+	WebhookID     uuid.UUID    `db:"webhook_id"`
+	Event         string       `db:"event"`
+	Payload       string       `db:"payload"`
+	Status        string       `db:"status"`
+	Attempts      int          `db:"attempts"`
+	NextAttempt   time.Time    `db:"next_attempt"`
+	LastError     string       `db:"last_error"`
+	DateCreated   time.Time    `db:"date_created"`
+	DateDelivered sql.NullTime `db:"date_delivered"`
+}
+
+func toDBDelivery(bus webhookbus.Delivery) delivery {
+	return delivery{
+		ID:            bus.ID,
+		WebhookID:     bus.WebhookID,
+		Event:         bus.Event.String(),
+		Payload:       string(bus.Payload),
+		Status:        bus.Status.String(),
+		Attempts:      bus.Attempts,
+		NextAttempt:   bus.NextAttempt.UTC(),
+		LastError:     bus.LastError,
+		DateCreated:   bus.DateCreated.UTC(),
+		DateDelivered: sql.NullTime{Time: bus.DateDelivered.UTC(), Valid: !bus.DateDelivered.IsZero()},
+	}
+}
+
+func toBusDelivery(db delivery) (webhookbus.Delivery, error) {
+	event, err := webhookbus.Events.Parse(db.Event)
+	if err != nil {
+		return webhookbus.Delivery{}, fmt.Errorf("parse event: %w", err)
+	}
+
+	status, err := webhookbus.Statuses.Parse(db.Status)
+	if err != nil {
+		return webhookbus.Delivery{}, fmt.Errorf("parse status: %w", err)
+	}
+
+	bus := webhookbus.Delivery{
+		ID:          db.ID,
+		WebhookID:   db.WebhookID,
+		Event:       event,
+		Payload:     []byte(db.Payload),
+		Status:      status,
+		Attempts:    db.Attempts,
+		NextAttempt: db.NextAttempt.In(time.Local),
+		LastError:   db.LastError,
+		DateCreated: db.DateCreated.In(time.Local),
+	}
+
+	if db.DateDelivered.Valid {
+		bus.DateDelivered = db.DateDelivered.Time.In(time.Local)
+	}
+
+	return bus, nil
+}
+
+func toBusDeliveries(dbs []delivery) ([]webhookbus.Delivery, error) {
+	bus := make([]webhookbus.Delivery, len(dbs))
+
+	for i, db := range dbs {
+		var err error
+		bus[i], err = toBusDelivery(db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return bus, nil
+}
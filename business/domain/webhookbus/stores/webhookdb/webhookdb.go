@@ -0,0 +1,113 @@
+// Package webhookdb contains webhook related CRUD functionality.
+package webhookdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/business/domain/webhookbus"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/foundation/logger"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for webhook database access.
+type Store struct {
+	log *logger.Logger
+	db  sqlx.ExtContext
+}
+
+// NewStore constructs the api for data access.
+func NewStore(log *logger.Logger, db *sqlx.DB) *Store {
+	return &Store{
+		log: log,
+		db:  db,
+	}
+}
+
+// Create inserts a new webhook into the database.
+func (s *Store) Create(ctx context.Context, wh webhookbus.Webhook) error {
+	const q = `
+	INSERT INTO webhooks
+		(webhook_id, galaxy_id, url, secret, events, created_by, date_created, date_updated)
+	VALUES
+		(:webhook_id, :galaxy_id, :url, :secret, :events, :created_by, :date_created, :date_updated)`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBWebhook(wh)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a webhook and, through the foreign key, its deliveries from
+// the database.
+func (s *Store) Delete(ctx context.Context, wh webhookbus.Webhook) error {
+	const q = `
+	DELETE FROM
+		webhooks
+	WHERE
+		webhook_id = :webhook_id`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, toDBWebhook(wh)); err != nil {
+		return fmt.Errorf("namedexeccontext: %w", err)
+	}
+
+	return nil
+}
+
+// QueryByID gets the specified webhook from the database.
+func (s *Store) QueryByID(ctx context.Context, webhookID uuid.UUID) (webhookbus.Webhook, error) {
+	data := struct {
+		ID string `db:"webhook_id"`
+	}{
+		ID: webhookID.String(),
+	}
+
+	const q = `
+	SELECT
+		webhook_id, galaxy_id, url, secret, events, created_by, date_created, date_updated
+	FROM
+		webhooks
+	WHERE 
+		webhook_id = :webhook_id`
+
+	var dbWebhook webhook
+	if err := sqldb.NamedQueryStruct(ctx, s.log, s.db, q, data, &dbWebhook); err != nil {
+		if errors.Is(err, sqldb.ErrDBNotFound) {
+			return webhookbus.Webhook{}, fmt.Errorf("db: %w", webhookbus.ErrNotFound)
+		}
+		return webhookbus.Webhook{}, fmt.Errorf("db: %w", err)
+	}
+
+	return toBusWebhook(dbWebhook)
+}
+
+// QueryByGalaxy retrieves the webhooks of a galaxy, oldest first.
+func (s *Store) QueryByGalaxy(ctx context.Context, galaxyID uuid.UUID) ([]webhookbus.Webhook, error) {
+	data := struct {
+		ID string `db:"galaxy_id"`
+	}{
+		ID: galaxyID.String(),
+	}
+
+	const q = `
+	SELECT
+		webhook_id, galaxy_id, url, secret, events, created_by, date_created, date_updated
+	FROM
+		webhooks
+	WHERE
+		galaxy_id = :galaxy_id
+	ORDER BY
+		date_created`
+
+	var dbWebhooks []webhook
+	if err := sqldb.NamedQuerySlice(ctx, s.log, s.db, q, data, &dbWebhooks); err != nil {
+		return nil, fmt.Errorf("namedqueryslice: %w", err)
+	}
+
+	return toBusWebhooks(dbWebhooks)
+}
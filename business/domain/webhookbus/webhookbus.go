@@ -0,0 +1,162 @@
+// Package webhookbus provides business access to galaxy webhooks and the
+// outbox their deliveries are queued in.
+package webhookbus
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/google/uuid"
+)
+
+// Set of error variables for CRUD operations.
+var (
+	ErrNotFound   = errors.New("webhook not found")
+	ErrInvalidURL = errors.New("webhook url must be an absolute http or https url")
+	ErrNoEvents   = errors.New("webhook must subscribe to at least one event")
+)
+
+// Storer interface declares the behavior this package needs to perists and
+// retrieve data.
+type Storer interface {
+	Create(ctx context.Context, wh Webhook) error
+	Delete(ctx context.Context, wh Webhook) error
+	QueryByID(ctx context.Context, webhookID uuid.UUID) (Webhook, error)
+	QueryByGalaxy(ctx context.Context, galaxyID uuid.UUID) ([]Webhook, error)
+	ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Delivery, error)
+	UpdateDelivery(ctx context.Context, d Delivery) error
+	QueryDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]Delivery, error)
+}
+
+// Business manages the set of APIs for webhook access.
+type Business struct {
+	log    *logger.Logger
+	storer Storer
+}
+
+// NewBusiness constructs a webhook business API for use. Deliveries of the
+// resource events are queued by the resource store, see NewDeliveries.
+func NewBusiness(log *logger.Logger, storer Storer) *Business {
+	return &Business{
+		log:    log,
+		storer: storer,
+	}
+}
+
+// Create adds a new webhook to a galaxy with a freshly generated signing
+// secret.
+func (b *Business) Create(ctx context.Context, nw NewWebhook) (Webhook, error) {
+	ctx, span := otel.AddSpan(ctx, "business.webhookbus.create")
+	defer span.End()
+
+	if err := checkURL(nw.URL); err != nil {
+		return Webhook{}, err
+	}
+
+	if len(nw.Events) == 0 {
+		return Webhook{}, ErrNoEvents
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return Webhook{}, fmt.Errorf("newsecret: %w", err)
+	}
+
+	now := time.Now()
+
+	wh := Webhook{
+		ID:          uuid.New(),
+		GalaxyID:    nw.GalaxyID,
+		URL:         nw.URL,
+		Secret:      secret,
+		Events:      nw.Events,
+		CreatedBy:   nw.CreatedBy,
+		DateCreated: now,
+		DateUpdated: now,
+	}
+
+	if err := b.storer.Create(ctx, wh); err != nil {
+		return Webhook{}, fmt.Errorf("create: %w", err)
+	}
+
+	return wh, nil
+}
+
+// Delete removes the specified webhook and its queued deliveries.
+func (b *Business) Delete(ctx context.Context, wh Webhook) error {
+	ctx, span := otel.AddSpan(ctx, "business.webhookbus.delete")
+	defer span.End()
+
+	if err := b.storer.Delete(ctx, wh); err != nil {
+		return fmt.Errorf("delete: %w", err)
+	}
+
+	return nil
+}
+
+// QueryByID finds the webhook by the specified ID.
+func (b *Business) QueryByID(ctx context.Context, webhookID uuid.UUID) (Webhook, error) {
+	ctx, span := otel.AddSpan(ctx, "business.webhookbus.querybyid")
+	defer span.End()
+
+	wh, err := b.storer.QueryByID(ctx, webhookID)
+	if err != nil {
+		return Webhook{}, fmt.Errorf("query: webhookID[%s]: %w", webhookID, err)
+	}
+
+	return wh, nil
+}
+
+// QueryByGalaxy retrieves the webhooks of a galaxy.
+func (b *Business) QueryByGalaxy(ctx context.Context, galaxyID uuid.UUID) ([]Webhook, error) {
+	ctx, span := otel.AddSpan(ctx, "business.webhookbus.querybygalaxy")
+	defer span.End()
+
+	whs, err := b.storer.QueryByGalaxy(ctx, galaxyID)
+	if err != nil {
+		return nil, fmt.Errorf("querybygalaxy: galaxyID[%s]: %w", galaxyID, err)
+	}
+
+	return whs, nil
+}
+
+// QueryDeliveries retrieves the most recent deliveries of a webhook, newest
+// first.
+func (b *Business) QueryDeliveries(ctx context.Context, webhookID uuid.UUID, limit int) ([]Delivery, error) {
+	ctx, span := otel.AddSpan(ctx, "business.webhookbus.querydeliveries")
+	defer span.End()
+
+	deliveries, err := b.storer.QueryDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("querydeliveries: webhookID[%s]: %w", webhookID, err)
+	}
+
+	return deliveries, nil
+}
+
+// =============================================================================
+
+func checkURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return ErrInvalidURL
+	}
+
+	return nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
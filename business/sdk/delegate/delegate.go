@@ -0,0 +1,66 @@
+// Package delegate provides the ability to make function calls between
+// different domain packages when an import is not possible.
+package delegate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/godwinrob/harvester/foundation/logger"
+)
+
+// Data represents the data that is passed to a delegate function. RawParams
+// holds the JSON encoded parameters of the action.
+type Data struct {
+	Domain    string
+	Action    string
+	RawParams []byte
+}
+
+// Func represents a function that can receive a delegate call.
+type Func func(context.Context, Data) error
+
+// Delegate manages the set of functions to be called by domain packages when
+// an action occurs.
+type Delegate struct {
+	log   *logger.Logger
+	funcs map[string]map[string][]Func
+}
+
+// New constructs a delegate for registering and calling functions.
+func New(log *logger.Logger) *Delegate {
+	return &Delegate{
+		log:   log,
+		funcs: make(map[string]map[string][]Func),
+	}
+}
+
+// Register adds a function to be called when the specified domain and action
+// are called. Functions must be registered before the service starts
+// handling requests.
+func (d *Delegate) Register(domain string, action string, fn Func) {
+	aMap, ok := d.funcs[domain]
+	if !ok {
+		aMap = make(map[string][]Func)
+		d.funcs[domain] = aMap
+	}
+
+	aMap[action] = append(aMap[action], fn)
+}
+
+// Call executes every function registered for the domain and action. Every
+// function is called even when an earlier one fails; the failures are joined
+// in the returned error.
+func (d *Delegate) Call(ctx context.Context, data Data) error {
+	d.log.Debug(ctx, "delegate call", "domain", data.Domain, "action", data.Action)
+
+	var errs []error
+	for _, fn := range d.funcs[data.Domain][data.Action] {
+		if err := fn(ctx, data); err != nil {
+			errs = append(errs, fmt.Errorf("%s.%s: %w", data.Domain, data.Action, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
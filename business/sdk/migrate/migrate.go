@@ -33,6 +33,19 @@ func Reset(ctx context.Context, db *sqlx.DB) error {
 
 	// Drop tables in reverse dependency order
 	queries := []string{
+		"DROP TABLE IF EXISTS rate_limits CASCADE",
+		"DROP TABLE IF EXISTS audit_log CASCADE",
+		"DROP TABLE IF EXISTS webhook_deliveries CASCADE",
+		"DROP TABLE IF EXISTS webhooks CASCADE",
+		"DROP TABLE IF EXISTS alerts CASCADE",
+		"DROP TABLE IF EXISTS alert_rules CASCADE",
+		"DROP TABLE IF EXISTS galaxy_members CASCADE",
+		"DROP TABLE IF EXISTS resource_verifications CASCADE",
+		"DROP TABLE IF EXISTS resource_status_history CASCADE",
+		"DROP TABLE IF EXISTS schematic_slots CASCADE",
+		"DROP TABLE IF EXISTS schematics CASCADE",
+		"DROP TABLE IF EXISTS resource_planets CASCADE",
+		"DROP TABLE IF EXISTS planets CASCADE",
 		"DROP TABLE IF EXISTS resource_type_groups CASCADE",
 		"DROP TABLE IF EXISTS resource_types CASCADE",
 		"DROP TABLE IF EXISTS resource_groups CASCADE",
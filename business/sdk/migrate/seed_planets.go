@@ -0,0 +1,52 @@
+package migrate
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed sql/seed/planets.csv
+var planetsData string
+
+// SeedPlanets inserts the standard planets into the database. The planet ids
+// match the specific_planet values used by planet-locked resource types.
+func SeedPlanets(ctx context.Context, db *sqlx.DB) error {
+	stmt, err := db.PrepareContext(ctx, `
+		INSERT INTO planets (planet_id, planet_name)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`)
+	if err != nil {
+		return fmt.Errorf("prepare statement: %w", err)
+	}
+	defer stmt.Close()
+
+	lines := strings.Split(strings.TrimSpace(planetsData), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := parseCSVLine(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("line %d: expected 2 fields, got %d", i+1, len(fields))
+		}
+
+		planetID, err := strconv.Atoi(fields[0])
+		if err != nil {
+			return fmt.Errorf("line %d: invalid planet_id %q: %w", i+1, fields[0], err)
+		}
+
+		if _, err := stmt.ExecContext(ctx, planetID, fields[1]); err != nil {
+			return fmt.Errorf("insert planet %d (%s): %w", i+1, fields[1], err)
+		}
+	}
+
+	return nil
+}
@@ -110,3 +110,298 @@ CREATE TABLE public.resource_type_groups (
 ALTER TABLE public.resources
     ADD CONSTRAINT resources_resource_type_fk
     FOREIGN KEY (resource_type) REFERENCES public.resource_types(resource_type);
+
+-- Version: 1.08
+-- Description: Create table planets
+CREATE TABLE public.planets (
+    planet_id    INT2 NOT NULL,
+    planet_name  VARCHAR(63) NOT NULL,
+
+    CONSTRAINT planets_pk PRIMARY KEY (planet_id),
+    CONSTRAINT planets_name_key UNIQUE (planet_name)
+);
+
+-- Version: 1.09
+-- Description: Create table resource_planets (planets a resource is available on)
+CREATE TABLE public.resource_planets (
+    resource_id  uuid NOT NULL,
+    planet_id    INT2 NOT NULL,
+
+    CONSTRAINT resource_planets_pk PRIMARY KEY (resource_id, planet_id),
+    CONSTRAINT resource_planets_resource_fk FOREIGN KEY (resource_id) REFERENCES public.resources(resource_id) ON DELETE CASCADE,
+    CONSTRAINT resource_planets_planet_fk FOREIGN KEY (planet_id) REFERENCES public.planets(planet_id)
+);
+
+-- Version: 1.10
+-- Description: Create table schematics
+CREATE TABLE public.schematics (
+    schematic_id    uuid NOT NULL,
+    schematic_name  VARCHAR(255) NOT NULL,
+    date_created    TIMESTAMP NOT NULL,
+    date_updated    TIMESTAMP NOT NULL,
+
+    CONSTRAINT schematics_pk PRIMARY KEY (schematic_id),
+    CONSTRAINT schematics_name_key UNIQUE (schematic_name)
+);
+
+-- Version: 1.11
+-- Description: Create table schematic_slots (resource slots and their experimentation weights)
+CREATE TABLE public.schematic_slots (
+    schematic_id    uuid NOT NULL,
+    slot_index      INT2 NOT NULL,
+    slot_name       VARCHAR(255) NOT NULL DEFAULT '',
+    resource_type   VARCHAR(63) NULL,
+    resource_group  VARCHAR(63) NULL,
+    cr INT2 DEFAULT 0 NOT NULL,
+    cd INT2 DEFAULT 0 NOT NULL,
+    dr INT2 DEFAULT 0 NOT NULL,
+    fl INT2 DEFAULT 0 NOT NULL,
+    hr INT2 DEFAULT 0 NOT NULL,
+    ma INT2 DEFAULT 0 NOT NULL,
+    pe INT2 DEFAULT 0 NOT NULL,
+    oq INT2 DEFAULT 0 NOT NULL,
+    sr INT2 DEFAULT 0 NOT NULL,
+    ut INT2 DEFAULT 0 NOT NULL,
+    er INT2 DEFAULT 0 NOT NULL,
+
+    CONSTRAINT schematic_slots_pk PRIMARY KEY (schematic_id, slot_index),
+    CONSTRAINT schematic_slots_schematic_fk FOREIGN KEY (schematic_id) REFERENCES public.schematics(schematic_id) ON DELETE CASCADE,
+    CONSTRAINT schematic_slots_resource_type_fk FOREIGN KEY (resource_type) REFERENCES public.resource_types(resource_type),
+    CONSTRAINT schematic_slots_resource_group_fk FOREIGN KEY (resource_group) REFERENCES public.resource_groups(resource_group),
+    CONSTRAINT schematic_slots_source_check CHECK ((resource_type IS NULL) <> (resource_group IS NULL))
+);
+
+-- Version: 1.12
+-- Description: Create table resource_status_history
+CREATE TABLE public.resource_status_history (
+    history_id   uuid NOT NULL,
+    resource_id  uuid NOT NULL,
+    status       VARCHAR(31) NOT NULL,
+    user_id      uuid NOT NULL,
+    changed_at   TIMESTAMP NOT NULL,
+
+    CONSTRAINT resource_status_history_pk PRIMARY KEY (history_id),
+    CONSTRAINT resource_status_history_resource_fk FOREIGN KEY (resource_id) REFERENCES public.resources(resource_id) ON DELETE CASCADE
+);
+
+CREATE INDEX resource_status_history_resource_idx ON public.resource_status_history (resource_id, changed_at);
+
+-- Version: 1.13
+-- Description: Add verification_threshold to galaxies
+ALTER TABLE public.galaxies
+    ADD COLUMN verification_threshold INT2 NOT NULL DEFAULT 1;
+
+-- Version: 1.14
+-- Description: Create table resource_verifications
+CREATE TABLE public.resource_verifications (
+    resource_id   uuid NOT NULL,
+    user_id       uuid NOT NULL,
+    vote          VARCHAR(31) NOT NULL,
+    date_created  TIMESTAMP NOT NULL,
+
+    CONSTRAINT resource_verifications_pk PRIMARY KEY (resource_id, user_id),
+    CONSTRAINT resource_verifications_resource_fk FOREIGN KEY (resource_id) REFERENCES public.resources(resource_id) ON DELETE CASCADE,
+    CONSTRAINT resource_verifications_user_fk FOREIGN KEY (user_id) REFERENCES public.users(user_id) ON DELETE CASCADE
+);
+
+-- Version: 1.15
+-- Description: Make resource names unique within a galaxy
+-- Resources entered more than once under the same name must be merged or
+-- renamed by an operator first: each copy can carry its own stats, planets,
+-- status history and verifications, so the migration stops and names them
+-- rather than choosing which to keep.
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT
+        string_agg(galaxy_id || '/' || resource_name, ', ' ORDER BY galaxy_id, resource_name)
+    INTO
+        duplicates
+    FROM (
+        SELECT
+            galaxy_id, resource_name
+        FROM
+            public.resources
+        GROUP BY
+            galaxy_id, resource_name
+        HAVING
+            count(*) > 1
+    ) d;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'resource names must be unique within a galaxy, merge or rename these before migrating: %', duplicates;
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX resources_galaxy_name_uq ON public.resources (galaxy_id, resource_name);
+
+-- Version: 1.16
+-- Description: Create table galaxy_members
+CREATE TABLE public.galaxy_members (
+    galaxy_id     uuid NOT NULL,
+    user_id       uuid NOT NULL,
+    role          VARCHAR(31) NOT NULL,
+    invited_by    uuid NOT NULL,
+    joined        BOOLEAN NOT NULL DEFAULT FALSE,
+    date_created  TIMESTAMP NOT NULL,
+    date_updated  TIMESTAMP NOT NULL,
+
+    CONSTRAINT galaxy_members_pk PRIMARY KEY (galaxy_id, user_id),
+    CONSTRAINT galaxy_members_galaxy_fk FOREIGN KEY (galaxy_id) REFERENCES public.galaxies(galaxy_id) ON DELETE CASCADE,
+    CONSTRAINT galaxy_members_user_fk FOREIGN KEY (user_id) REFERENCES public.users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX galaxy_members_user_idx ON public.galaxy_members (user_id);
+
+-- Version: 1.17
+-- Description: Make existing galaxy owners members of their galaxies
+INSERT INTO public.galaxy_members (galaxy_id, user_id, role, invited_by, joined, date_created, date_updated)
+SELECT g.galaxy_id, g.owner_user_id, 'OWNER', g.owner_user_id, TRUE, g.date_created, g.date_updated
+FROM public.galaxies g
+JOIN public.users u ON u.user_id = g.owner_user_id
+ON CONFLICT DO NOTHING;
+
+-- Version: 1.18
+-- Description: Create table alert_rules
+CREATE TABLE public.alert_rules (
+    rule_id         uuid NOT NULL,
+    user_id         uuid NOT NULL,
+    rule_name       VARCHAR(63) NOT NULL,
+    galaxy_id       uuid NOT NULL,
+    resource_type   VARCHAR(63) NULL,
+    resource_group  VARCHAR(63) NULL,
+    cr_min          SMALLINT NOT NULL DEFAULT 0,
+    cd_min          SMALLINT NOT NULL DEFAULT 0,
+    dr_min          SMALLINT NOT NULL DEFAULT 0,
+    fl_min          SMALLINT NOT NULL DEFAULT 0,
+    hr_min          SMALLINT NOT NULL DEFAULT 0,
+    ma_min          SMALLINT NOT NULL DEFAULT 0,
+    pe_min          SMALLINT NOT NULL DEFAULT 0,
+    oq_min          SMALLINT NOT NULL DEFAULT 0,
+    sr_min          SMALLINT NOT NULL DEFAULT 0,
+    ut_min          SMALLINT NOT NULL DEFAULT 0,
+    er_min          SMALLINT NOT NULL DEFAULT 0,
+    w_cr            SMALLINT NOT NULL DEFAULT 0,
+    w_cd            SMALLINT NOT NULL DEFAULT 0,
+    w_dr            SMALLINT NOT NULL DEFAULT 0,
+    w_fl            SMALLINT NOT NULL DEFAULT 0,
+    w_hr            SMALLINT NOT NULL DEFAULT 0,
+    w_ma            SMALLINT NOT NULL DEFAULT 0,
+    w_pe            SMALLINT NOT NULL DEFAULT 0,
+    w_oq            SMALLINT NOT NULL DEFAULT 0,
+    w_sr            SMALLINT NOT NULL DEFAULT 0,
+    w_ut            SMALLINT NOT NULL DEFAULT 0,
+    w_er            SMALLINT NOT NULL DEFAULT 0,
+    min_score       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    date_created    TIMESTAMP NOT NULL,
+    date_updated    TIMESTAMP NOT NULL,
+
+    CONSTRAINT alert_rules_pk PRIMARY KEY (rule_id),
+    CONSTRAINT alert_rules_user_fk FOREIGN KEY (user_id) REFERENCES public.users(user_id) ON DELETE CASCADE,
+    CONSTRAINT alert_rules_galaxy_fk FOREIGN KEY (galaxy_id) REFERENCES public.galaxies(galaxy_id) ON DELETE CASCADE,
+    CONSTRAINT alert_rules_resource_type_fk FOREIGN KEY (resource_type) REFERENCES public.resource_types(resource_type),
+    CONSTRAINT alert_rules_resource_group_fk FOREIGN KEY (resource_group) REFERENCES public.resource_groups(resource_group),
+    CONSTRAINT alert_rules_source_check CHECK ((resource_type IS NULL) <> (resource_group IS NULL))
+);
+
+CREATE INDEX alert_rules_galaxy_idx ON public.alert_rules (galaxy_id);
+CREATE INDEX alert_rules_user_idx ON public.alert_rules (user_id);
+
+-- Version: 1.19
+-- Description: Create table alerts
+CREATE TABLE public.alerts (
+    alert_id      uuid NOT NULL,
+    rule_id       uuid NOT NULL,
+    user_id       uuid NOT NULL,
+    resource_id   uuid NOT NULL,
+    score         DOUBLE PRECISION NOT NULL DEFAULT 0,
+    date_created  TIMESTAMP NOT NULL,
+
+    CONSTRAINT alerts_pk PRIMARY KEY (alert_id),
+    CONSTRAINT alerts_rule_resource_uq UNIQUE (rule_id, resource_id),
+    CONSTRAINT alerts_rule_fk FOREIGN KEY (rule_id) REFERENCES public.alert_rules(rule_id) ON DELETE CASCADE,
+    CONSTRAINT alerts_user_fk FOREIGN KEY (user_id) REFERENCES public.users(user_id) ON DELETE CASCADE,
+    CONSTRAINT alerts_resource_fk FOREIGN KEY (resource_id) REFERENCES public.resources(resource_id) ON DELETE CASCADE
+);
+
+CREATE INDEX alerts_user_idx ON public.alerts (user_id, date_created);
+
+-- Version: 1.20
+-- Description: Create table webhooks
+CREATE TABLE public.webhooks (
+    webhook_id    uuid NOT NULL,
+    galaxy_id     uuid NOT NULL,
+    url           TEXT NOT NULL,
+    secret        VARCHAR(128) NOT NULL,
+    events        TEXT[] NOT NULL,
+    created_by    uuid NOT NULL,
+    date_created  TIMESTAMP NOT NULL,
+    date_updated  TIMESTAMP NOT NULL,
+
+    CONSTRAINT webhooks_pk PRIMARY KEY (webhook_id),
+    CONSTRAINT webhooks_galaxy_fk FOREIGN KEY (galaxy_id) REFERENCES public.galaxies(galaxy_id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhooks_galaxy_idx ON public.webhooks (galaxy_id);
+
+-- Version: 1.21
+-- Description: Create table webhook_deliveries, the outbox of webhook payloads
+CREATE TABLE public.webhook_deliveries (
+    delivery_id     uuid NOT NULL,
+    webhook_id      uuid NOT NULL,
+    event           VARCHAR(63) NOT NULL,
+    payload         JSONB NOT NULL,
+    status          VARCHAR(31) NOT NULL,
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt    TIMESTAMP NOT NULL,
+    last_error      TEXT NOT NULL DEFAULT '',
+    date_created    TIMESTAMP NOT NULL,
+    date_delivered  TIMESTAMP NULL,
+
+    CONSTRAINT webhook_deliveries_pk PRIMARY KEY (delivery_id),
+    CONSTRAINT webhook_deliveries_webhook_fk FOREIGN KEY (webhook_id) REFERENCES public.webhooks(webhook_id) ON DELETE CASCADE
+);
+
+CREATE INDEX webhook_deliveries_due_idx ON public.webhook_deliveries (next_attempt) WHERE status = 'PENDING';
+CREATE INDEX webhook_deliveries_webhook_idx ON public.webhook_deliveries (webhook_id, date_created);
+
+-- Version: 1.22
+-- Description: Add trigram indexes on names for fuzzy search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX resources_name_trgm_idx ON public.resources USING GIN (resource_name gin_trgm_ops);
+CREATE INDEX resource_types_name_trgm_idx ON public.resource_types USING GIN (resource_type_name gin_trgm_ops);
+CREATE INDEX resource_groups_name_trgm_idx ON public.resource_groups USING GIN (group_name gin_trgm_ops);
+
+-- Version: 1.23
+-- Description: Create table audit_log, the record of every mutation
+CREATE TABLE public.audit_log (
+    audit_id      uuid NOT NULL,
+    actor_id      uuid NULL,
+    action        VARCHAR(15) NOT NULL,
+    entity_type   VARCHAR(31) NOT NULL,
+    entity_id     VARCHAR(127) NOT NULL,
+    before_data   JSONB NULL,
+    after_data    JSONB NULL,
+    trace_id      VARCHAR(63) NOT NULL,
+    date_created  TIMESTAMP NOT NULL,
+
+    CONSTRAINT audit_log_pk PRIMARY KEY (audit_id)
+);
+
+CREATE INDEX audit_log_entity_idx ON public.audit_log (entity_type, entity_id);
+CREATE INDEX audit_log_actor_idx ON public.audit_log (actor_id);
+CREATE INDEX audit_log_date_created_idx ON public.audit_log (date_created);
+
+-- Version: 1.24
+-- Description: Create table rate_limits, the token buckets of the rate limiter
+CREATE UNLOGGED TABLE public.rate_limits (
+    rate_key      VARCHAR(255) NOT NULL,
+    tokens        DOUBLE PRECISION NOT NULL,
+    date_updated  TIMESTAMP NOT NULL,
+
+    CONSTRAINT rate_limits_pk PRIMARY KEY (rate_key)
+);
+
+CREATE INDEX rate_limits_date_updated_idx ON public.rate_limits (date_updated);
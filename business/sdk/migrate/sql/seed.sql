@@ -8,6 +8,12 @@ INSERT INTO galaxies (galaxy_id, galaxy_name, owner_user_id) VALUES
     ('b4629864-500c-4f06-8e4a-d31cea7bcfae', 'Bria', '45b5fbd3-755f-4379-8f07-a58d4a30fa2f')
 ON CONFLICT DO NOTHING;
 
+INSERT INTO galaxy_members (galaxy_id, user_id, role, invited_by, joined, date_created, date_updated) VALUES
+    ('681672b7-95a8-4871-8832-e5774799c0e3', '5cf37266-3473-4006-984f-9325122678b7', 'OWNER', '5cf37266-3473-4006-984f-9325122678b7', true, '2019-03-24 00:00:00', '2019-03-24 00:00:00'),
+    ('681672b7-95a8-4871-8832-e5774799c0e3', '45b5fbd3-755f-4379-8f07-a58d4a30fa2f', 'EDITOR', '5cf37266-3473-4006-984f-9325122678b7', true, '2019-03-24 00:00:00', '2019-03-24 00:00:00'),
+    ('b4629864-500c-4f06-8e4a-d31cea7bcfae', '45b5fbd3-755f-4379-8f07-a58d4a30fa2f', 'OWNER', '45b5fbd3-755f-4379-8f07-a58d4a30fa2f', true, '2019-03-24 00:00:00', '2019-03-24 00:00:00')
+ON CONFLICT DO NOTHING;
+
 INSERT INTO resources (
     resource_id,
     resource_name,
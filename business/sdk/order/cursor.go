@@ -0,0 +1,87 @@
+package order
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/godwinrob/harvester/foundation/validate"
+)
+
+// Cursor represents the last row of a page in an order: the row's value of
+// the order field and its id, which breaks ties between equal values. The
+// next page is the rows that follow it, so rows inserted meanwhile neither
+// shift nor repeat the rows of later pages. The zero value starts at the
+// first row.
+type Cursor struct {
+	By    By
+	Value string
+	ID    string
+}
+
+// NewCursor constructs a cursor for the row with the value and id in the
+// order.
+func NewCursor(by By, value string, id string) Cursor {
+	return Cursor{
+		By:    by,
+		Value: value,
+		ID:    id,
+	}
+}
+
+// IsZero reports whether the cursor starts at the first row.
+func (c Cursor) IsZero() bool {
+	return c.ID == ""
+}
+
+type cursorToken struct {
+	Field     string `json:"f"`
+	Direction string `json:"d"`
+	Value     string `json:"v"`
+	ID        string `json:"i"`
+}
+
+// String returns the cursor encoded as an opaque token for a client to pass
+// back.
+func (c Cursor) String() string {
+	if c.IsZero() {
+		return ""
+	}
+
+	data, err := json.Marshal(cursorToken{
+		Field:     c.By.Field,
+		Direction: c.By.Direction,
+		Value:     c.Value,
+		ID:        c.ID,
+	})
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// ParseCursor decodes a token made by Cursor.String. The cursor must have been
+// made for the same order the query asks for. An empty token returns the zero
+// cursor.
+func ParseCursor(cursor string, orderBy By) (Cursor, error) {
+	if cursor == "" {
+		return Cursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return Cursor{}, validate.NewFieldsError("cursor", errors.New("invalid cursor"))
+	}
+
+	var ct cursorToken
+	if err := json.Unmarshal(data, &ct); err != nil || ct.ID == "" {
+		return Cursor{}, validate.NewFieldsError("cursor", errors.New("invalid cursor"))
+	}
+
+	if ct.Field != orderBy.Field || ct.Direction != orderBy.Direction {
+		return Cursor{}, validate.NewFieldsError("cursor", errors.New("cursor was made for a different order"))
+	}
+
+	return NewCursor(orderBy, ct.Value, ct.ID), nil
+}
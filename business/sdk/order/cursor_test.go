@@ -0,0 +1,81 @@
+package order
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/godwinrob/harvester/foundation/validate"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor Cursor
+	}{
+		{"ascending", NewCursor(NewBy("name", ASC), "Abcdef", "3f1e0c56-8a3b-4f6e-9d55-0f5a4c2b7e11")},
+		{"descending", NewCursor(NewBy("added_at", DESC), "2024-05-01 12:00:00+00", "3f1e0c56-8a3b-4f6e-9d55-0f5a4c2b7e11")},
+		{"empty value", NewCursor(NewBy("name", ASC), "", "3f1e0c56-8a3b-4f6e-9d55-0f5a4c2b7e11")},
+		{"special characters", NewCursor(NewBy("name", ASC), `O'Brien "&" ünïcødé/+=`, "42")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := tt.cursor.String()
+			if token == "" {
+				t.Fatal("String() is empty")
+			}
+
+			got, err := ParseCursor(token, tt.cursor.By)
+			if err != nil {
+				t.Fatalf("ParseCursor: %s", err)
+			}
+
+			if got != tt.cursor {
+				t.Errorf("ParseCursor(String()) = %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestCursorZero(t *testing.T) {
+	if token := (Cursor{}).String(); token != "" {
+		t.Errorf("zero cursor String() = %q, want empty", token)
+	}
+
+	c, err := ParseCursor("", NewBy("name", ASC))
+	if err != nil {
+		t.Fatalf("ParseCursor: %s", err)
+	}
+
+	if !c.IsZero() {
+		t.Errorf("ParseCursor(\"\") = %+v, want the zero cursor", c)
+	}
+}
+
+func TestParseCursorErrors(t *testing.T) {
+	byName := NewBy("name", ASC)
+	valid := NewCursor(byName, "Abcdef", "42").String()
+
+	tests := []struct {
+		name    string
+		cursor  string
+		orderBy By
+	}{
+		{"not base64", "not a cursor!", byName},
+		{"not json", base64.RawURLEncoding.EncodeToString([]byte("name,ASC")), byName},
+		{"no id", base64.RawURLEncoding.EncodeToString([]byte(`{"f":"name","d":"ASC","v":"Abcdef"}`)), byName},
+		{"other field", valid, NewBy("added_at", ASC)},
+		{"other direction", valid, NewBy("name", DESC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCursor(tt.cursor, tt.orderBy)
+
+			fe := validate.GetFieldErrors(err)
+			if len(fe) != 1 || fe[0].Field != "cursor" {
+				t.Errorf("ParseCursor() error = %v, want a field error on cursor", err)
+			}
+		})
+	}
+}
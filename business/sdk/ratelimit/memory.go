@@ -0,0 +1,56 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps buckets in the memory of the process, so each instance of
+// the service limits requests on its own.
+type MemoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]Bucket
+	idle    time.Duration
+	swept   time.Time
+}
+
+// NewMemoryStore constructs an in-process store. Buckets that go unused for
+// the idle duration are dropped, so idle should be at least the longest
+// budget period, after which any bucket is full again.
+func NewMemoryStore(idle time.Duration) *MemoryStore {
+	return &MemoryStore{
+		buckets: make(map[string]Bucket),
+		idle:    idle,
+		swept:   time.Now(),
+	}
+}
+
+// Update applies fn to the bucket of the key and stores the result.
+func (s *MemoryStore) Update(ctx context.Context, key string, fn func(Bucket) Bucket) (Bucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep()
+
+	b := fn(s.buckets[key])
+	s.buckets[key] = b
+
+	return b, nil
+}
+
+// sweep drops the buckets that have been idle, at most once per idle period.
+func (s *MemoryStore) sweep() {
+	now := time.Now()
+	if now.Sub(s.swept) < s.idle {
+		return
+	}
+
+	for key, b := range s.buckets {
+		if now.Sub(b.DateUpdated) >= s.idle {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.swept = now
+}
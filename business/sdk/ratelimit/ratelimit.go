@@ -0,0 +1,126 @@
+// Package ratelimit provides support for limiting the rate of requests a
+// caller can make with token buckets.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Budget is the number of requests a caller may make over a period. A caller
+// can spend the whole budget at once, after which it refills evenly over the
+// period.
+type Budget struct {
+	Name   string
+	Limit  int
+	Period time.Duration
+}
+
+// NewBudget constructs a budget, checking the limit and period are positive.
+func NewBudget(name string, limit int, period time.Duration) (Budget, error) {
+	switch {
+	case limit < 1:
+		return Budget{}, fmt.Errorf("%s limit %d must be at least 1", name, limit)
+	case period <= 0:
+		return Budget{}, fmt.Errorf("%s period %s must be positive", name, period)
+	}
+
+	b := Budget{
+		Name:   name,
+		Limit:  limit,
+		Period: period,
+	}
+
+	return b, nil
+}
+
+// rate returns the number of tokens the budget refills per second.
+func (b Budget) rate() float64 {
+	return float64(b.Limit) / b.Period.Seconds()
+}
+
+// refill returns the time the budget takes to refill the tokens, rounded up
+// to the second.
+func (b Budget) refill(tokens float64) time.Duration {
+	return time.Duration(math.Ceil(tokens/b.rate())) * time.Second
+}
+
+// Bucket is the state of a caller's budget as it was last updated. A bucket
+// that was never updated is full.
+type Bucket struct {
+	Tokens      float64
+	DateUpdated time.Time
+}
+
+// Quota is the state of a caller's budget after a request.
+type Quota struct {
+	Budget     Budget
+	Allowed    bool
+	Remaining  int
+	Reset      time.Duration
+	RetryAfter time.Duration
+}
+
+// Storer interface declares the behavior this package needs to persist and
+// retrieve buckets. Update must apply fn to the bucket of the key and store
+// the result atomically, so concurrent requests can't spend the same token.
+type Storer interface {
+	Update(ctx context.Context, key string, fn func(Bucket) Bucket) (Bucket, error)
+}
+
+// Limiter manages the set of APIs for rate limiting.
+type Limiter struct {
+	storer Storer
+}
+
+// NewLimiter constructs a rate limiter for use.
+func NewLimiter(storer Storer) *Limiter {
+	return &Limiter{
+		storer: storer,
+	}
+}
+
+// Allow takes a token from the caller's bucket of the budget, refilling it
+// for the time passed since it was last used. The request is allowed when a
+// token was left to take.
+func (l *Limiter) Allow(ctx context.Context, key string, budget Budget) (Quota, error) {
+	now := time.Now()
+	limit := float64(budget.Limit)
+
+	var allowed bool
+
+	bucket, err := l.storer.Update(ctx, budget.Name+":"+key, func(b Bucket) Bucket {
+		tokens := limit
+		if !b.DateUpdated.IsZero() {
+			tokens = min(limit, b.Tokens+now.Sub(b.DateUpdated).Seconds()*budget.rate())
+		}
+
+		allowed = tokens >= 1
+		if allowed {
+			tokens--
+		}
+
+		return Bucket{
+			Tokens:      tokens,
+			DateUpdated: now,
+		}
+	})
+	if err != nil {
+		return Quota{}, fmt.Errorf("update: %w", err)
+	}
+
+	q := Quota{
+		Budget:    budget,
+		Allowed:   allowed,
+		Remaining: int(bucket.Tokens),
+		Reset:     budget.refill(limit - bucket.Tokens),
+	}
+
+	if !allowed {
+		q.RetryAfter = budget.refill(1 - bucket.Tokens)
+	}
+
+	return q, nil
+}
@@ -0,0 +1,188 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestNewBudget(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   int
+		period  time.Duration
+		wantErr bool
+	}{
+		{"valid", 10, time.Minute, false},
+		{"zero limit", 0, time.Minute, true},
+		{"negative limit", -1, time.Minute, true},
+		{"zero period", 10, 0, true},
+		{"negative period", 10, -time.Second, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBudget("read", tt.limit, tt.period)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewBudget(%d, %s) error = %v, want error %t", tt.limit, tt.period, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLimiterAllow(t *testing.T) {
+	// The budget refills a token every 6 seconds.
+	budget := Budget{
+		Name:   "write",
+		Limit:  10,
+		Period: time.Minute,
+	}
+
+	tests := []struct {
+		name           string
+		bucket         *Bucket
+		age            time.Duration
+		wantAllowed    bool
+		wantRemaining  int
+		wantReset      time.Duration
+		wantRetryAfter time.Duration
+	}{
+		{"new caller", nil, 0, true, 9, 6 * time.Second, 0},
+		{"tokens left", &Bucket{Tokens: 4}, 0, true, 3, 42 * time.Second, 0},
+		{"last token", &Bucket{Tokens: 1}, 0, true, 0, time.Minute, 0},
+		{"empty", &Bucket{Tokens: 0}, 0, false, 0, time.Minute, 6 * time.Second},
+		{"half refilled", &Bucket{Tokens: 0}, 3 * time.Second, false, 0, 57 * time.Second, 3 * time.Second},
+		{"refilled a token", &Bucket{Tokens: 0}, 6500 * time.Millisecond, true, 0, time.Minute, 0},
+		{"refills no more than the limit", &Bucket{Tokens: 2}, time.Hour, true, 9, 6 * time.Second, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storer := newMemoryStorer()
+
+			if tt.bucket != nil {
+				b := *tt.bucket
+				b.DateUpdated = time.Now().Add(-tt.age)
+				storer.buckets["write:caller"] = b
+			}
+
+			l := NewLimiter(storer)
+
+			q, err := l.Allow(context.Background(), "caller", budget)
+			if err != nil {
+				t.Fatalf("Allow: %s", err)
+			}
+
+			if q.Allowed != tt.wantAllowed {
+				t.Errorf("allowed = %t, want %t", q.Allowed, tt.wantAllowed)
+			}
+
+			if q.Remaining != tt.wantRemaining {
+				t.Errorf("remaining = %d, want %d", q.Remaining, tt.wantRemaining)
+			}
+
+			if q.Reset != tt.wantReset {
+				t.Errorf("reset = %s, want %s", q.Reset, tt.wantReset)
+			}
+
+			if q.RetryAfter != tt.wantRetryAfter {
+				t.Errorf("retry after = %s, want %s", q.RetryAfter, tt.wantRetryAfter)
+			}
+
+			if q.Budget != budget {
+				t.Errorf("budget = %+v, want %+v", q.Budget, budget)
+			}
+		})
+	}
+}
+
+func TestLimiterAllowSpendsBudget(t *testing.T) {
+	budget := Budget{
+		Name:   "bulk",
+		Limit:  3,
+		Period: time.Hour,
+	}
+
+	storer := newMemoryStorer()
+	l := NewLimiter(storer)
+
+	for i := range budget.Limit {
+		q, err := l.Allow(context.Background(), "caller", budget)
+		if err != nil {
+			t.Fatalf("Allow: %s", err)
+		}
+
+		if !q.Allowed || q.Remaining != budget.Limit-i-1 {
+			t.Fatalf("request %d: allowed %t with %d remaining, want allowed with %d", i, q.Allowed, q.Remaining, budget.Limit-i-1)
+		}
+	}
+
+	q, err := l.Allow(context.Background(), "caller", budget)
+	if err != nil {
+		t.Fatalf("Allow: %s", err)
+	}
+
+	if q.Allowed {
+		t.Error("request over the limit was allowed")
+	}
+
+	other, err := l.Allow(context.Background(), "other", budget)
+	if err != nil {
+		t.Fatalf("Allow: %s", err)
+	}
+
+	if !other.Allowed {
+		t.Error("another caller shares the bucket")
+	}
+
+	read, err := l.Allow(context.Background(), "caller", Budget{Name: "read", Limit: 1, Period: time.Hour})
+	if err != nil {
+		t.Fatalf("Allow: %s", err)
+	}
+
+	if !read.Allowed {
+		t.Error("another budget shares the bucket")
+	}
+}
+
+func TestLimiterAllowStoreError(t *testing.T) {
+	storer := newMemoryStorer()
+	storer.err = errors.New("store down")
+
+	l := NewLimiter(storer)
+
+	if _, err := l.Allow(context.Background(), "caller", Budget{Name: "read", Limit: 1, Period: time.Second}); !errors.Is(err, storer.err) {
+		t.Errorf("Allow error = %v, want %v", err, storer.err)
+	}
+}
+
+// =============================================================================
+
+// memoryStorer keeps buckets in memory.
+type memoryStorer struct {
+	mu      sync.Mutex
+	buckets map[string]Bucket
+	err     error
+}
+
+func newMemoryStorer() *memoryStorer {
+	return &memoryStorer{
+		buckets: make(map[string]Bucket),
+	}
+}
+
+func (s *memoryStorer) Update(ctx context.Context, key string, fn func(Bucket) Bucket) (Bucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return Bucket{}, s.err
+	}
+
+	b := fn(s.buckets[key])
+	s.buckets[key] = b
+
+	return b, nil
+}
@@ -0,0 +1,28 @@
+package ratelimitdb
+
+import (
+	"time"
+
+	"github.com/godwinrob/harvester/business/sdk/ratelimit"
+)
+
+type bucket struct {
+	Key         string    `db:"rate_key"`
+	Tokens      float64   `db:"tokens"`
+	DateUpdated time.Time `db:"date_updated"`
+}
+
+func toDBBucket(key string, bus ratelimit.Bucket) bucket {
+	return bucket{
+		Key:         key,
+		Tokens:      bus.Tokens,
+		DateUpdated: bus.DateUpdated.UTC(),
+	}
+}
+
+func toBusBucket(db bucket) ratelimit.Bucket {
+	return ratelimit.Bucket{
+		Tokens:      db.Tokens,
+		DateUpdated: db.DateUpdated.In(time.Local),
+	}
+}
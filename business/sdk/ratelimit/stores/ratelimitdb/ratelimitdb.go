@@ -0,0 +1,144 @@
+// Package ratelimitdb contains rate limit bucket storage in the database, so
+// every instance of the service shares the same buckets.
+package ratelimitdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/godwinrob/harvester/business/sdk/ratelimit"
+	"github.com/godwinrob/harvester/business/sdk/sqldb"
+	"github.com/godwinrob/harvester/foundation/logger"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Store manages the set of APIs for rate limit database access.
+type Store struct {
+	log  *logger.Logger
+	db   sqlx.ExtContext
+	idle time.Duration
+
+	mu     sync.Mutex
+	purged time.Time
+}
+
+// NewStore constructs the api for data access. Buckets that go unused for the
+// idle duration are deleted, so idle should be at least the longest budget
+// period, after which any bucket is full again.
+func NewStore(log *logger.Logger, db *sqlx.DB, idle time.Duration) *Store {
+	return &Store{
+		log:    log,
+		db:     db,
+		idle:   idle,
+		purged: time.Now(),
+	}
+}
+
+// Update applies fn to the bucket of the key and stores the result. The row
+// of the bucket is locked for the transaction, so requests to other instances
+// wait for the update instead of spending the same token.
+func (s *Store) Update(ctx context.Context, key string, fn func(ratelimit.Bucket) ratelimit.Bucket) (ratelimit.Bucket, error) {
+	db, ok := s.db.(*sqlx.DB)
+	if !ok {
+		return ratelimit.Bucket{}, errors.New("update requires *sqlx.DB")
+	}
+
+	s.purge(ctx)
+
+	var bus ratelimit.Bucket
+
+	err := sqldb.WithTransaction(db, func(tx *sqlx.Tx) error {
+
+		// A new key gets a bucket that was never updated, which is full, so
+		// there is a row to lock.
+		const insert = `
+		INSERT INTO rate_limits
+			(rate_key, tokens, date_updated)
+		VALUES
+			(:rate_key, :tokens, :date_updated)
+		ON CONFLICT (rate_key) DO NOTHING`
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, insert, toDBBucket(key, ratelimit.Bucket{})); err != nil {
+			return fmt.Errorf("insert: %w", err)
+		}
+
+		data := struct {
+			Key string `db:"rate_key"`
+		}{
+			Key: key,
+		}
+
+		const query = `
+		SELECT
+			rate_key, tokens, date_updated
+		FROM
+			rate_limits
+		WHERE
+			rate_key = :rate_key
+		FOR UPDATE`
+
+		var dbBucket bucket
+		if err := sqldb.NamedQueryStruct(ctx, s.log, tx, query, data, &dbBucket); err != nil {
+			return fmt.Errorf("query: %w", err)
+		}
+
+		bus = fn(toBusBucket(dbBucket))
+
+		const update = `
+		UPDATE
+			rate_limits
+		SET
+			"tokens" = :tokens,
+			"date_updated" = :date_updated
+		WHERE
+			rate_key = :rate_key`
+
+		if err := sqldb.NamedExecContextWithTx(ctx, s.log, tx, update, toDBBucket(key, bus)); err != nil {
+			return fmt.Errorf("update: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ratelimit.Bucket{}, err
+	}
+
+	return bus, nil
+}
+
+// purge deletes the buckets that have been idle, at most once per idle period
+// for each instance. A failed purge is only logged, it's tried again after
+// the next period.
+func (s *Store) purge(ctx context.Context) {
+	s.mu.Lock()
+	now := time.Now()
+	due := now.Sub(s.purged) >= s.idle
+	if due {
+		s.purged = now
+	}
+	s.mu.Unlock()
+
+	if !due {
+		return
+	}
+
+	data := struct {
+		Before time.Time `db:"before"`
+	}{
+		Before: now.Add(-s.idle).UTC(),
+	}
+
+	const q = `
+	DELETE FROM
+		rate_limits
+	WHERE
+		date_updated < :before`
+
+	if err := sqldb.NamedExecContext(ctx, s.log, s.db, q, data); err != nil {
+		s.log.Error(ctx, "ratelimit", "status", "purging idle buckets", "ERROR", err)
+	}
+}
@@ -0,0 +1,59 @@
+package sqldb
+
+import (
+	"strings"
+
+	"github.com/godwinrob/harvester/business/sdk/order"
+)
+
+// Keyset describes the order of a query's rows for keyset paging: the
+// expression the rows are ordered by, the column holding the row id that
+// breaks ties between equal values, and the direction. The expression must
+// not be NULL for any row.
+type Keyset struct {
+	Expr      string
+	ID        string
+	Direction string
+}
+
+// Query wraps the query to return the page of rows that follow the cursor in
+// the order. Each row carries its order key in the text columns cursor_value
+// and cursor_id, which a scan type receives by embedding Keyed. Unlike an
+// OFFSET the page does not move when rows are inserted ahead of it, and the
+// database seeks straight to it rather than reading past the earlier rows.
+func (k Keyset) Query(query string, cursor order.Cursor, rowsPerPage int, data map[string]any) string {
+	var b strings.Builder
+
+	b.WriteString("SELECT page.*, CAST(" + k.Expr + " AS TEXT) AS cursor_value, CAST(" + k.ID + " AS TEXT) AS cursor_id FROM (")
+	b.WriteString(query)
+	b.WriteString(") AS page")
+
+	if !cursor.IsZero() {
+		op := ">"
+		if k.Direction == order.DESC {
+			op = "<"
+		}
+
+		b.WriteString(" WHERE (" + k.Expr + ", " + k.ID + ") " + op + " (:cursor_value, :cursor_id)")
+		data["cursor_value"] = cursor.Value
+		data["cursor_id"] = cursor.ID
+	}
+
+	b.WriteString(" ORDER BY " + k.Expr + " " + k.Direction + ", " + k.ID + " " + k.Direction)
+	b.WriteString(" FETCH NEXT :rows_per_page ROWS ONLY")
+	data["rows_per_page"] = rowsPerPage
+
+	return b.String()
+}
+
+// Keyed receives the order key columns added by Keyset.Query when embedded
+// in a row's scan type.
+type Keyed struct {
+	CursorValue string `db:"cursor_value"`
+	CursorID    string `db:"cursor_id"`
+}
+
+// Cursor returns the cursor that starts after the row.
+func (k Keyed) Cursor(orderBy order.By) order.Cursor {
+	return order.NewCursor(orderBy, k.CursorValue, k.CursorID)
+}
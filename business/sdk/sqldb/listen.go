@@ -0,0 +1,70 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/jmoiron/sqlx"
+)
+
+// NotifyWithTx sends a notification with the payload on the channel as part of
+// the transaction. Listeners only receive it once the transaction commits.
+func NotifyWithTx(ctx context.Context, log *logger.Logger, tx *sqlx.Tx, channel string, payload string) error {
+	data := struct {
+		Channel string `db:"channel"`
+		Payload string `db:"payload"`
+	}{
+		Channel: channel,
+		Payload: payload,
+	}
+
+	const q = `SELECT pg_notify(:channel, :payload)`
+
+	return NamedExecContextWithTx(ctx, log, tx, q, data)
+}
+
+// Listen listens on the channel and calls fn with the payload of every
+// notification until the context is canceled or the connection fails. It
+// holds a connection of the pool for as long as it runs; the connection is
+// discarded afterwards rather than returned to the pool still listening.
+func Listen(ctx context.Context, db *sqlx.DB, channel string, fn func(payload string)) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("conn: %w", err)
+	}
+	defer conn.Close()
+
+	var listenErr error
+	conn.Raw(func(driverConn any) error {
+		c, ok := driverConn.(*stdlib.Conn)
+		if !ok {
+			listenErr = fmt.Errorf("listen requires a pgx connection, got %T", driverConn)
+			return nil
+		}
+
+		pc := c.Conn()
+
+		if _, err := pc.Exec(ctx, "LISTEN "+pgx.Identifier{channel}.Sanitize()); err != nil {
+			listenErr = fmt.Errorf("listen: %w", err)
+			return driver.ErrBadConn
+		}
+
+		for {
+			n, err := pc.WaitForNotification(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					listenErr = fmt.Errorf("wait: %w", err)
+				}
+				return driver.ErrBadConn
+			}
+
+			fn(n.Payload)
+		}
+	})
+
+	return listenErr
+}
@@ -0,0 +1,45 @@
+package sqldb
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// Set of operations the query metrics are labelled with, one for each kind
+// of helper. The duration of a query_each includes the time the caller spends
+// on the rows as they are read.
+const (
+	opExec        = "exec"
+	opExecIn      = "exec_in"
+	opQuerySlice  = "query_slice"
+	opQueryEach   = "query_each"
+	opQueryStruct = "query_struct"
+)
+
+var queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "harvester",
+	Subsystem: "db",
+	Name:      "query_duration_seconds",
+	Help:      "Time taken by a database call, by operation.",
+	Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+}, []string{"operation"})
+
+var queryErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "harvester",
+	Subsystem: "db",
+	Name:      "query_errors_total",
+	Help:      "Number of database calls that failed, by operation.",
+}, []string{"operation"})
+
+// observe records a database call of the operation that started at start and
+// ended with err. A query that finds no row has not failed.
+func observe(operation string, start time.Time, err error) {
+	queryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+
+	if err != nil && !errors.Is(err, ErrDBNotFound) {
+		queryErrors.WithLabelValues(operation).Inc()
+	}
+}
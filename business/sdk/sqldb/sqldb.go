@@ -70,8 +70,14 @@ func WithTransaction(db Transactor, fn func(tx *sqlx.Tx) error) (err error) {
 // a transaction with logging and tracing where field replacement is necessary.
 func NamedExecContextWithTx(ctx context.Context, log *logger.Logger, tx *sqlx.Tx, query string, data any) (err error) {
 	q := queryString(query, data)
+	start := time.Now()
+
+	ctx, span := startSpan(ctx, opExec, query)
 
 	defer func() {
+		observe(opExec, start, err)
+		endSpan(span, err)
+
 		if err != nil {
 			switch data.(type) {
 			case struct{}:
@@ -101,8 +107,14 @@ func NamedExecContextWithTx(ctx context.Context, log *logger.Logger, tx *sqlx.Tx
 // IN clause support, logging and tracing where field replacement is necessary.
 func NamedExecContextUsingIn(ctx context.Context, log *logger.Logger, db sqlx.ExtContext, query string, data any) (err error) {
 	q := queryString(query, data)
+	start := time.Now()
+
+	ctx, span := startSpan(ctx, opExecIn, query)
 
 	defer func() {
+		observe(opExecIn, start, err)
+		endSpan(span, err)
+
 		if err != nil {
 			log.Infoc(ctx, 5, "database.NamedExecContextUsingIn", "query", q, "ERROR", err)
 		}
@@ -138,8 +150,14 @@ func NamedExecContextUsingIn(ctx context.Context, log *logger.Logger, db sqlx.Ex
 // within a transaction with IN clause support, logging and tracing.
 func NamedExecContextUsingInWithTx(ctx context.Context, log *logger.Logger, tx *sqlx.Tx, query string, data any) (err error) {
 	q := queryString(query, data)
+	start := time.Now()
+
+	ctx, span := startSpan(ctx, opExecIn, query)
 
 	defer func() {
+		observe(opExecIn, start, err)
+		endSpan(span, err)
+
 		if err != nil {
 			log.Infoc(ctx, 5, "database.NamedExecContextUsingInWithTx", "query", q, "ERROR", err)
 		}
@@ -259,8 +277,14 @@ func ExecContext(ctx context.Context, log *logger.Logger, db sqlx.ExtContext, qu
 // logging and tracing where field replacement is necessary.
 func NamedExecContext(ctx context.Context, log *logger.Logger, db sqlx.ExtContext, query string, data any) (err error) {
 	q := queryString(query, data)
+	start := time.Now()
+
+	ctx, span := startSpan(ctx, opExec, query)
 
 	defer func() {
+		observe(opExec, start, err)
+		endSpan(span, err)
+
 		if err != nil {
 			switch data.(type) {
 			case struct{}:
@@ -308,8 +332,14 @@ func NamedQuerySliceUsingIn[T any](ctx context.Context, log *logger.Logger, db s
 
 func namedQuerySlice[T any](ctx context.Context, log *logger.Logger, db sqlx.ExtContext, query string, data any, dest *[]T, withIn bool) (err error) {
 	q := queryString(query, data)
+	start := time.Now()
+
+	ctx, span := startSpan(ctx, opQuerySlice, query)
 
 	defer func() {
+		observe(opQuerySlice, start, err)
+		endSpan(span, err)
+
 		if err != nil {
 			log.Infoc(ctx, 6, "database.NamedQuerySlice", "query", q, "ERROR", err)
 		}
@@ -359,6 +389,48 @@ func namedQuerySlice[T any](ctx context.Context, log *logger.Logger, db sqlx.Ext
 	return nil
 }
 
+// NamedQueryEach is a helper function for executing queries that return a
+// collection of data too large to hold in memory. Each row is unmarshalled
+// and passed to fn as it is read from the result cursor, and iteration stops
+// at the first error fn returns.
+func NamedQueryEach[T any](ctx context.Context, log *logger.Logger, db sqlx.ExtContext, query string, data any, fn func(T) error) (err error) {
+	q := queryString(query, data)
+	start := time.Now()
+
+	ctx, span := startSpan(ctx, opQueryEach, query)
+
+	defer func() {
+		observe(opQueryEach, start, err)
+		endSpan(span, err)
+
+		if err != nil {
+			log.Infoc(ctx, 6, "database.NamedQueryEach", "query", q, "ERROR", err)
+		}
+	}()
+
+	rows, err := sqlx.NamedQueryContext(ctx, db, query, data)
+	if err != nil {
+		if pqerr, ok := err.(*pgconn.PgError); ok && pqerr.Code == undefinedTable {
+			return ErrUndefinedTable
+		}
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var v T
+		if err := rows.StructScan(&v); err != nil {
+			return err
+		}
+
+		if err := fn(v); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 // QueryStruct is a helper function for executing queries that return a
 // single value to be unmarshalled into a struct type where field replacement is necessary.
 func QueryStruct(ctx context.Context, log *logger.Logger, db sqlx.ExtContext, query string, dest any) error {
@@ -380,8 +452,14 @@ func NamedQueryStructUsingIn(ctx context.Context, log *logger.Logger, db sqlx.Ex
 
 func namedQueryStruct(ctx context.Context, log *logger.Logger, db sqlx.ExtContext, query string, data any, dest any, withIn bool) (err error) {
 	q := queryString(query, data)
+	start := time.Now()
+
+	ctx, span := startSpan(ctx, opQueryStruct, query)
 
 	defer func() {
+		observe(opQueryStruct, start, err)
+		endSpan(span, err)
+
 		if err != nil {
 			log.Infoc(ctx, 6, "database.NamedQuerySlice", "query", q, "ERROR", err)
 		}
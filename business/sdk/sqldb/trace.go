@@ -0,0 +1,35 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/godwinrob/harvester/foundation/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startSpan starts the span of a database call. The span records the query
+// as written, with its named parameters, and never the values bound to them,
+// which can be password hashes, emails and other user data.
+func startSpan(ctx context.Context, operation string, query string) (context.Context, trace.Span) {
+	return otel.AddSpan(ctx, "business.sdk.sqldb."+operation, attribute.String("query", spanQuery(query)))
+}
+
+// spanQuery collapses the whitespace of a query onto a single line.
+func spanQuery(query string) string {
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// endSpan ends the span of a database call that ended with err. Like the
+// metrics, a query that finds no row is not marked as failed.
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, ErrDBNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,164 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/godwinrob/harvester/foundation/logger"
+	"github.com/godwinrob/harvester/foundation/otel"
+	"github.com/jmoiron/sqlx"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSpanQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{"one line", "SELECT 1", "SELECT 1"},
+		{"indented", "\n\tSELECT\n\t\tuser_id, email\n\tFROM\n\t\tusers\n\tWHERE\n\t\temail = :email", "SELECT user_id, email FROM users WHERE email = :email"},
+		{"spaces", "  UPDATE  users SET name = :name  ", "UPDATE users SET name = :name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spanQuery(tt.query); got != tt.want {
+				t.Errorf("spanQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpansOmitBoundValues(t *testing.T) {
+	const secret = "hunter2-secret-value"
+
+	const q = `
+	UPDATE
+		users
+	SET
+		password_hash = :password_hash
+	WHERE
+		email IN (:emails)`
+
+	data := struct {
+		PasswordHash string   `db:"password_hash"`
+		Emails       []string `db:"emails"`
+	}{
+		PasswordHash: secret,
+		Emails:       []string{secret + "@example.com"},
+	}
+
+	const want = "UPDATE users SET password_hash = :password_hash WHERE email IN (:emails)"
+
+	log := logger.New(io.Discard, logger.LevelError, "TEST", func(context.Context) string { return "" })
+
+	tests := []struct {
+		name string
+		span string
+		call func(ctx context.Context) error
+	}{
+		{"exec", "business.sdk.sqldb." + opExec, func(ctx context.Context) error {
+			return NamedExecContext(ctx, log, failingDB{}, q, data)
+		}},
+		{"exec in", "business.sdk.sqldb." + opExecIn, func(ctx context.Context) error {
+			return NamedExecContextUsingIn(ctx, log, failingDB{}, q, data)
+		}},
+		{"query struct", "business.sdk.sqldb." + opQueryStruct, func(ctx context.Context) error {
+			var dest struct{}
+			return NamedQueryStruct(ctx, log, failingDB{}, q, data, &dest)
+		}},
+		{"query slice", "business.sdk.sqldb." + opQuerySlice, func(ctx context.Context) error {
+			var dest []struct{}
+			return NamedQuerySliceUsingIn(ctx, log, failingDB{}, q, data, &dest)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+			defer provider.Shutdown(context.Background())
+
+			ctx := otel.InjectTracing(context.Background(), provider.Tracer("test"))
+
+			if err := tt.call(ctx); !errors.Is(err, errNoDatabase) {
+				t.Fatalf("call error = %v, want %v", err, errNoDatabase)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans, want 1", len(spans))
+			}
+
+			span := spans[0]
+			if span.Name() != tt.span {
+				t.Errorf("span name = %q, want %q", span.Name(), tt.span)
+			}
+
+			var query string
+			for _, attr := range span.Attributes() {
+				if strings.Contains(attr.Value.Emit(), secret) {
+					t.Errorf("attribute %s holds a bound value: %s", attr.Key, attr.Value.Emit())
+				}
+
+				if attr.Key == "query" {
+					query = attr.Value.AsString()
+				}
+			}
+
+			if query != want {
+				t.Errorf("query attribute = %q, want %q", query, want)
+			}
+
+			for _, evt := range span.Events() {
+				for _, attr := range evt.Attributes {
+					if strings.Contains(attr.Value.Emit(), secret) {
+						t.Errorf("event %s attribute %s holds a bound value", evt.Name, attr.Key)
+					}
+				}
+			}
+		})
+	}
+}
+
+// =============================================================================
+
+var errNoDatabase = errors.New("no database")
+
+// failingDB is a database whose every call fails, so the spans of the calls
+// can be checked without one.
+type failingDB struct{}
+
+func (failingDB) DriverName() string {
+	return "pgx"
+}
+
+func (failingDB) Rebind(query string) string {
+	return sqlx.Rebind(sqlx.DOLLAR, query)
+}
+
+func (failingDB) BindNamed(query string, arg any) (string, []any, error) {
+	return sqlx.BindNamed(sqlx.DOLLAR, query, arg)
+}
+
+func (failingDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (failingDB) QueryxContext(ctx context.Context, query string, args ...any) (*sqlx.Rows, error) {
+	return nil, errNoDatabase
+}
+
+func (failingDB) QueryRowxContext(ctx context.Context, query string, args ...any) *sqlx.Row {
+	return nil
+}
+
+func (failingDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return nil, errNoDatabase
+}
@@ -0,0 +1,20 @@
+package otel
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type ctxKey int
+
+const tracerKey ctxKey = 1
+
+func setTracer(ctx context.Context, tracer trace.Tracer) context.Context {
+	return context.WithValue(ctx, tracerKey, tracer)
+}
+
+func getTracer(ctx context.Context) (trace.Tracer, bool) {
+	v, ok := ctx.Value(tracerKey).(trace.Tracer)
+	return v, ok
+}
@@ -0,0 +1,94 @@
+// Package otel provides support for initializing OpenTelemetry tracing and
+// adding spans to a call chain.
+package otel
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/godwinrob/harvester/foundation/logger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config defines the information needed to init tracing.
+type Config struct {
+	ServiceName string
+	Host        string
+	Probability float64
+}
+
+// InitTracing configures OpenTelemetry tracing for the service and returns the
+// tracer provider along with a function to flush and release it on shutdown.
+// Spans are exported over OTLP/HTTP to the host. Without a host nothing is
+// exported, but spans are still created so trace ids reach the logs.
+func InitTracing(log *logger.Logger, cfg Config) (trace.TracerProvider, func(ctx context.Context), error) {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Probability))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(cfg.ServiceName),
+		)),
+	}
+
+	if cfg.Host != "" {
+		exporter, err := otlptracehttp.New(
+			context.Background(),
+			otlptracehttp.WithEndpoint(cfg.Host),
+			otlptracehttp.WithInsecure(),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("creating new exporter: %w", err)
+		}
+
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	traceProvider := sdktrace.NewTracerProvider(opts...)
+
+	// The global provider and propagator are used by anything that is not
+	// handed a tracer, such as spans started outside of a request.
+	otel.SetTracerProvider(traceProvider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	teardown := func(ctx context.Context) {
+		if err := traceProvider.Shutdown(ctx); err != nil {
+			log.Error(ctx, "otel", "status", "shutting down tracer provider", "ERROR", err)
+		}
+	}
+
+	return traceProvider, teardown, nil
+}
+
+// InjectTracing stores the tracer in the context so spans further down the
+// call chain are started from it.
+func InjectTracing(ctx context.Context, tracer trace.Tracer) context.Context {
+	return setTracer(ctx, tracer)
+}
+
+// AddSpan adds an OpenTelemetry span to the trace and context. The span is
+// started from the tracer in the context, or from the global tracer provider
+// when there is none.
+func AddSpan(ctx context.Context, spanName string, keyValues ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer, ok := getTracer(ctx)
+	if !ok {
+		tracer = otel.GetTracerProvider().Tracer("harvester")
+	}
+
+	ctx, span := tracer.Start(ctx, spanName)
+	span.SetAttributes(keyValues...)
+
+	return ctx, span
+}
+
+// GetTraceID returns the trace id of the span in the context. A context
+// without a span returns an id of zeros.
+func GetTraceID(ctx context.Context) string {
+	return trace.SpanFromContext(ctx).SpanContext().TraceID().String()
+}
@@ -1,23 +1,73 @@
 package web
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
 
 type ctxKey int
 
 const (
-	traceKey ctxKey = iota + 1
+	writerKey ctxKey = iota + 1
+	headerKey
+	controllerKey
 )
 
-func setTraceID(ctx context.Context, traceID string) context.Context {
-	return context.WithValue(ctx, traceKey, traceID)
+func setWriter(ctx context.Context, w *streamWriter) context.Context {
+	return context.WithValue(ctx, writerKey, w)
 }
 
-// GetTraceID returns the trace id from the context.
-func GetTraceID(ctx context.Context) string {
-	v, ok := ctx.Value(traceKey).(string)
+func getWriter(ctx context.Context) *streamWriter {
+	v, ok := ctx.Value(writerKey).(*streamWriter)
 	if !ok {
-		return "00000000-0000-0000-0000-000000000000"
+		return nil
 	}
 
 	return v
 }
+
+func setHeader(ctx context.Context, h http.Header) context.Context {
+	return context.WithValue(ctx, headerKey, h)
+}
+
+// Header returns the header of the response to the request, so middleware
+// can add headers to it before the response is written. A context without a
+// response returns an empty header.
+func Header(ctx context.Context) http.Header {
+	v, ok := ctx.Value(headerKey).(http.Header)
+	if !ok {
+		return http.Header{}
+	}
+
+	return v
+}
+
+func setController(ctx context.Context, rc *http.ResponseController) context.Context {
+	return context.WithValue(ctx, controllerKey, rc)
+}
+
+// ExtendDeadlines moves the read and write deadlines of the connection serving
+// the request to d from now, for handlers that take longer than the server's
+// timeouts allow, such as reading a large body. A context without a response
+// is left alone.
+func ExtendDeadlines(ctx context.Context, d time.Duration) error {
+	rc, ok := ctx.Value(controllerKey).(*http.ResponseController)
+	if !ok {
+		return nil
+	}
+
+	deadline := time.Now().Add(d)
+
+	if err := rc.SetReadDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("set read deadline: %w", err)
+	}
+
+	if err := rc.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("set write deadline: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package web
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// corsMaxAge is how long, in seconds, a browser may cache a preflight response.
+const corsMaxAge = 24 * 60 * 60
+
+// EnableCORS allows browsers on the origins to call the application. A "*"
+// origin allows any. Preflight requests are answered for the routes added
+// after it, so it must be called before any routes are added.
+func (app *App) EnableCORS(origins []string) {
+	app.origins = origins
+	app.corsRoutes = make(map[string]bool)
+
+	// Preflight requests carry no credentials, so they don't go through the
+	// middleware.
+	app.ServeMux.HandleFunc(http.MethodOptions+" /", app.preflight)
+}
+
+// addCORS records the pattern so preflight requests for its path allow its
+// method.
+func (app *App) addCORS(pattern string) {
+	if app.corsRoutes == nil {
+		return
+	}
+
+	method, _, ok := strings.Cut(pattern, " ")
+	if !ok {
+		return
+	}
+
+	app.corsRoutes[pattern] = true
+
+	if !slices.Contains(app.corsMethods, method) {
+		app.corsMethods = append(app.corsMethods, method)
+	}
+}
+
+// preflight answers a CORS preflight request with the methods routed for the
+// path and the headers those methods accept. The mux is asked which route each
+// method of the request's path goes to, so the answer follows the same
+// matching as the request itself.
+func (app *App) preflight(w http.ResponseWriter, r *http.Request) {
+	methods := []string{http.MethodOptions}
+	var body bool
+
+	for _, method := range app.corsMethods {
+		probe := r.Clone(r.Context())
+		probe.Method = method
+
+		if _, pattern := app.ServeMux.Handler(probe); app.corsRoutes[pattern] {
+			methods = append(methods, method)
+
+			switch method {
+			case http.MethodPost, http.MethodPut, http.MethodPatch:
+				body = true
+			}
+		}
+	}
+
+	if len(methods) == 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	if app.allowOrigin(w, r) {
+		headers := []string{"Authorization", "traceparent"}
+		if body {
+			headers = append(headers, "Content-Type")
+		}
+
+		h := w.Header()
+		h.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+		h.Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+		h.Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// allowOrigin sets the allowed origin of the response when the request comes
+// from an origin in the config, and reports whether it did.
+func (app *App) allowOrigin(w http.ResponseWriter, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || app.corsRoutes == nil {
+		return false
+	}
+
+	h := w.Header()
+	h.Add("Vary", "Origin")
+
+	for _, allowed := range app.origins {
+		if allowed != "*" && allowed != origin {
+			continue
+		}
+
+		h.Set("Access-Control-Allow-Origin", allowed)
+
+		// Let the caller read every response header, like the rate limit
+		// quota.
+		h.Set("Access-Control-Expose-Headers", "*")
+
+		return true
+	}
+
+	return false
+}
@@ -5,8 +5,21 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 )
 
+// errStreamBroken reports a streamed response that failed after it started.
+var errStreamBroken = errors.New("stream broken")
+
+// stream adapts a StreamEncoder to the Encoder a handler returns.
+type stream struct {
+	StreamEncoder
+}
+
+func (s stream) Encode() ([]byte, string, error) {
+	return nil, "", errors.New("a stream must be written with EncodeTo")
+}
+
 type httpStatus interface {
 	HTTPStatus() int
 }
@@ -22,6 +35,11 @@ func respondError(ctx context.Context, w http.ResponseWriter, err error) error {
 
 func respond(ctx context.Context, w http.ResponseWriter, data Encoder) error {
 
+	// A streaming handler has already written its response.
+	if _, ok := data.(noResponse); ok {
+		return nil
+	}
+
 	// If the context has been canceled, it means the client is no longer
 	// waiting for a response.
 	if err := ctx.Err(); err != nil {
@@ -35,6 +53,10 @@ func respond(ctx context.Context, w http.ResponseWriter, data Encoder) error {
 		return nil
 	}
 
+	if s, ok := data.(stream); ok {
+		return respondStream(ctx, w, s)
+	}
+
 	var statusCode = http.StatusOK
 	switch v := data.(type) {
 	case httpStatus:
@@ -57,3 +79,22 @@ func respond(ctx context.Context, w http.ResponseWriter, data Encoder) error {
 
 	return nil
 }
+
+// respondStream writes the response from a stream encoder. The server's write
+// timeout is lifted for the response, since writing a large result can take
+// longer.
+func respondStream(ctx context.Context, w http.ResponseWriter, s stream) error {
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return fmt.Errorf("respond: set write deadline: %w", err)
+	}
+
+	w.Header().Set("Content-Type", s.ContentType())
+	w.WriteHeader(http.StatusOK)
+
+	if err := s.EncodeTo(ctx, w); err != nil {
+		return fmt.Errorf("respond: %w: %w", errStreamBroken, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// StreamHandler represents a function that writes its own response, such as
+// a stream of server-sent events, instead of returning a single encoded
+// value. An error returned before anything is written is responded to like
+// the error of a Handler.
+type StreamHandler func(ctx context.Context, w http.ResponseWriter, r *http.Request) error
+
+// HandleStreamFunc sets a streaming handler function for a given HTTP method
+// and path pair to the application server mux. The application and route
+// middleware run around the handler like they do for HandleFunc.
+func (app *App) HandleStreamFunc(pattern string, streamHandler StreamHandler, mw ...Middleware) {
+	handler := func(ctx context.Context, r *http.Request) (Encoder, error) {
+		w := getWriter(ctx)
+
+		if err := streamHandler(ctx, w, r); err != nil {
+			if w.written {
+				return noResponse{}, fmt.Errorf("stream: %w", err)
+			}
+			return nil, err
+		}
+
+		return noResponse{}, nil
+	}
+
+	handler = wrapMiddleware(mw, handler)
+	handler = wrapMiddleware(app.mw, handler)
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		sw := &streamWriter{ResponseWriter: w}
+
+		app.allowOrigin(sw, r)
+
+		ctx, span := app.startSpan(sw, r, pattern)
+		defer span.End()
+
+		ctx = setWriter(ctx, sw)
+		ctx = setHeader(ctx, sw.Header())
+		ctx = setController(ctx, http.NewResponseController(sw))
+
+		resp, err := handler(ctx, r)
+		if err != nil {
+			span.RecordError(err)
+			if sw.written {
+				app.log(ctx, "stream", "ERROR", err)
+				return
+			}
+			if err := respondError(ctx, w, err); err != nil {
+				app.log(ctx, "respondError", "ERROR", err)
+			}
+			return
+		}
+
+		if err := respond(ctx, w, resp); err != nil {
+			app.log(ctx, "respond", "ERROR", err)
+		}
+	}
+
+	app.ServeMux.HandleFunc(pattern, h)
+	app.addCORS(pattern)
+}
+
+// =============================================================================
+
+// noResponse is returned by the handler wrapping a StreamHandler to tell
+// respond the response has already been written.
+type noResponse struct{}
+
+func (noResponse) Encode() ([]byte, string, error) {
+	return nil, "", nil
+}
+
+// streamWriter records whether the stream handler has started its response,
+// after which errors can no longer be responded to.
+type streamWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (sw *streamWriter) WriteHeader(statusCode int) {
+	sw.written = true
+	sw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (sw *streamWriter) Write(b []byte) (int, error) {
+	sw.written = true
+	return sw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer to flush
+// and change deadlines.
+func (sw *streamWriter) Unwrap() http.ResponseWriter {
+	return sw.ResponseWriter
+}
+
+// =============================================================================
+
+// EventStream writes server-sent events to a response.
+type EventStream struct {
+	w  http.ResponseWriter
+	rc *http.ResponseController
+}
+
+// NewEventStream starts a server-sent events response. The server's write
+// timeout is lifted for the response, since a stream stays open for as long
+// as the client listens.
+func NewEventStream(w http.ResponseWriter) (*EventStream, error) {
+	rc := http.NewResponseController(w)
+
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return nil, fmt.Errorf("set write deadline: %w", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	es := EventStream{
+		w:  w,
+		rc: rc,
+	}
+
+	if err := es.flush(); err != nil {
+		return nil, err
+	}
+
+	return &es, nil
+}
+
+// Send writes an event with the specified name, id and data. Data spanning
+// several lines is sent as several data fields, as the format requires.
+func (es *EventStream) Send(event string, id string, data []byte) error {
+	var b strings.Builder
+
+	if id != "" {
+		fmt.Fprintf(&b, "id: %s\n", id)
+	}
+	if event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event)
+	}
+	for line := range strings.SplitSeq(string(data), "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+
+	if _, err := es.w.Write([]byte(b.String())); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return es.flush()
+}
+
+// Ping writes a comment, which clients ignore, to keep idle connections and
+// proxies from timing the stream out.
+func (es *EventStream) Ping() error {
+	if _, err := es.w.Write([]byte(": ping\n\n")); err != nil {
+		return fmt.Errorf("write: %w", err)
+	}
+
+	return es.flush()
+}
+
+func (es *EventStream) flush() error {
+	if err := es.rc.Flush(); err != nil {
+		return fmt.Errorf("flush: %w", err)
+	}
+
+	return nil
+}
@@ -2,9 +2,14 @@ package web
 
 import (
 	"context"
+	"errors"
+	"io"
 	"net/http"
 
-	"github.com/google/uuid"
+	"github.com/godwinrob/harvester/foundation/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Encoder defines behavior that can encode a data model and provide
@@ -13,6 +18,20 @@ type Encoder interface {
 	Encode() (data []byte, contentType string, err error)
 }
 
+// StreamEncoder defines behavior that can encode a data model directly to the
+// response as it is produced, for results too large to encode at once, and
+// provide the content type for that encoding.
+type StreamEncoder interface {
+	ContentType() string
+	EncodeTo(ctx context.Context, w io.Writer) error
+}
+
+// Stream returns an encoder a handler can return to have the stream encoder
+// write the response.
+func Stream(se StreamEncoder) Encoder {
+	return stream{se}
+}
+
 // Handler represents a function that handles a http request within our own
 // little mini framework.
 type Handler func(context.Context, *http.Request) (Encoder, error)
@@ -26,15 +45,20 @@ type Logger func(context.Context, string, ...any)
 // data/logic on this App struct.
 type App struct {
 	*http.ServeMux
-	log Logger
-	mw  []Middleware
+	log         Logger
+	tracer      trace.Tracer
+	mw          []Middleware
+	origins     []string
+	corsRoutes  map[string]bool
+	corsMethods []string
 }
 
 // NewApp creates an App value that handle a set of routes for the application.
-func NewApp(log Logger, mw ...Middleware) *App {
+func NewApp(log Logger, tracer trace.Tracer, mw ...Middleware) *App {
 	return &App{
 		ServeMux: http.NewServeMux(),
 		log:      log,
+		tracer:   tracer,
 		mw:       mw,
 	}
 }
@@ -45,10 +69,17 @@ func (app *App) HandleFunc(pattern string, handler Handler, mw ...Middleware) {
 	handler = wrapMiddleware(app.mw, handler)
 
 	h := func(w http.ResponseWriter, r *http.Request) {
-		ctx := setTraceID(r.Context(), uuid.NewString())
+		app.allowOrigin(w, r)
+
+		ctx, span := app.startSpan(w, r, pattern)
+		defer span.End()
+
+		ctx = setHeader(ctx, w.Header())
+		ctx = setController(ctx, http.NewResponseController(w))
 
 		resp, err := handler(ctx, r)
 		if err != nil {
+			span.RecordError(err)
 			if err := respondError(ctx, w, err); err != nil {
 				app.log(ctx, "respondError", "ERROR", err)
 			}
@@ -57,8 +88,37 @@ func (app *App) HandleFunc(pattern string, handler Handler, mw ...Middleware) {
 
 		if err := respond(ctx, w, resp); err != nil {
 			app.log(ctx, "respond", "ERROR", err)
+
+			// Abort the connection so the client can tell the response
+			// is incomplete instead of taking it as the whole result.
+			if errors.Is(err, errStreamBroken) {
+				panic(http.ErrAbortHandler)
+			}
 		}
 	}
 
 	app.ServeMux.HandleFunc(pattern, h)
+	app.addCORS(pattern)
+}
+
+// startSpan starts the server span of a request, continuing the trace of a
+// W3C traceparent header when the caller sent one. The traceparent of the
+// span is echoed on the response so a caller can find the trace.
+func (app *App) startSpan(w http.ResponseWriter, r *http.Request, pattern string) (context.Context, trace.Span) {
+	var propagator propagation.TraceContext
+
+	ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+	ctx, span := app.tracer.Start(ctx, pattern,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("http.request.method", r.Method),
+			attribute.String("http.route", pattern),
+			attribute.String("url.path", r.URL.Path),
+		),
+	)
+
+	propagator.Inject(ctx, propagation.HeaderCarrier(w.Header()))
+
+	return otel.InjectTracing(ctx, app.tracer), span
 }
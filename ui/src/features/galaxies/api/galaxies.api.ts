@@ -27,7 +27,7 @@ export const galaxiesApi = {
   /**
    * Get a galaxy by name
    */
-  getByName: (name: string) => api.get<Galaxy>(`/galaxies/name/${name}`),
+  getByName: (name: string) => api.get<Galaxy>('/galaxies/lookup', { name }),
 
   /**
    * Create a new galaxy
@@ -28,9 +28,10 @@ export const resourcesApi = {
   get: (id: string) => api.get<Resource>(`/resources/${id}`),
 
   /**
-   * Get a resource by name
+   * Get a resource by name within a galaxy
    */
-  getByName: (name: string) => api.get<Resource>(`/resources/name/${name}`),
+  getByName: (galaxyId: string, name: string) =>
+    api.get<Resource>(`/resources/name/${name}`, { galaxy_id: galaxyId }),
 
   /**
    * Create a new resource